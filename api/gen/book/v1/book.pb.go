@@ -0,0 +1,928 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: book/v1/book.proto
+
+package bookv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StockStatus は在庫ステータスです。
+type StockStatus int32
+
+const (
+	StockStatus_STOCK_STATUS_UNSPECIFIED  StockStatus = 0
+	StockStatus_STOCK_STATUS_IN_STOCK     StockStatus = 1
+	StockStatus_STOCK_STATUS_LOW_STOCK    StockStatus = 2
+	StockStatus_STOCK_STATUS_OUT_OF_STOCK StockStatus = 3
+)
+
+// Enum value maps for StockStatus.
+var (
+	StockStatus_name = map[int32]string{
+		0: "STOCK_STATUS_UNSPECIFIED",
+		1: "STOCK_STATUS_IN_STOCK",
+		2: "STOCK_STATUS_LOW_STOCK",
+		3: "STOCK_STATUS_OUT_OF_STOCK",
+	}
+	StockStatus_value = map[string]int32{
+		"STOCK_STATUS_UNSPECIFIED":  0,
+		"STOCK_STATUS_IN_STOCK":     1,
+		"STOCK_STATUS_LOW_STOCK":    2,
+		"STOCK_STATUS_OUT_OF_STOCK": 3,
+	}
+)
+
+func (x StockStatus) Enum() *StockStatus {
+	p := new(StockStatus)
+	*p = x
+	return p
+}
+
+func (x StockStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_book_v1_book_proto_enumTypes[0].Descriptor()
+}
+
+func (StockStatus) Type() protoreflect.EnumType {
+	return &file_book_v1_book_proto_enumTypes[0]
+}
+
+func (x StockStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockStatus.Descriptor instead.
+func (StockStatus) EnumDescriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{0}
+}
+
+// BookFormat は書籍の形態です。
+type BookFormat int32
+
+const (
+	BookFormat_BOOK_FORMAT_UNSPECIFIED BookFormat = 0
+	BookFormat_BOOK_FORMAT_HARDCOVER   BookFormat = 1
+	BookFormat_BOOK_FORMAT_PAPERBACK   BookFormat = 2
+	BookFormat_BOOK_FORMAT_EBOOK       BookFormat = 3
+)
+
+// Enum value maps for BookFormat.
+var (
+	BookFormat_name = map[int32]string{
+		0: "BOOK_FORMAT_UNSPECIFIED",
+		1: "BOOK_FORMAT_HARDCOVER",
+		2: "BOOK_FORMAT_PAPERBACK",
+		3: "BOOK_FORMAT_EBOOK",
+	}
+	BookFormat_value = map[string]int32{
+		"BOOK_FORMAT_UNSPECIFIED": 0,
+		"BOOK_FORMAT_HARDCOVER":   1,
+		"BOOK_FORMAT_PAPERBACK":   2,
+		"BOOK_FORMAT_EBOOK":       3,
+	}
+)
+
+func (x BookFormat) Enum() *BookFormat {
+	p := new(BookFormat)
+	*p = x
+	return p
+}
+
+func (x BookFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BookFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_book_v1_book_proto_enumTypes[1].Descriptor()
+}
+
+func (BookFormat) Type() protoreflect.EnumType {
+	return &file_book_v1_book_proto_enumTypes[1]
+}
+
+func (x BookFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BookFormat.Descriptor instead.
+func (BookFormat) EnumDescriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{1}
+}
+
+// Author は著者です。
+type Author struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Author) Reset() {
+	*x = Author{}
+	mi := &file_book_v1_book_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Author) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Author) ProtoMessage() {}
+
+func (x *Author) ProtoReflect() protoreflect.Message {
+	mi := &file_book_v1_book_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Author.ProtoReflect.Descriptor instead.
+func (*Author) Descriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Author) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Author) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Publisher は出版社です。
+type Publisher struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Publisher) Reset() {
+	*x = Publisher{}
+	mi := &file_book_v1_book_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Publisher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Publisher) ProtoMessage() {}
+
+func (x *Publisher) ProtoReflect() protoreflect.Message {
+	mi := &file_book_v1_book_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Publisher.ProtoReflect.Descriptor instead.
+func (*Publisher) Descriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Publisher) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Publisher) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Category はカテゴリ（ジャンル）です。
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_book_v1_book_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_book_v1_book_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Book は書籍情報です。
+// 書誌情報（authors以降）は、不明な場合は既定値（authorsは空、publisherは未設定、formatはBOOK_FORMAT_UNSPECIFIED）です。
+type Book struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Isbn              string                 `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Title             string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	PriceAmount       float64                `protobuf:"fixed64,3,opt,name=price_amount,json=priceAmount,proto3" json:"price_amount,omitempty"`
+	QuantityAvailable int32                  `protobuf:"varint,4,opt,name=quantity_available,json=quantityAvailable,proto3" json:"quantity_available,omitempty"`
+	Status            StockStatus            `protobuf:"varint,5,opt,name=status,proto3,enum=book.v1.StockStatus" json:"status,omitempty"`
+	Subtitle          string                 `protobuf:"bytes,6,opt,name=subtitle,proto3" json:"subtitle,omitempty"`
+	// authors は記載順（筆頭著者が先頭）です。
+	Authors   []*Author  `protobuf:"bytes,7,rep,name=authors,proto3" json:"authors,omitempty"`
+	Publisher *Publisher `protobuf:"bytes,8,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	// publication_date は出版日（YYYY-MM-DD）です。
+	PublicationDate string `protobuf:"bytes,9,opt,name=publication_date,json=publicationDate,proto3" json:"publication_date,omitempty"`
+	// language は本文の言語（ISO 639の言語コード）です。
+	Language  string     `protobuf:"bytes,10,opt,name=language,proto3" json:"language,omitempty"`
+	PageCount int32      `protobuf:"varint,11,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	Format    BookFormat `protobuf:"varint,12,opt,name=format,proto3,enum=book.v1.BookFormat" json:"format,omitempty"`
+	Edition   int32      `protobuf:"varint,13,opt,name=edition,proto3" json:"edition,omitempty"`
+	// categories は割り当てられたカテゴリ（カテゴリ名順）です。割り当てはHTTP API（PUT /books/{isbn}/categories）で行います。
+	Categories    []*Category `protobuf:"bytes,14,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	mi := &file_book_v1_book_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_book_v1_book_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Book) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetPriceAmount() float64 {
+	if x != nil {
+		return x.PriceAmount
+	}
+	return 0
+}
+
+func (x *Book) GetQuantityAvailable() int32 {
+	if x != nil {
+		return x.QuantityAvailable
+	}
+	return 0
+}
+
+func (x *Book) GetStatus() StockStatus {
+	if x != nil {
+		return x.Status
+	}
+	return StockStatus_STOCK_STATUS_UNSPECIFIED
+}
+
+func (x *Book) GetSubtitle() string {
+	if x != nil {
+		return x.Subtitle
+	}
+	return ""
+}
+
+func (x *Book) GetAuthors() []*Author {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *Book) GetPublisher() *Publisher {
+	if x != nil {
+		return x.Publisher
+	}
+	return nil
+}
+
+func (x *Book) GetPublicationDate() string {
+	if x != nil {
+		return x.PublicationDate
+	}
+	return ""
+}
+
+func (x *Book) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Book) GetPageCount() int32 {
+	if x != nil {
+		return x.PageCount
+	}
+	return 0
+}
+
+func (x *Book) GetFormat() BookFormat {
+	if x != nil {
+		return x.Format
+	}
+	return BookFormat_BOOK_FORMAT_UNSPECIFIED
+}
+
+func (x *Book) GetEdition() int32 {
+	if x != nil {
+		return x.Edition
+	}
+	return 0
+}
+
+func (x *Book) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// RegisterBookRequest は書籍の登録リクエストです。
+// 書誌情報（author_ids以降）はHTTP API（POST /books）と同じ規則で検証し、省略した項目は不明として登録します。
+type RegisterBookRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Isbn     string                 `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	Title    string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Price    float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Subtitle string                 `protobuf:"bytes,4,opt,name=subtitle,proto3" json:"subtitle,omitempty"`
+	// author_ids は登録済みの著者のIDです（記載順）。
+	AuthorIds []string `protobuf:"bytes,5,rep,name=author_ids,json=authorIds,proto3" json:"author_ids,omitempty"`
+	// publisher_id は登録済みの出版社のIDです。
+	PublisherId     string     `protobuf:"bytes,6,opt,name=publisher_id,json=publisherId,proto3" json:"publisher_id,omitempty"`
+	PublicationDate string     `protobuf:"bytes,7,opt,name=publication_date,json=publicationDate,proto3" json:"publication_date,omitempty"`
+	Language        string     `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
+	PageCount       int32      `protobuf:"varint,9,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	Format          BookFormat `protobuf:"varint,10,opt,name=format,proto3,enum=book.v1.BookFormat" json:"format,omitempty"`
+	Edition         int32      `protobuf:"varint,11,opt,name=edition,proto3" json:"edition,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RegisterBookRequest) Reset() {
+	*x = RegisterBookRequest{}
+	mi := &file_book_v1_book_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterBookRequest) ProtoMessage() {}
+
+func (x *RegisterBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_v1_book_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterBookRequest.ProtoReflect.Descriptor instead.
+func (*RegisterBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RegisterBookRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *RegisterBookRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RegisterBookRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *RegisterBookRequest) GetSubtitle() string {
+	if x != nil {
+		return x.Subtitle
+	}
+	return ""
+}
+
+func (x *RegisterBookRequest) GetAuthorIds() []string {
+	if x != nil {
+		return x.AuthorIds
+	}
+	return nil
+}
+
+func (x *RegisterBookRequest) GetPublisherId() string {
+	if x != nil {
+		return x.PublisherId
+	}
+	return ""
+}
+
+func (x *RegisterBookRequest) GetPublicationDate() string {
+	if x != nil {
+		return x.PublicationDate
+	}
+	return ""
+}
+
+func (x *RegisterBookRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *RegisterBookRequest) GetPageCount() int32 {
+	if x != nil {
+		return x.PageCount
+	}
+	return 0
+}
+
+func (x *RegisterBookRequest) GetFormat() BookFormat {
+	if x != nil {
+		return x.Format
+	}
+	return BookFormat_BOOK_FORMAT_UNSPECIFIED
+}
+
+func (x *RegisterBookRequest) GetEdition() int32 {
+	if x != nil {
+		return x.Edition
+	}
+	return 0
+}
+
+type GetBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Isbn          string                 `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	mi := &file_book_v1_book_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_v1_book_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetBookRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+type AdjustStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Isbn  string                 `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	// delta は在庫数の増減量です。正の値で入荷、負の値で出荷を表します。
+	Delta         int32 `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockRequest) Reset() {
+	*x = AdjustStockRequest{}
+	mi := &file_book_v1_book_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockRequest) ProtoMessage() {}
+
+func (x *AdjustStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_v1_book_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockRequest) Descriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AdjustStockRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+func (x *AdjustStockRequest) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type WatchBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Isbn          string                 `protobuf:"bytes,1,opt,name=isbn,proto3" json:"isbn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBookRequest) Reset() {
+	*x = WatchBookRequest{}
+	mi := &file_book_v1_book_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBookRequest) ProtoMessage() {}
+
+func (x *WatchBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_book_v1_book_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBookRequest.ProtoReflect.Descriptor instead.
+func (*WatchBookRequest) Descriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WatchBookRequest) GetIsbn() string {
+	if x != nil {
+		return x.Isbn
+	}
+	return ""
+}
+
+type WatchBookResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_name は変更の契機となったドメインイベント名です。最初の送信では空です。
+	EventName string `protobuf:"bytes,1,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
+	// book は変更後の書籍情報です。書籍が削除された場合は設定されません。
+	Book          *Book                  `protobuf:"bytes,2,opt,name=book,proto3" json:"book,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBookResponse) Reset() {
+	*x = WatchBookResponse{}
+	mi := &file_book_v1_book_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBookResponse) ProtoMessage() {}
+
+func (x *WatchBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_book_v1_book_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBookResponse.ProtoReflect.Descriptor instead.
+func (*WatchBookResponse) Descriptor() ([]byte, []int) {
+	return file_book_v1_book_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchBookResponse) GetEventName() string {
+	if x != nil {
+		return x.EventName
+	}
+	return ""
+}
+
+func (x *WatchBookResponse) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+func (x *WatchBookResponse) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_book_v1_book_proto protoreflect.FileDescriptor
+
+const file_book_v1_book_proto_rawDesc = "" +
+	"\n" +
+	"\x12book/v1/book.proto\x12\abook.v1\x1a\x1fgoogle/protobuf/timestamp.proto\",\n" +
+	"\x06Author\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"/\n" +
+	"\tPublisher\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\".\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x89\x04\n" +
+	"\x04Book\x12\x12\n" +
+	"\x04isbn\x18\x01 \x01(\tR\x04isbn\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12!\n" +
+	"\fprice_amount\x18\x03 \x01(\x01R\vpriceAmount\x12-\n" +
+	"\x12quantity_available\x18\x04 \x01(\x05R\x11quantityAvailable\x12,\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x14.book.v1.StockStatusR\x06status\x12\x1a\n" +
+	"\bsubtitle\x18\x06 \x01(\tR\bsubtitle\x12)\n" +
+	"\aauthors\x18\a \x03(\v2\x0f.book.v1.AuthorR\aauthors\x120\n" +
+	"\tpublisher\x18\b \x01(\v2\x12.book.v1.PublisherR\tpublisher\x12)\n" +
+	"\x10publication_date\x18\t \x01(\tR\x0fpublicationDate\x12\x1a\n" +
+	"\blanguage\x18\n" +
+	" \x01(\tR\blanguage\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\v \x01(\x05R\tpageCount\x12+\n" +
+	"\x06format\x18\f \x01(\x0e2\x13.book.v1.BookFormatR\x06format\x12\x18\n" +
+	"\aedition\x18\r \x01(\x05R\aedition\x121\n" +
+	"\n" +
+	"categories\x18\x0e \x03(\v2\x11.book.v1.CategoryR\n" +
+	"categories\"\xe0\x02\n" +
+	"\x13RegisterBookRequest\x12\x12\n" +
+	"\x04isbn\x18\x01 \x01(\tR\x04isbn\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bsubtitle\x18\x04 \x01(\tR\bsubtitle\x12\x1d\n" +
+	"\n" +
+	"author_ids\x18\x05 \x03(\tR\tauthorIds\x12!\n" +
+	"\fpublisher_id\x18\x06 \x01(\tR\vpublisherId\x12)\n" +
+	"\x10publication_date\x18\a \x01(\tR\x0fpublicationDate\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\x12\x1d\n" +
+	"\n" +
+	"page_count\x18\t \x01(\x05R\tpageCount\x12+\n" +
+	"\x06format\x18\n" +
+	" \x01(\x0e2\x13.book.v1.BookFormatR\x06format\x12\x18\n" +
+	"\aedition\x18\v \x01(\x05R\aedition\"$\n" +
+	"\x0eGetBookRequest\x12\x12\n" +
+	"\x04isbn\x18\x01 \x01(\tR\x04isbn\">\n" +
+	"\x12AdjustStockRequest\x12\x12\n" +
+	"\x04isbn\x18\x01 \x01(\tR\x04isbn\x12\x14\n" +
+	"\x05delta\x18\x02 \x01(\x05R\x05delta\"&\n" +
+	"\x10WatchBookRequest\x12\x12\n" +
+	"\x04isbn\x18\x01 \x01(\tR\x04isbn\"\x92\x01\n" +
+	"\x11WatchBookResponse\x12\x1d\n" +
+	"\n" +
+	"event_name\x18\x01 \x01(\tR\teventName\x12!\n" +
+	"\x04book\x18\x02 \x01(\v2\r.book.v1.BookR\x04book\x12;\n" +
+	"\voccurred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt*\x81\x01\n" +
+	"\vStockStatus\x12\x1c\n" +
+	"\x18STOCK_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15STOCK_STATUS_IN_STOCK\x10\x01\x12\x1a\n" +
+	"\x16STOCK_STATUS_LOW_STOCK\x10\x02\x12\x1d\n" +
+	"\x19STOCK_STATUS_OUT_OF_STOCK\x10\x03*v\n" +
+	"\n" +
+	"BookFormat\x12\x1b\n" +
+	"\x17BOOK_FORMAT_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15BOOK_FORMAT_HARDCOVER\x10\x01\x12\x19\n" +
+	"\x15BOOK_FORMAT_PAPERBACK\x10\x02\x12\x15\n" +
+	"\x11BOOK_FORMAT_EBOOK\x10\x032\xfe\x01\n" +
+	"\vBookService\x12;\n" +
+	"\fRegisterBook\x12\x1c.book.v1.RegisterBookRequest\x1a\r.book.v1.Book\x121\n" +
+	"\aGetBook\x12\x17.book.v1.GetBookRequest\x1a\r.book.v1.Book\x129\n" +
+	"\vAdjustStock\x12\x1b.book.v1.AdjustStockRequest\x1a\r.book.v1.Book\x12D\n" +
+	"\tWatchBook\x12\x19.book.v1.WatchBookRequest\x1a\x1a.book.v1.WatchBookResponse0\x01B(Z&ddd-hands-on-go/api/gen/book/v1;bookv1b\x06proto3"
+
+var (
+	file_book_v1_book_proto_rawDescOnce sync.Once
+	file_book_v1_book_proto_rawDescData []byte
+)
+
+func file_book_v1_book_proto_rawDescGZIP() []byte {
+	file_book_v1_book_proto_rawDescOnce.Do(func() {
+		file_book_v1_book_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_book_v1_book_proto_rawDesc), len(file_book_v1_book_proto_rawDesc)))
+	})
+	return file_book_v1_book_proto_rawDescData
+}
+
+var file_book_v1_book_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_book_v1_book_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_book_v1_book_proto_goTypes = []any{
+	(StockStatus)(0),              // 0: book.v1.StockStatus
+	(BookFormat)(0),               // 1: book.v1.BookFormat
+	(*Author)(nil),                // 2: book.v1.Author
+	(*Publisher)(nil),             // 3: book.v1.Publisher
+	(*Category)(nil),              // 4: book.v1.Category
+	(*Book)(nil),                  // 5: book.v1.Book
+	(*RegisterBookRequest)(nil),   // 6: book.v1.RegisterBookRequest
+	(*GetBookRequest)(nil),        // 7: book.v1.GetBookRequest
+	(*AdjustStockRequest)(nil),    // 8: book.v1.AdjustStockRequest
+	(*WatchBookRequest)(nil),      // 9: book.v1.WatchBookRequest
+	(*WatchBookResponse)(nil),     // 10: book.v1.WatchBookResponse
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+}
+var file_book_v1_book_proto_depIdxs = []int32{
+	0,  // 0: book.v1.Book.status:type_name -> book.v1.StockStatus
+	2,  // 1: book.v1.Book.authors:type_name -> book.v1.Author
+	3,  // 2: book.v1.Book.publisher:type_name -> book.v1.Publisher
+	1,  // 3: book.v1.Book.format:type_name -> book.v1.BookFormat
+	4,  // 4: book.v1.Book.categories:type_name -> book.v1.Category
+	1,  // 5: book.v1.RegisterBookRequest.format:type_name -> book.v1.BookFormat
+	5,  // 6: book.v1.WatchBookResponse.book:type_name -> book.v1.Book
+	11, // 7: book.v1.WatchBookResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	6,  // 8: book.v1.BookService.RegisterBook:input_type -> book.v1.RegisterBookRequest
+	7,  // 9: book.v1.BookService.GetBook:input_type -> book.v1.GetBookRequest
+	8,  // 10: book.v1.BookService.AdjustStock:input_type -> book.v1.AdjustStockRequest
+	9,  // 11: book.v1.BookService.WatchBook:input_type -> book.v1.WatchBookRequest
+	5,  // 12: book.v1.BookService.RegisterBook:output_type -> book.v1.Book
+	5,  // 13: book.v1.BookService.GetBook:output_type -> book.v1.Book
+	5,  // 14: book.v1.BookService.AdjustStock:output_type -> book.v1.Book
+	10, // 15: book.v1.BookService.WatchBook:output_type -> book.v1.WatchBookResponse
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_book_v1_book_proto_init() }
+func file_book_v1_book_proto_init() {
+	if File_book_v1_book_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_book_v1_book_proto_rawDesc), len(file_book_v1_book_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_book_v1_book_proto_goTypes,
+		DependencyIndexes: file_book_v1_book_proto_depIdxs,
+		EnumInfos:         file_book_v1_book_proto_enumTypes,
+		MessageInfos:      file_book_v1_book_proto_msgTypes,
+	}.Build()
+	File_book_v1_book_proto = out.File
+	file_book_v1_book_proto_goTypes = nil
+	file_book_v1_book_proto_depIdxs = nil
+}
@@ -0,0 +1,9 @@
+// Package api はHTTP APIの契約（OpenAPI仕様）を提供します。
+package api
+
+import _ "embed"
+
+// OpenAPISpec はBookHandlerの全ルートを記述したOpenAPI 3ドキュメントです。
+//
+//go:embed openapi.json
+var OpenAPISpec []byte
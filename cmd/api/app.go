@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"ddd-hands-on-go/cmd/api/grpcserver"
+	"ddd-hands-on-go/internal/application/idempotency"
+	"ddd-hands-on-go/internal/config"
+	"ddd-hands-on-go/internal/infrastructure/event"
+	"ddd-hands-on-go/internal/logging"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+)
+
+// App は依存関係を解決済みのアプリケーション全体です。InitializeApp などのインジェクターで生成します。
+type App struct {
+	Config     *config.Config
+	Logger     *slog.Logger
+	HTTPServer *http.Server
+	// GRPCServer はgRPCが無効な場合はnilです。
+	GRPCServer   *grpc.Server
+	BookWatcher  *grpcserver.BookWatcher
+	EventEmitter *event.EventEmitter
+	// TracerProvider はトレースを出力しない場合はnilです。
+	TracerProvider *sdktrace.TracerProvider
+	Idempotency    *idempotency.Service
+}
+
+// Run はサーバーを起動し、ctxが終了するか、いずれかのサーバーの起動に失敗すると停止処理を行ってから戻ります。
+func (a *App) Run(ctx context.Context) error {
+	// 起動に失敗したサーバーのエラーはserveErrで受け取り、他のサーバーも停止する
+	serveErr := make(chan error, 2)
+
+	if a.GRPCServer != nil {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", a.Config.Server.GRPCPort))
+		if err != nil {
+			return fmt.Errorf("gRPCリスナーの作成エラー: %w", err)
+		}
+		go func() {
+			a.Logger.Info("gRPCサーバーを起動しています", "port", a.Config.Server.GRPCPort)
+			if err := a.GRPCServer.Serve(lis); err != nil {
+				serveErr <- fmt.Errorf("gRPCサーバー起動エラー: %w", err)
+			}
+		}()
+	}
+
+	go a.Idempotency.RunPurger(logging.WithLogger(ctx, a.Logger), a.Config.Idempotency.PurgeInterval)
+
+	go func() {
+		a.Logger.Info("HTTPサーバーを起動しています", "port", a.Config.Server.HTTPPort)
+		if err := a.HTTPServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("サーバー起動エラー: %w", err)
+		}
+	}()
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		a.Logger.Info("停止シグナルを受信しました。処理中のリクエストの完了を待っています", "timeout", a.Config.Server.ShutdownTimeout)
+	case runErr = <-serveErr:
+		a.Logger.Error("サーバーを停止しています", "error", runErr)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.Config.Server.ShutdownTimeout)
+	defer cancel()
+	if err := a.shutdown(shutdownCtx); err != nil {
+		return errors.Join(runErr, fmt.Errorf("停止処理が完了しませんでした: %w", err))
+	}
+	if runErr != nil {
+		return runErr
+	}
+	a.Logger.Info("サーバーを停止しました")
+	return nil
+}
+
+// shutdown は新しいリクエストの受け付けを止め、処理中のリクエストと非同期のイベントリスナーの完了を待ちます。
+// ctxの期限を過ぎた場合は、残っている接続を強制的に閉じてエラーを返します。
+// データベースなどのリソースはインジェクターが返すクリーンアップ関数で解放します。
+func (a *App) shutdown(ctx context.Context) error {
+	var errs []error
+
+	if err := a.HTTPServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("HTTPサーバー: %w", err))
+		_ = a.HTTPServer.Close()
+	}
+
+	if a.GRPCServer != nil {
+		// WatchBookのストリームはクライアントが切断するまで終わらないため、先に監視を終了させる
+		a.BookWatcher.Close()
+		stopped := make(chan struct{})
+		go func() {
+			a.GRPCServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			a.GRPCServer.Stop()
+			errs = append(errs, fmt.Errorf("gRPCサーバー: %w", ctx.Err()))
+		}
+	}
+
+	// リクエストの処理で発行されたイベントのリスナーが、データベースを閉じる前に終わるのを待つ
+	if err := a.EventEmitter.Drain(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("イベントリスナー: %w", err))
+	}
+
+	// イベントリスナーのスパンまで含めて、送信待ちのスパンを出力する
+	if a.TracerProvider != nil {
+		if err := a.TracerProvider.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("トレース: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
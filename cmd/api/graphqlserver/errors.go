@@ -0,0 +1,60 @@
+package graphqlserver
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/auth"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+)
+
+// resolverError はextensions.codeにエラーの種類を含めるGraphQLのエラーです。
+type resolverError struct {
+	message string
+	code    string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+// Extensions はGraphQLレスポンスのextensionsに含める値を返します。
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// toResolverError はドメインエラーの種類に応じたコードを持つエラーへ変換します。
+// HTTPハンドラー・gRPCサーバーの対応付けと揃えています。
+// メッセージはkeyのメッセージに原因のエラーのメッセージを続けたもので、コンテキストの言語（Accept-Language）で記述します。
+func toResolverError(ctx context.Context, key i18n.Key, err error) error {
+	code := codeFromError(err)
+	if code == "INTERNAL" {
+		logging.FromContext(ctx).Error(i18n.Message(i18n.Default, key), "error", err)
+	}
+	locale := i18n.FromContext(ctx)
+	return &resolverError{message: i18n.Message(locale, key) + ": " + i18n.ErrorMessage(locale, err), code: code}
+}
+
+func codeFromError(err error) string {
+	switch shared.KindOf(err) {
+	case shared.KindInvalid:
+		return "BAD_USER_INPUT"
+	case shared.KindNotFound:
+		return "NOT_FOUND"
+	case shared.KindConflict:
+		return "CONFLICT"
+	default:
+		return "INTERNAL"
+	}
+}
+
+// authorize はアクターがroleを必要とするミューテーションを実行できるかを確認します。
+// POST /graphql 自体はviewerで利用できるため、更新系の操作はリゾルバーごとにロールを確認します。
+// 認証が無効な場合（コンテキストにアクターがいない場合）は確認しません。
+func authorize(ctx context.Context, role auth.Role) error {
+	p := auth.FromContext(ctx)
+	if p == nil || p.Can(role) {
+		return nil
+	}
+	return &resolverError{message: i18n.Message(i18n.FromContext(ctx), i18n.MsgRoleRequired, role), code: "FORBIDDEN"}
+}
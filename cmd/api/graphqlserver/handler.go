@@ -0,0 +1,27 @@
+package graphqlserver
+
+import (
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+// Handler はGraphQLのリクエストを処理するHTTPハンドラーです。
+type Handler struct {
+	resolver *Resolver
+	relay    *relay.Handler
+}
+
+// NewHandler は新しいHandlerを生成します。
+func NewHandler(schema *graphql.Schema, resolver *Resolver) *Handler {
+	return &Handler{resolver: resolver, relay: &relay.Handler{Schema: schema}}
+}
+
+// ServeHTTP はリクエストごとのローダーを用意してGraphQLのクエリを実行します。
+// エラーのメッセージは、middleware.Localeがリクエストのコンテキストに格納した言語（Accept-Language）で記述します。
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := withBookLoader(r.Context(), h.resolver.getBookService)
+	ctx = withStockHistoryLoader(ctx, h.resolver.stockHistoryService)
+	h.relay.ServeHTTP(w, r.WithContext(ctx))
+}
@@ -0,0 +1,102 @@
+package graphqlserver
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/application/book"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+type loaderKey struct{}
+
+type stockHistoryLoaderKey struct{}
+
+// bookLoader はISBNをキーに書籍情報をまとめて取得するローダーです。
+type bookLoader = dataloader.Interface[string, *book.BookDTO]
+
+// batchWait は同一リクエスト内のbookフィールドの読み込みをまとめるために待機する時間です。
+const batchWait = 2 * time.Millisecond
+
+// newBookLoader はGetBookApplicationService.ExecuteBatchでまとめて取得するローダーを生成します。
+func newBookLoader(getBookService *book.GetBookApplicationService) bookLoader {
+	return dataloader.NewBatchedLoader(func(ctx context.Context, isbns []string) []*dataloader.Result[*book.BookDTO] {
+		results := make([]*dataloader.Result[*book.BookDTO], len(isbns))
+
+		dtos, err := getBookService.ExecuteBatch(ctx, isbns)
+		for i, isbn := range isbns {
+			if err != nil {
+				results[i] = &dataloader.Result[*book.BookDTO]{Error: err}
+				continue
+			}
+			results[i] = &dataloader.Result[*book.BookDTO]{Data: dtos[isbn]}
+		}
+		return results
+	}, dataloader.WithWait[string, *book.BookDTO](batchWait))
+}
+
+// withBookLoader はリクエストごとのローダーをコンテキストに格納します。
+// ローダーはキャッシュを持つため、リクエストをまたいで共有してはいけません。
+func withBookLoader(ctx context.Context, getBookService *book.GetBookApplicationService) context.Context {
+	return context.WithValue(ctx, loaderKey{}, newBookLoader(getBookService))
+}
+
+// bookLoaderFrom はコンテキストからローダーを取得します。
+// 格納されていない場合（ハンドラーを経由しない実行）は、その場で生成します。
+func bookLoaderFrom(ctx context.Context, getBookService *book.GetBookApplicationService) bookLoader {
+	if l, ok := ctx.Value(loaderKey{}).(bookLoader); ok {
+		return l
+	}
+	return newBookLoader(getBookService)
+}
+
+// stockHistoryKey は在庫数の変更履歴の読み込みのキーです。書籍ごとに取得件数を指定できるため、件数もキーに含めます。
+type stockHistoryKey struct {
+	isbn  string
+	limit int
+}
+
+// stockHistoryLoader は書籍ごとの在庫数の変更履歴をまとめて取得するローダーです。
+type stockHistoryLoader = dataloader.Interface[stockHistoryKey, []*book.StockHistoryEntryDTO]
+
+// newStockHistoryLoader はListStockHistoryApplicationService.ExecuteBatchでまとめて取得するローダーを生成します。
+// 取得件数の異なるキーは、取得件数ごとに分けて取得します。
+func newStockHistoryLoader(stockHistoryService *book.ListStockHistoryApplicationService) stockHistoryLoader {
+	return dataloader.NewBatchedLoader(func(ctx context.Context, keys []stockHistoryKey) []*dataloader.Result[[]*book.StockHistoryEntryDTO] {
+		results := make([]*dataloader.Result[[]*book.StockHistoryEntryDTO], len(keys))
+
+		indexesByLimit := make(map[int][]int)
+		for i, key := range keys {
+			indexesByLimit[key.limit] = append(indexesByLimit[key.limit], i)
+		}
+		for limit, indexes := range indexesByLimit {
+			isbns := make([]string, len(indexes))
+			for j, i := range indexes {
+				isbns[j] = keys[i].isbn
+			}
+			history, err := stockHistoryService.ExecuteBatch(ctx, isbns, limit)
+			for _, i := range indexes {
+				if err != nil {
+					results[i] = &dataloader.Result[[]*book.StockHistoryEntryDTO]{Error: err}
+					continue
+				}
+				results[i] = &dataloader.Result[[]*book.StockHistoryEntryDTO]{Data: history[keys[i].isbn]}
+			}
+		}
+		return results
+	}, dataloader.WithWait[stockHistoryKey, []*book.StockHistoryEntryDTO](batchWait))
+}
+
+// withStockHistoryLoader はリクエストごとの在庫数の変更履歴のローダーをコンテキストに格納します。
+func withStockHistoryLoader(ctx context.Context, stockHistoryService *book.ListStockHistoryApplicationService) context.Context {
+	return context.WithValue(ctx, stockHistoryLoaderKey{}, newStockHistoryLoader(stockHistoryService))
+}
+
+// stockHistoryLoaderFrom はコンテキストから在庫数の変更履歴のローダーを取得します。
+// 格納されていない場合（ハンドラーを経由しない実行）は、その場で生成します。
+func stockHistoryLoaderFrom(ctx context.Context, stockHistoryService *book.ListStockHistoryApplicationService) stockHistoryLoader {
+	if l, ok := ctx.Value(stockHistoryLoaderKey{}).(stockHistoryLoader); ok {
+		return l
+	}
+	return newStockHistoryLoader(stockHistoryService)
+}
@@ -0,0 +1,346 @@
+// Package graphqlserver はGraphQLのプレゼンテーション層を実装します。
+// HTTP/gRPCと同じアプリケーションサービスを利用します。
+package graphqlserver
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/auth"
+	"ddd-hands-on-go/internal/i18n"
+	_ "embed"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+// Resolver はGraphQLスキーマのルートリゾルバーです。
+type Resolver struct {
+	registerBookService *book.RegisterBookApplicationService
+	getBookService      *book.GetBookApplicationService
+	listBooksService    *book.ListBooksApplicationService
+	adjustStockService  *book.AdjustStockApplicationService
+	stockHistoryService *book.ListStockHistoryApplicationService
+}
+
+// NewResolver は新しいResolverを生成します。
+func NewResolver(
+	registerBookService *book.RegisterBookApplicationService,
+	getBookService *book.GetBookApplicationService,
+	listBooksService *book.ListBooksApplicationService,
+	adjustStockService *book.AdjustStockApplicationService,
+	stockHistoryService *book.ListStockHistoryApplicationService,
+) *Resolver {
+	return &Resolver{
+		registerBookService: registerBookService,
+		getBookService:      getBookService,
+		listBooksService:    listBooksService,
+		adjustStockService:  adjustStockService,
+		stockHistoryService: stockHistoryService,
+	}
+}
+
+// NewSchema はリゾルバーを結び付けたGraphQLスキーマを生成します。
+func NewSchema(resolver *Resolver) (*graphql.Schema, error) {
+	return graphql.ParseSchema(schemaString, resolver)
+}
+
+// Book はISBNを指定して書籍を取得します。
+// 同一リクエスト内の複数のbookフィールドはまとめて1回のクエリで取得されます。
+func (r *Resolver) Book(ctx context.Context, args struct{ Isbn string }) (*BookResolver, error) {
+	dto, err := bookLoaderFrom(ctx, r.getBookService).Load(ctx, args.Isbn)()
+	if err != nil {
+		return nil, toResolverError(ctx, i18n.MsgGetBookFailed, err)
+	}
+	if dto == nil {
+		return nil, nil
+	}
+	return r.bookResolver(dto), nil
+}
+
+// Books は書籍の一覧を取得します。
+func (r *Resolver) Books(ctx context.Context, args struct {
+	First      int32
+	Offset     int32
+	Status     *string
+	CategoryId *graphql.ID
+	Sort       string
+}) ([]*BookResolver, error) {
+	query := book.ListBooksQuery{
+		Sort:   book.ListBooksSort(strings.ToLower(args.Sort)),
+		Limit:  int(args.First),
+		Offset: int(args.Offset),
+	}
+	if args.Status != nil {
+		query.Status = *args.Status
+	}
+	if args.CategoryId != nil {
+		query.CategoryId = string(*args.CategoryId)
+	}
+
+	dtos, err := r.listBooksService.Execute(ctx, query)
+	if err != nil {
+		return nil, toResolverError(ctx, i18n.MsgListBooksFailed, err)
+	}
+
+	resolvers := make([]*BookResolver, len(dtos))
+	for i, dto := range dtos {
+		resolvers[i] = r.bookResolver(dto)
+	}
+	return resolvers, nil
+}
+
+// RegisterBookInput は書籍登録の入力です。
+type RegisterBookInput struct {
+	Isbn            string
+	Title           string
+	Subtitle        *string
+	Price           float64
+	AuthorIds       *[]graphql.ID
+	PublisherId     *graphql.ID
+	PublicationDate *string
+	Language        *string
+	PageCount       *int32
+	Format          *string
+	Edition         *int32
+}
+
+// RegisterBook は書籍を登録し、登録後の書籍を返します。
+func (r *Resolver) RegisterBook(ctx context.Context, args struct{ Input RegisterBookInput }) (*BookResolver, error) {
+	if err := authorize(ctx, auth.RoleEditor); err != nil {
+		return nil, err
+	}
+	cmd := book.RegisterBookCommand{
+		ISBN:        args.Input.Isbn,
+		Title:       args.Input.Title,
+		PriceAmount: args.Input.Price,
+	}
+	in := args.Input
+	if in.Subtitle != nil {
+		cmd.Subtitle = *in.Subtitle
+	}
+	if in.AuthorIds != nil {
+		for _, id := range *in.AuthorIds {
+			cmd.Metadata.AuthorIds = append(cmd.Metadata.AuthorIds, string(id))
+		}
+	}
+	if in.PublisherId != nil {
+		cmd.Metadata.PublisherId = string(*in.PublisherId)
+	}
+	if in.PublicationDate != nil {
+		cmd.Metadata.PublicationDate = *in.PublicationDate
+	}
+	if in.Language != nil {
+		cmd.Metadata.Language = *in.Language
+	}
+	if in.PageCount != nil {
+		cmd.Metadata.PageCount = int(*in.PageCount)
+	}
+	if in.Format != nil {
+		cmd.Metadata.Format = *in.Format
+	}
+	if in.Edition != nil {
+		cmd.Metadata.Edition = int(*in.Edition)
+	}
+	dto, err := r.registerBookService.Execute(ctx, cmd)
+	if err != nil {
+		return nil, toResolverError(ctx, i18n.MsgRegisterBookFailed, err)
+	}
+	return r.bookResolver(dto), nil
+}
+
+// AdjustStock は在庫数を増減させ、調整後の書籍を返します。
+func (r *Resolver) AdjustStock(ctx context.Context, args struct {
+	Isbn  string
+	Delta int32
+}) (*BookResolver, error) {
+	if err := authorize(ctx, auth.RoleWarehouse); err != nil {
+		return nil, err
+	}
+	dto, err := r.adjustStockService.Execute(ctx, book.AdjustStockCommand{
+		ISBN:  args.Isbn,
+		Delta: int(args.Delta),
+	})
+	if err != nil {
+		return nil, toResolverError(ctx, i18n.MsgAdjustStockFailed, err)
+	}
+	return r.bookResolver(dto), nil
+}
+
+// bookResolver はdtoのBook型のリゾルバーを生成します。
+func (r *Resolver) bookResolver(dto *book.BookDTO) *BookResolver {
+	return &BookResolver{dto: dto, stockHistoryService: r.stockHistoryService}
+}
+
+// BookResolver はBook型のリゾルバーです。
+type BookResolver struct {
+	dto                 *book.BookDTO
+	stockHistoryService *book.ListStockHistoryApplicationService
+}
+
+func (b *BookResolver) Isbn() string {
+	return b.dto.ISBN
+}
+
+func (b *BookResolver) Title() string {
+	return b.dto.Title
+}
+
+func (b *BookResolver) Subtitle() *string {
+	return nonEmpty(b.dto.Subtitle)
+}
+
+func (b *BookResolver) Authors() []*AuthorResolver {
+	resolvers := make([]*AuthorResolver, len(b.dto.Authors))
+	for i, a := range b.dto.Authors {
+		resolvers[i] = &AuthorResolver{id: a.ID, name: a.Name}
+	}
+	return resolvers
+}
+
+func (b *BookResolver) Publisher() *PublisherResolver {
+	if b.dto.Publisher == nil {
+		return nil
+	}
+	return &PublisherResolver{id: b.dto.Publisher.ID, name: b.dto.Publisher.Name}
+}
+
+func (b *BookResolver) PublicationDate() *string {
+	return nonEmpty(b.dto.PublicationDate)
+}
+
+func (b *BookResolver) Language() *string {
+	return nonEmpty(b.dto.Language)
+}
+
+func (b *BookResolver) PageCount() *int32 {
+	return nonZero(b.dto.PageCount)
+}
+
+func (b *BookResolver) Format() *string {
+	return nonEmpty(b.dto.Format)
+}
+
+func (b *BookResolver) Edition() *int32 {
+	return nonZero(b.dto.Edition)
+}
+
+// nonEmpty は空文字列をnull（nil）として返します。
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nonZero は0をnull（nil）として返します。
+func nonZero(n int) *int32 {
+	if n == 0 {
+		return nil
+	}
+	v := int32(n)
+	return &v
+}
+
+func (b *BookResolver) Categories() []*CategoryResolver {
+	resolvers := make([]*CategoryResolver, len(b.dto.Categories))
+	for i, c := range b.dto.Categories {
+		resolvers[i] = &CategoryResolver{id: c.ID, name: c.Name}
+	}
+	return resolvers
+}
+
+func (b *BookResolver) PriceAmount() float64 {
+	return b.dto.PriceAmount
+}
+
+func (b *BookResolver) QuantityAvailable() int32 {
+	return int32(b.dto.QuantityAvailable) // #nosec G115 -- 在庫数はint32の範囲に収まる
+}
+
+func (b *BookResolver) Status() string {
+	return b.dto.Status
+}
+
+// StockHistory は在庫数の変更履歴を新しい順に最大first件返します。
+// 同一リクエスト内の複数の書籍の履歴はまとめて1回のクエリで取得されます。
+func (b *BookResolver) StockHistory(ctx context.Context, args struct{ First int32 }) ([]*StockHistoryEntryResolver, error) {
+	key := stockHistoryKey{isbn: b.dto.ISBN, limit: int(args.First)}
+	entries, err := stockHistoryLoaderFrom(ctx, b.stockHistoryService).Load(ctx, key)()
+	if err != nil {
+		return nil, toResolverError(ctx, i18n.MsgListStockHistoryFailed, err)
+	}
+
+	resolvers := make([]*StockHistoryEntryResolver, len(entries))
+	for i, entry := range entries {
+		resolvers[i] = &StockHistoryEntryResolver{entry: entry}
+	}
+	return resolvers, nil
+}
+
+// StockHistoryEntryResolver はStockHistoryEntry型のリゾルバーです。
+type StockHistoryEntryResolver struct {
+	entry *book.StockHistoryEntryDTO
+}
+
+func (e *StockHistoryEntryResolver) Delta() int32 {
+	return int32(e.entry.Delta) // #nosec G115 -- 変更量はint32の範囲に収まる
+}
+
+func (e *StockHistoryEntryResolver) QuantityAvailable() int32 {
+	return int32(e.entry.QuantityAvailable) // #nosec G115 -- 在庫数はint32の範囲に収まる
+}
+
+func (e *StockHistoryEntryResolver) Status() string {
+	return e.entry.Status
+}
+
+// OccurredAt は変更日時をRFC 3339形式で返します。
+func (e *StockHistoryEntryResolver) OccurredAt() string {
+	return e.entry.OccurredAt.UTC().Format(time.RFC3339Nano)
+}
+
+// AuthorResolver はAuthor型のリゾルバーです。
+type AuthorResolver struct {
+	id   string
+	name string
+}
+
+func (a *AuthorResolver) ID() graphql.ID {
+	return graphql.ID(a.id)
+}
+
+func (a *AuthorResolver) Name() string {
+	return a.name
+}
+
+// PublisherResolver はPublisher型のリゾルバーです。
+type PublisherResolver struct {
+	id   string
+	name string
+}
+
+func (p *PublisherResolver) ID() graphql.ID {
+	return graphql.ID(p.id)
+}
+
+func (p *PublisherResolver) Name() string {
+	return p.name
+}
+
+// CategoryResolver はCategory型のリゾルバーです。
+type CategoryResolver struct {
+	id   string
+	name string
+}
+
+func (c *CategoryResolver) ID() graphql.ID {
+	return graphql.ID(c.id)
+}
+
+func (c *CategoryResolver) Name() string {
+	return c.name
+}
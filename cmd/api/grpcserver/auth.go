@@ -0,0 +1,97 @@
+package grpcserver
+
+import (
+	"context"
+	bookv1 "ddd-hands-on-go/api/gen/book/v1"
+	"ddd-hands-on-go/internal/auth"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// APIKeyMetadataKey はAPIキーを受け渡すメタデータのキーです。HTTPのX-API-Keyヘッダーに対応します。
+const APIKeyMetadataKey = "x-api-key"
+
+// MethodRoles はRPCごとの、呼び出しに必要なロールです。HTTPのhandler.RouteRolesに対応します。
+var MethodRoles = map[string]auth.Role{
+	bookv1.BookService_RegisterBook_FullMethodName: auth.RoleEditor,
+	bookv1.BookService_GetBook_FullMethodName:      auth.RoleViewer,
+	bookv1.BookService_AdjustStock_FullMethodName:  auth.RoleWarehouse,
+	bookv1.BookService_WatchBook_FullMethodName:    auth.RoleViewer,
+}
+
+// AuthInterceptor はHTTPのmiddleware.Authと同様に、RPCのアクターを認証してロールを確認するインターセプターを提供します。
+// RequestLoggerのインターセプターより後に実行し、ロガーにactorを付与します。
+type AuthInterceptor struct {
+	authenticators []auth.Authenticator
+	roles          map[string]auth.Role
+}
+
+// NewAuthInterceptor は新しいAuthInterceptorを生成します。rolesにないRPCは認証なしで呼び出せます。
+func NewAuthInterceptor(authenticators []auth.Authenticator, roles map[string]auth.Role) *AuthInterceptor {
+	return &AuthInterceptor{authenticators: authenticators, roles: roles}
+}
+
+// UnaryInterceptor は単項RPCのインターセプターです。
+func (a *AuthInterceptor) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor はストリーミングRPCのインターセプターです。
+func (a *AuthInterceptor) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorize はメタデータの認証情報でアクターを認証し、methodに必要なロールを持っているかを確認します。
+// 認証情報がないか不正な場合はUnauthenticatedを、ロールが足りない場合はPermissionDeniedを返します。
+func (a *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	required, ok := a.roles[method]
+	if !ok {
+		return ctx, nil
+	}
+
+	logger := logging.FromContext(ctx)
+	principal, err := auth.Authenticate(ctx, a.authenticators, credentialsFromMetadata(ctx))
+	if err != nil {
+		logger.Warn("認証に失敗しました", "error", err)
+		return nil, localizedStatusError(ctx, codes.Unauthenticated, i18n.MsgInvalidCredentials)
+	}
+	if principal == nil {
+		return nil, localizedStatusError(ctx, codes.Unauthenticated, i18n.MsgAuthenticationRequired)
+	}
+
+	logger = logger.With("actor", principal.ID)
+	if !principal.Can(required) {
+		logger.Warn("ロールが不足しています", "required_role", required, "roles", principal.Roles)
+		return nil, localizedStatusError(ctx, codes.PermissionDenied, i18n.MsgRoleRequired, required)
+	}
+
+	ctx = auth.WithPrincipal(ctx, principal)
+	return logging.WithLogger(ctx, logger), nil
+}
+
+// credentialsFromMetadata はx-api-keyとauthorizationのメタデータから認証情報を取り出します。
+func credentialsFromMetadata(ctx context.Context) auth.Credentials {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	return auth.Credentials{
+		APIKey:      first(APIKeyMetadataKey),
+		BearerToken: auth.BearerToken(first("authorization")),
+	}
+}
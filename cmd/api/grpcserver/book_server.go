@@ -0,0 +1,204 @@
+// Package grpcserver はgRPCのプレゼンテーション層を実装します。
+// HTTPハンドラーと同じアプリケーションサービスを利用し、振る舞いとエラーの対応付けを揃えます。
+package grpcserver
+
+import (
+	"context"
+	bookv1 "ddd-hands-on-go/api/gen/book/v1"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/i18n"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// BookServer はbookv1.BookServiceServerの実装です。
+type BookServer struct {
+	bookv1.UnimplementedBookServiceServer
+
+	registerBookService *book.RegisterBookApplicationService
+	getBookService      *book.GetBookApplicationService
+	adjustStockService  *book.AdjustStockApplicationService
+	watcher             *BookWatcher
+}
+
+// NewBookServer は新しいBookServerを生成します。
+func NewBookServer(
+	registerBookService *book.RegisterBookApplicationService,
+	getBookService *book.GetBookApplicationService,
+	adjustStockService *book.AdjustStockApplicationService,
+	watcher *BookWatcher,
+) *BookServer {
+	return &BookServer{
+		registerBookService: registerBookService,
+		getBookService:      getBookService,
+		adjustStockService:  adjustStockService,
+		watcher:             watcher,
+	}
+}
+
+// RegisterBook は書籍を登録し、登録後の書籍情報を返します。
+func (s *BookServer) RegisterBook(ctx context.Context, req *bookv1.RegisterBookRequest) (*bookv1.Book, error) {
+	cmd := book.RegisterBookCommand{
+		ISBN:        req.GetIsbn(),
+		Title:       req.GetTitle(),
+		Subtitle:    req.GetSubtitle(),
+		PriceAmount: req.GetPrice(),
+		Metadata: book.MetadataInput{
+			AuthorIds:       req.GetAuthorIds(),
+			PublisherId:     req.GetPublisherId(),
+			PublicationDate: req.GetPublicationDate(),
+			Language:        req.GetLanguage(),
+			PageCount:       int(req.GetPageCount()),
+			Format:          fromProtoBookFormat(req.GetFormat()),
+			Edition:         int(req.GetEdition()),
+		},
+	}
+	dto, err := s.registerBookService.Execute(ctx, cmd)
+	if err != nil {
+		return nil, toStatusError(ctx, i18n.MsgRegisterBookFailed, err)
+	}
+	return toProtoBook(dto), nil
+}
+
+// GetBook はISBNを指定して書籍を取得します。
+func (s *BookServer) GetBook(ctx context.Context, req *bookv1.GetBookRequest) (*bookv1.Book, error) {
+	dto, err := s.getBookService.Execute(ctx, req.GetIsbn())
+	if err != nil {
+		return nil, toStatusError(ctx, i18n.MsgGetBookFailed, err)
+	}
+	if dto == nil {
+		return nil, localizedStatusError(ctx, codes.NotFound, i18n.MsgBookNotFound)
+	}
+	return toProtoBook(dto), nil
+}
+
+// AdjustStock は在庫数を増減させ、調整後の書籍情報を返します。
+func (s *BookServer) AdjustStock(ctx context.Context, req *bookv1.AdjustStockRequest) (*bookv1.Book, error) {
+	cmd := book.AdjustStockCommand{
+		ISBN:  req.GetIsbn(),
+		Delta: int(req.GetDelta()),
+	}
+	dto, err := s.adjustStockService.Execute(ctx, cmd)
+	if err != nil {
+		return nil, toStatusError(ctx, i18n.MsgAdjustStockFailed, err)
+	}
+	return toProtoBook(dto), nil
+}
+
+// WatchBook は書籍の現在の状態を送信した後、変更があるたびに最新の状態を送信します。
+// 書籍が削除された場合はbookを設定せずに送信し、ストリームを終了します。
+func (s *BookServer) WatchBook(req *bookv1.WatchBookRequest, stream bookv1.BookService_WatchBookServer) error {
+	ctx := stream.Context()
+
+	// 初期状態の送信より前に購読し、その間の変更を取りこぼさないようにする
+	changes, cancel := s.watcher.Watch(req.GetIsbn())
+	defer cancel()
+
+	current, err := s.GetBook(ctx, &bookv1.GetBookRequest{Isbn: req.GetIsbn()})
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&bookv1.WatchBookResponse{Book: current, OccurredAt: timestamppb.Now()}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case change, ok := <-changes:
+			if !ok {
+				return localizedStatusError(ctx, codes.Unavailable, i18n.MsgServerShuttingDown)
+			}
+			dto, err := s.getBookService.Execute(ctx, req.GetIsbn())
+			if err != nil {
+				return toStatusError(ctx, i18n.MsgGetBookFailed, err)
+			}
+
+			resp := &bookv1.WatchBookResponse{
+				EventName:  change.EventName,
+				OccurredAt: timestamppb.New(change.OccurredAt),
+			}
+			if dto != nil {
+				resp.Book = toProtoBook(dto)
+			}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+			if dto == nil {
+				return nil
+			}
+		}
+	}
+}
+
+// toProtoBook はBookDTOをgRPCのメッセージへ変換します。
+func toProtoBook(dto *book.BookDTO) *bookv1.Book {
+	b := &bookv1.Book{
+		Isbn:              dto.ISBN,
+		Title:             dto.Title,
+		Subtitle:          dto.Subtitle,
+		PriceAmount:       dto.PriceAmount,
+		QuantityAvailable: int32(dto.QuantityAvailable), // #nosec G115 -- 在庫数はint32の範囲に収まる
+		Status:            toProtoStockStatus(dto.Status),
+		PublicationDate:   dto.PublicationDate,
+		Language:          dto.Language,
+		PageCount:         int32(dto.PageCount), // #nosec G115 -- ページ数はドメインで上限を検証済み
+		Format:            toProtoBookFormat(dto.Format),
+		Edition:           int32(dto.Edition), // #nosec G115 -- 版はドメインで上限を検証済み
+	}
+	for _, a := range dto.Authors {
+		b.Authors = append(b.Authors, &bookv1.Author{Id: a.ID, Name: a.Name})
+	}
+	if dto.Publisher != nil {
+		b.Publisher = &bookv1.Publisher{Id: dto.Publisher.ID, Name: dto.Publisher.Name}
+	}
+	for _, c := range dto.Categories {
+		b.Categories = append(b.Categories, &bookv1.Category{Id: c.ID, Name: c.Name})
+	}
+	return b
+}
+
+func toProtoStockStatus(s string) bookv1.StockStatus {
+	switch s {
+	case "IN_STOCK":
+		return bookv1.StockStatus_STOCK_STATUS_IN_STOCK
+	case "LOW_STOCK":
+		return bookv1.StockStatus_STOCK_STATUS_LOW_STOCK
+	case "OUT_OF_STOCK":
+		return bookv1.StockStatus_STOCK_STATUS_OUT_OF_STOCK
+	default:
+		return bookv1.StockStatus_STOCK_STATUS_UNSPECIFIED
+	}
+}
+
+func toProtoBookFormat(f string) bookv1.BookFormat {
+	switch f {
+	case "HARDCOVER":
+		return bookv1.BookFormat_BOOK_FORMAT_HARDCOVER
+	case "PAPERBACK":
+		return bookv1.BookFormat_BOOK_FORMAT_PAPERBACK
+	case "EBOOK":
+		return bookv1.BookFormat_BOOK_FORMAT_EBOOK
+	default:
+		return bookv1.BookFormat_BOOK_FORMAT_UNSPECIFIED
+	}
+}
+
+// fromProtoBookFormat はgRPCの形態をアプリケーションサービスの形態へ変換します。
+// BOOK_FORMAT_UNSPECIFIEDは不明として空文字列に、未知の値はドメインで不正な形態として扱われる文字列にします。
+func fromProtoBookFormat(f bookv1.BookFormat) string {
+	switch f {
+	case bookv1.BookFormat_BOOK_FORMAT_UNSPECIFIED:
+		return ""
+	case bookv1.BookFormat_BOOK_FORMAT_HARDCOVER:
+		return "HARDCOVER"
+	case bookv1.BookFormat_BOOK_FORMAT_PAPERBACK:
+		return "PAPERBACK"
+	case bookv1.BookFormat_BOOK_FORMAT_EBOOK:
+		return "EBOOK"
+	default:
+		return f.String()
+	}
+}
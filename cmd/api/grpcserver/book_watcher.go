@@ -0,0 +1,131 @@
+package grpcserver
+
+import (
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/shared"
+	"sync"
+	"time"
+)
+
+// watchedEvents はWatchBookで通知の契機とするドメインイベントの一覧です。
+var watchedEvents = []string{
+	"BookCreated",
+	"BookTitleChanged",
+	"BookPriceChanged",
+	"BookCategoriesChanged",
+	"StockQuantityChanged",
+	"BookDeleted",
+}
+
+// BookChange は書籍に変更があったことを表す通知です。
+type BookChange struct {
+	EventName  string
+	OccurredAt time.Time
+}
+
+// BookWatcher はドメインイベントを購読し、書籍ごとの監視者へ変更を通知します。
+type BookWatcher struct {
+	mu       sync.Mutex
+	watchers map[string]map[chan BookChange]struct{}
+	closed   bool
+}
+
+// NewBookWatcher は新しいBookWatcherを生成し、ドメインイベントを購読します。
+func NewBookWatcher(subscriber shared.DomainEventSubscriber) *BookWatcher {
+	w := &BookWatcher{watchers: make(map[string]map[chan BookChange]struct{})}
+	for _, name := range watchedEvents {
+		subscriber.Subscribe(name, w.handle)
+	}
+	return w
+}
+
+// Watch は指定したISBNの書籍の変更通知を受け取るチャネルと、監視を解除する関数を返します。
+// 通知は受信側が追いつかない場合に最新の1件へまとめられるため、受信側は通知のたびに最新の状態を取得してください。
+// Closeされた後はチャネルが閉じられます。
+func (w *BookWatcher) Watch(isbn string) (<-chan BookChange, func()) {
+	ch := make(chan BookChange, 1)
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	if w.watchers[isbn] == nil {
+		w.watchers[isbn] = make(map[chan BookChange]struct{})
+	}
+	w.watchers[isbn][ch] = struct{}{}
+	w.mu.Unlock()
+
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.watchers[isbn], ch)
+		if len(w.watchers[isbn]) == 0 {
+			delete(w.watchers, isbn)
+		}
+	}
+}
+
+// Close は全ての監視者のチャネルを閉じ、以降の監視を受け付けません。
+// サーバーの停止時に、終了しないストリームを終わらせるために使用します。
+func (w *BookWatcher) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+	for _, chs := range w.watchers {
+		for ch := range chs {
+			close(ch)
+		}
+	}
+	w.watchers = make(map[string]map[chan BookChange]struct{})
+}
+
+// handle はドメインイベントを受け取り、該当する書籍の監視者へ通知します。
+// イベントの発行元をブロックしないよう、通知は待たずに送信します。
+func (w *BookWatcher) handle(event shared.DomainEvent) {
+	isbn := bookIdOf(event)
+	if isbn == "" {
+		return
+	}
+	change := BookChange{EventName: event.EventName(), OccurredAt: event.OccurredOn()}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ch := range w.watchers[isbn] {
+		select {
+		case ch <- change:
+		default:
+			// 未受信の通知を最新の通知で置き換える
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- change:
+			default:
+			}
+		}
+	}
+}
+
+// bookIdOf はイベントの対象となる書籍のIDを返します。
+func bookIdOf(event shared.DomainEvent) string {
+	switch e := event.(type) {
+	case *book.BookCreated:
+		return e.BookId
+	case *book.BookTitleChanged:
+		return e.BookId
+	case *book.BookPriceChanged:
+		return e.BookId
+	case *book.BookCategoriesChanged:
+		return e.BookId
+	case *book.StockQuantityChanged:
+		return e.BookId
+	case *book.BookDeleted:
+		return e.BookId
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,46 @@
+package grpcserver
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError はドメインエラーの種類に応じたgRPCステータスのエラーへ変換します。
+// HTTPハンドラーのステータスコードの対応付けと揃えています。
+// メッセージはkeyのメッセージに原因のエラーのメッセージを続けたもので、コンテキストの言語（メタデータのaccept-language）で記述します。
+func toStatusError(ctx context.Context, key i18n.Key, err error) error {
+	code := codeFromError(err)
+	if code == codes.Internal {
+		logging.FromContext(ctx).Error(i18n.Message(i18n.Default, key), "error", err)
+	}
+	locale := i18n.FromContext(ctx)
+	return status.Error(code, i18n.Message(locale, key)+": "+i18n.ErrorMessage(locale, err))
+}
+
+// localizedStatusError はkeyのメッセージをコンテキストの言語で記述したgRPCステータスのエラーを返します。
+func localizedStatusError(ctx context.Context, code codes.Code, key i18n.Key, args ...interface{}) error {
+	return status.Error(code, i18n.Message(i18n.FromContext(ctx), key, args...))
+}
+
+// codeFromError はドメインエラーの種類をgRPCのステータスコードへ変換します。
+func codeFromError(err error) codes.Code {
+	switch shared.KindOf(err) {
+	case shared.KindInvalid:
+		return codes.InvalidArgument
+	case shared.KindNotFound:
+		return codes.NotFound
+	case shared.KindConflict:
+		// 重複登録は状態を変えても成功しないため、在庫不足などの状態の矛盾と区別する
+		if i18n.ErrorCode(err) == i18n.MsgBookAlreadyExists {
+			return codes.AlreadyExists
+		}
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,30 @@
+package grpcserver
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/i18n"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// LocaleMetadataKey はエラーのメッセージの言語を指定するメタデータのキーです。HTTPのAccept-Languageヘッダーに対応します。
+const LocaleMetadataKey = "accept-language"
+
+// LocaleUnaryInterceptor はHTTPのmiddleware.Localeと同様に、メタデータのaccept-languageで選んだ言語をコンテキストに格納する単項RPCのインターセプターです。
+// ステータスのメッセージはこの言語で記述します。
+func LocaleUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withLocale(ctx), req)
+}
+
+// LocaleStreamInterceptor はLocaleUnaryInterceptorのストリーミングRPC版です。
+func LocaleStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextStream{ServerStream: ss, ctx: withLocale(ss.Context())})
+}
+
+// withLocale はメタデータのaccept-languageから選んだ言語をコンテキストに格納します。指定されていない場合はi18n.Defaultです。
+func withLocale(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return i18n.WithLocale(ctx, i18n.ParseAcceptLanguage(strings.Join(md.Get(LocaleMetadataKey), ",")))
+}
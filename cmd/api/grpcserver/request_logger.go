@@ -0,0 +1,88 @@
+package grpcserver
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/logging"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDMetadataKey はリクエストIDを受け渡すメタデータのキーです。HTTPのX-Request-IDヘッダーに対応します。
+const RequestIDMetadataKey = "x-request-id"
+
+// RequestLogger はHTTPのmiddleware.RequestLoggerと同様に、RPCごとにリクエストIDを割り当ててロガーをコンテキストに格納する
+// インターセプターを提供します。
+type RequestLogger struct {
+	logger *slog.Logger
+}
+
+// NewRequestLogger は新しいRequestLoggerを生成します。
+func NewRequestLogger(logger *slog.Logger) *RequestLogger {
+	return &RequestLogger{logger: logger}
+}
+
+// UnaryInterceptor は単項RPCのインターセプターです。
+func (l *RequestLogger) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, logger := l.begin(ctx)
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	l.end(ctx, logger, info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamInterceptor はストリーミングRPCのインターセプターです。
+func (l *RequestLogger) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, logger := l.begin(ss.Context())
+	start := time.Now()
+	err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	l.end(ctx, logger, info.FullMethod, start, err)
+	return err
+}
+
+// begin はメタデータのリクエストIDを引き継ぐか新たに割り当て、レスポンスのヘッダーにも設定します。
+func (l *RequestLogger) begin(ctx context.Context) (context.Context, *slog.Logger) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if !logging.ValidRequestID(requestID) {
+		requestID = logging.NewRequestID()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID))
+
+	logger := l.logger.With(logging.RequestIDKey, requestID)
+	ctx = logging.WithRequestID(ctx, requestID)
+	return logging.WithLogger(ctx, logger), logger
+}
+
+func (l *RequestLogger) end(ctx context.Context, logger *slog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss:
+		// サーバー側の障害のみエラーとして出力する（HTTPの5xxに相当）
+		level = slog.LevelError
+	}
+	logger.LogAttrs(ctx, level, "RPCを処理しました",
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
+
+// contextStream はリクエストIDを格納したコンテキストを返すServerStreamです。
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
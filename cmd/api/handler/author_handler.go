@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/application/author"
+	"ddd-hands-on-go/internal/i18n"
+	"net/http"
+	"net/url"
+)
+
+// AuthorHandler は著者の登録・取得・一覧・更新・削除を処理するハンドラーです。
+type AuthorHandler struct {
+	registerAuthorService *author.RegisterAuthorApplicationService
+	getAuthorService      *author.GetAuthorApplicationService
+	listAuthorsService    *author.ListAuthorsApplicationService
+	updateAuthorService   *author.UpdateAuthorApplicationService
+	deleteAuthorService   *author.DeleteAuthorApplicationService
+}
+
+// NewAuthorHandler は新しいAuthorHandlerを生成します。
+func NewAuthorHandler(
+	registerAuthorService *author.RegisterAuthorApplicationService,
+	getAuthorService *author.GetAuthorApplicationService,
+	listAuthorsService *author.ListAuthorsApplicationService,
+	updateAuthorService *author.UpdateAuthorApplicationService,
+	deleteAuthorService *author.DeleteAuthorApplicationService,
+) *AuthorHandler {
+	return &AuthorHandler{
+		registerAuthorService: registerAuthorService,
+		getAuthorService:      getAuthorService,
+		listAuthorsService:    listAuthorsService,
+		updateAuthorService:   updateAuthorService,
+		deleteAuthorService:   deleteAuthorService,
+	}
+}
+
+// authorRequest は著者の登録・更新リクエストのボディです。
+type authorRequest struct {
+	Name string `json:"name"`
+}
+
+// authorListResponse は著者一覧のレスポンスボディです。
+type authorListResponse struct {
+	Items  []*author.AuthorDTO `json:"items"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+}
+
+// RegisterAuthor は著者登録リクエストを処理し、登録した著者とそのURL（Locationヘッダー）を返します。
+func (h *AuthorHandler) RegisterAuthor(w http.ResponseWriter, r *http.Request) {
+	var req authorRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	dto, err := h.registerAuthorService.Execute(r.Context(), author.RegisterAuthorCommand{Name: req.Name})
+	if err != nil {
+		writeError(w, r, i18n.MsgRegisterAuthorFailed, err)
+		return
+	}
+
+	w.Header().Set("Location", "/authors/"+url.PathEscape(dto.ID))
+	response.JSON(w, r, http.StatusCreated, dto)
+}
+
+// GetAuthor は著者取得リクエストを処理します。
+func (h *AuthorHandler) GetAuthor(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	dto, err := h.getAuthorService.Execute(r.Context(), id)
+	if err != nil {
+		writeError(w, r, i18n.MsgGetAuthorFailed, err)
+		return
+	}
+	if dto == nil {
+		response.Error(w, r, http.StatusNotFound, response.CodeNotFound, i18n.MsgAuthorNotFound, id)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, dto)
+}
+
+// ListAuthors は著者の一覧を著者名順に返します。クエリパラメータ limit・offset でページを指定します。
+func (h *AuthorHandler) ListAuthors(w http.ResponseWriter, r *http.Request) {
+	var query author.ListAuthorsQuery
+	if !parsePage(w, r, &query.Limit, &query.Offset) {
+		return
+	}
+
+	dtos, err := h.listAuthorsService.Execute(r.Context(), query)
+	if err != nil {
+		writeError(w, r, i18n.MsgListAuthorsFailed, err)
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = author.DefaultListLimit
+	}
+	response.JSON(w, r, http.StatusOK, authorListResponse{Items: dtos, Limit: query.Limit, Offset: query.Offset})
+}
+
+// UpdateAuthor は著者名の変更リクエストを処理します。
+func (h *AuthorHandler) UpdateAuthor(w http.ResponseWriter, r *http.Request) {
+	var req authorRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	dto, err := h.updateAuthorService.Execute(r.Context(), author.UpdateAuthorCommand{ID: r.PathValue("id"), Name: req.Name})
+	if err != nil {
+		writeError(w, r, i18n.MsgUpdateAuthorFailed, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, dto)
+}
+
+// DeleteAuthor は著者削除リクエストを処理します。書籍から参照されている著者は削除できません（409）。
+func (h *AuthorHandler) DeleteAuthor(w http.ResponseWriter, r *http.Request) {
+	if err := h.deleteAuthorService.Execute(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, r, i18n.MsgDeleteAuthorFailed, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterRoutes はAuthorHandlerのルートをmuxに登録します。
+// ルートを追加・変更した場合は api/openapi.json とRouteRoles・RouteMediaTypesも更新してください。
+func (h *AuthorHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("POST /authors", traced("AuthorHandler.RegisterAuthor", h.RegisterAuthor))
+	mux.Handle("GET /authors", traced("AuthorHandler.ListAuthors", h.ListAuthors))
+	mux.Handle("GET /authors/{id}", traced("AuthorHandler.GetAuthor", h.GetAuthor))
+	mux.Handle("PUT /authors/{id}", traced("AuthorHandler.UpdateAuthor", h.UpdateAuthor))
+	mux.Handle("DELETE /authors/{id}", traced("AuthorHandler.DeleteAuthor", h.DeleteAuthor))
+}
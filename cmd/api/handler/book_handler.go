@@ -1,96 +1,254 @@
 package handler
 
 import (
+	"bytes"
+	"ddd-hands-on-go/cmd/api/response"
 	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
 	"encoding/json"
-	"fmt"
-	"log"
+	"errors"
+	"io"
 	"net/http"
+	"net/url"
 )
 
 type BookHandler struct {
 	registerBookService *book.RegisterBookApplicationService
 	getBookService      *book.GetBookApplicationService
+	updateBookService   *book.UpdateBookApplicationService
+	deleteBookService   *book.DeleteBookApplicationService
+	adjustStockService  *book.AdjustStockApplicationService
 }
 
 // NewBookHandler は新しいBookHandlerを生成します。
 func NewBookHandler(
 	registerBookService *book.RegisterBookApplicationService,
 	getBookService *book.GetBookApplicationService,
+	updateBookService *book.UpdateBookApplicationService,
+	deleteBookService *book.DeleteBookApplicationService,
+	adjustStockService *book.AdjustStockApplicationService,
 ) *BookHandler {
 	return &BookHandler{
 		registerBookService: registerBookService,
 		getBookService:      getBookService,
+		updateBookService:   updateBookService,
+		deleteBookService:   deleteBookService,
+		adjustStockService:  adjustStockService,
 	}
 }
 
-// RegisterBookRequest is the request body for registering a book.
-// This struct is replaced by an anonymous struct in the Create method in the provided edit.
-// Keeping it for now as the edit only shows a partial replacement.
-// However, the instruction implies a full replacement of the RegisterBook method.
-// Based on the provided `Code Edit` block, the `RegisterBookRequest` type is effectively removed
-// and replaced by an anonymous struct within the `Create` method.
-// I will remove the `RegisterBookRequest` type as it's no longer used by the new `Create` method.
+// registerBookRequest は書籍登録リクエストのボディです。
+type registerBookRequest struct {
+	ISBN            string   `json:"isbn"`
+	Title           string   `json:"title"`
+	Subtitle        string   `json:"subtitle"`
+	Price           float64  `json:"price"`
+	AuthorIds       []string `json:"author_ids"`
+	PublisherId     string   `json:"publisher_id"`
+	PublicationDate string   `json:"publication_date"`
+	Language        string   `json:"language"`
+	PageCount       int      `json:"page_count"`
+	Format          string   `json:"format"`
+	Edition         int      `json:"edition"`
+}
+
+// updateBookRequest は書籍更新リクエストのボディです。
+type updateBookRequest struct {
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle"`
+	Price    float64 `json:"price"`
+}
 
-// Create は書籍登録リクエストを処理します。
-func (h *BookHandler) RegisterBook(w http.ResponseWriter, r *http.Request) { // Renamed from Create to RegisterBook to match original function name
+// adjustStockRequest は在庫調整リクエストのボディです。
+type adjustStockRequest struct {
+	Delta int `json:"delta"`
+}
+
+// RegisterBook は書籍登録リクエストを処理し、登録した書籍とそのURL（Locationヘッダー）を返します。
+func (h *BookHandler) RegisterBook(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "許可されていないメソッドです", http.StatusMethodNotAllowed)
+		response.Error(w, r, http.StatusMethodNotAllowed, response.CodeMethodNotAllowed, i18n.MsgMethodNotAllowed)
 		return
 	}
 
-	var req struct {
-		ISBN  string  `json:"isbn"`
-		Title string  `json:"title"`
-		Price float64 `json:"price"` // Renamed from PriceAmount to Price
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "リクエストボディの読み込みに失敗しました", http.StatusBadRequest)
+	var req registerBookRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeBodyError(w, r, err)
 		return
 	}
 
 	cmd := book.RegisterBookCommand{
 		ISBN:        req.ISBN,
 		Title:       req.Title,
-		PriceAmount: req.Price, // Mapped to req.Price
+		Subtitle:    req.Subtitle,
+		PriceAmount: req.Price,
+		Metadata: book.MetadataInput{
+			AuthorIds:       req.AuthorIds,
+			PublisherId:     req.PublisherId,
+			PublicationDate: req.PublicationDate,
+			Language:        req.Language,
+			PageCount:       req.PageCount,
+			Format:          req.Format,
+			Edition:         req.Edition,
+		},
 	}
 
-	if err := h.registerBookService.Execute(r.Context(), cmd); err != nil { // Changed from registerBookAppSvc to registerBookService
-		// 本来はエラーの種類に応じてステータスコードを使い分けるべき
-		log.Printf("書籍登録エラー: %v", err)
-		http.Error(w, fmt.Sprintf("書籍の登録に失敗しました: %v", err), http.StatusInternalServerError)
+	dto, err := h.registerBookService.Execute(r.Context(), cmd)
+	if err != nil {
+		writeError(w, r, i18n.MsgRegisterBookFailed, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	fmt.Fprintln(w, "書籍が正常に登録されました")
+	w.Header().Set("Location", "/books/"+url.PathEscape(dto.ISBN))
+	response.JSON(w, r, http.StatusCreated, dto)
 }
 
+// GetBook は書籍取得リクエストを処理します。
 func (h *BookHandler) GetBook(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		http.Error(w, "許可されていないメソッドです", http.StatusMethodNotAllowed) // Localized error message
+		response.Error(w, r, http.StatusMethodNotAllowed, response.CodeMethodNotAllowed, i18n.MsgMethodNotAllowed)
 		return
 	}
 
 	isbn := r.PathValue("isbn")
 	if isbn == "" {
-		http.Error(w, "ISBN is required", http.StatusBadRequest)
+		response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, i18n.MsgISBNRequired)
 		return
 	}
 
 	dto, err := h.getBookService.Execute(r.Context(), isbn)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, i18n.MsgGetBookFailed, err)
 		return
 	}
 	if dto == nil {
-		http.Error(w, "Book not found", http.StatusNotFound)
+		response.Error(w, r, http.StatusNotFound, response.CodeNotFound, i18n.MsgBookNotFound)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, dto)
+}
+
+// UpdateBook は書籍のタイトルと価格の更新リクエストを処理します。
+func (h *BookHandler) UpdateBook(w http.ResponseWriter, r *http.Request) {
+	var req updateBookRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	cmd := book.UpdateBookCommand{
+		ISBN:        r.PathValue("isbn"),
+		Title:       req.Title,
+		Subtitle:    req.Subtitle,
+		PriceAmount: req.Price,
+	}
+
+	dto, err := h.updateBookService.Execute(r.Context(), cmd)
+	if err != nil {
+		writeError(w, r, i18n.MsgUpdateBookFailed, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, dto)
+}
+
+// DeleteBook は書籍削除リクエストを処理します。
+func (h *BookHandler) DeleteBook(w http.ResponseWriter, r *http.Request) {
+	if err := h.deleteBookService.Execute(r.Context(), r.PathValue("isbn")); err != nil {
+		writeError(w, r, i18n.MsgDeleteBookFailed, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AdjustStock は在庫調整リクエストを処理します。
+func (h *BookHandler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	var req adjustStockRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	cmd := book.AdjustStockCommand{
+		ISBN:  r.PathValue("isbn"),
+		Delta: req.Delta,
+	}
+
+	dto, err := h.adjustStockService.Execute(r.Context(), cmd)
+	if err != nil {
+		writeError(w, r, i18n.MsgAdjustStockFailed, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(dto); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	response.JSON(w, r, http.StatusOK, dto)
+}
+
+// decodeJSONBody はリクエストボディをvへデコードします。未知の項目はエラーとします。
+func decodeJSONBody(r *http.Request, v interface{}) error {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("複数のJSON値が含まれています")
+	}
+	return nil
+}
+
+// writeError はエラーの種類に応じたステータスコードでエラーレスポンスを書き込みます。
+// keyは失敗した操作を表すメッセージで、エラーのメッセージをリクエストの言語で続けて返します。
+func writeError(w http.ResponseWriter, r *http.Request, key i18n.Key, err error) {
+	status := statusFromError(err)
+	if status == http.StatusInternalServerError {
+		// サーバー内部のエラーの詳細はレスポンスに含めず、リクエストIDで照合できるログにのみ記録する
+		logging.FromContext(r.Context()).Error(i18n.Message(i18n.Default, key), "error", err)
+		tracing.RecordError(r.Context(), err)
+		response.Error(w, r, status, response.CodeInternal, key)
+		return
+	}
+	response.ErrorWithCause(w, r, status, response.CodeForStatus(status), key, err)
+}
+
+// writeBodyError はリクエストボディの読み込みエラーのレスポンスを書き込みます。
+func writeBodyError(w http.ResponseWriter, r *http.Request, err error) {
+	status := bodyReadStatus(err)
+	response.Error(w, r, status, response.CodeForStatus(status), i18n.MsgInvalidRequestBody, err)
+}
+
+// bodyReadStatus はリクエストボディの読み込みエラーに対応するステータスコードを返します。
+// middleware.BodyLimitの上限を超えた場合は413、それ以外は400です。
+func bodyReadStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// statusFromError はドメインエラーの種類をHTTPステータスコードへ変換します。
+// 一括取り込みのようにボディを読み込みながら処理する場合は、ボディの上限超過を413とします。
+func statusFromError(err error) int {
+	if bodyReadStatus(err) == http.StatusRequestEntityTooLarge {
+		return http.StatusRequestEntityTooLarge
+	}
+	switch shared.KindOf(err) {
+	case shared.KindInvalid:
+		return http.StatusBadRequest
+	case shared.KindNotFound:
+		return http.StatusNotFound
+	case shared.KindConflict:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
 	}
 }
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/infrastructure/catalogfile"
+	"ddd-hands-on-go/internal/logging"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CatalogHandler は書籍の一覧と検索、カタログの一括取り込みと書き出しを処理するハンドラーです。
+type CatalogHandler struct {
+	listBooksService   *book.ListBooksApplicationService
+	searchBooksService *book.SearchBooksApplicationService
+	importBooksService *book.ImportBooksApplicationService
+	exportBooksService *book.ExportBooksApplicationService
+}
+
+// NewCatalogHandler は新しいCatalogHandlerを生成します。
+func NewCatalogHandler(
+	listBooksService *book.ListBooksApplicationService,
+	searchBooksService *book.SearchBooksApplicationService,
+	importBooksService *book.ImportBooksApplicationService,
+	exportBooksService *book.ExportBooksApplicationService,
+) *CatalogHandler {
+	return &CatalogHandler{
+		listBooksService:   listBooksService,
+		searchBooksService: searchBooksService,
+		importBooksService: importBooksService,
+		exportBooksService: exportBooksService,
+	}
+}
+
+// bookListResponse は書籍一覧のJSONのレスポンスボディです。
+type bookListResponse struct {
+	Items  []*book.BookDTO `json:"items"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+// ListBooks は書籍の一覧を返します。
+// クエリパラメータ limit・offset でページを、status で在庫ステータスによる絞り込みを、sort で並び順（isbn（既定）または title）を指定します。
+// category_id を指定した場合は、そのカテゴリと子孫のカテゴリのいずれかが割り当てられた書籍に絞り込みます。
+// 形式はAcceptヘッダーで選び、JSON（既定）またはCSV（書き出しと同じ列）で返します。
+func (h *CatalogHandler) ListBooks(w http.ResponseWriter, r *http.Request) {
+	query := book.ListBooksQuery{
+		Status:     r.URL.Query().Get("status"),
+		CategoryId: r.URL.Query().Get("category_id"),
+		Sort:       book.ListBooksSort(r.URL.Query().Get("sort")),
+	}
+	if !parsePage(w, r, &query.Limit, &query.Offset) {
+		return
+	}
+
+	dtos, err := h.listBooksService.Execute(r.Context(), query)
+	if err != nil {
+		writeError(w, r, i18n.MsgListBooksFailed, err)
+		return
+	}
+
+	if response.MediaType(r.Context(), response.MediaTypeJSON) == response.MediaTypeCSV {
+		w.Header().Set("Content-Type", exportFormats[catalogfile.CSV].contentType)
+		csvWriter := catalogfile.NewCSVExportWriter(w)
+		for _, dto := range dtos {
+			if err := csvWriter.Write(dto); err != nil {
+				logging.FromContext(r.Context()).Error("書籍一覧のCSVの書き出しに失敗しました", "error", err)
+				return
+			}
+		}
+		if err := csvWriter.Close(); err != nil {
+			logging.FromContext(r.Context()).Error("書籍一覧のCSVの書き出しに失敗しました", "error", err)
+		}
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = book.DefaultListLimit
+	}
+	response.JSON(w, r, http.StatusOK, bookListResponse{Items: dtos, Limit: query.Limit, Offset: query.Offset})
+}
+
+// bookSearchResponse は書籍の検索結果のレスポンスボディです。
+type bookSearchResponse struct {
+	Items  []*book.BookSearchHitDTO `json:"items"`
+	Limit  int                      `json:"limit"`
+	Offset int                      `json:"offset"`
+}
+
+// SearchBooks はクエリパラメータ q の検索語で書籍を検索し、関連度の高い順に返します。limit・offset でページを指定します。
+func (h *CatalogHandler) SearchBooks(w http.ResponseWriter, r *http.Request) {
+	query := book.SearchBooksQuery{Q: r.URL.Query().Get("q")}
+	if !parsePage(w, r, &query.Limit, &query.Offset) {
+		return
+	}
+
+	hits, err := h.searchBooksService.Execute(r.Context(), query)
+	if err != nil {
+		writeError(w, r, i18n.MsgSearchBooksFailed, err)
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = book.DefaultListLimit
+	}
+	response.JSON(w, r, http.StatusOK, bookSearchResponse{Items: hits, Limit: query.Limit, Offset: query.Offset})
+}
+
+// parsePage はクエリパラメータ limit・offset をlimitとoffsetへ読み込みます。指定がない場合は変更しません。
+// 整数でない場合は400のレスポンスを書き込み、falseを返します。
+func parsePage(w http.ResponseWriter, r *http.Request, limit, offset *int) bool {
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"limit", limit}, {"offset", offset}} {
+		v := r.URL.Query().Get(p.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, i18n.MsgQueryParameterNotInteger, p.name)
+			return false
+		}
+		*p.dst = n
+	}
+	return true
+}
+
+// importFormats はContent-Typeと取り込み形式の対応です。
+var importFormats = map[string]catalogfile.Format{
+	"text/csv":             catalogfile.CSV,
+	"application/x-ndjson": catalogfile.NDJSON,
+}
+
+// ImportBooks はCSVまたはNDJSONのリクエストボディを読み込みながら書籍を一括登録します。
+// クエリパラメータ mode で all-or-nothing（既定）または best-effort を、dry_run=true で検証のみの実行を指定します。
+func (h *CatalogHandler) ImportBooks(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	format, ok := importFormats[mediaType]
+	if !ok {
+		response.Error(w, r, http.StatusUnsupportedMediaType, response.CodeUnsupportedMediaType, i18n.MsgUnsupportedImportType)
+		return
+	}
+
+	mode := book.ImportAllOrNothing
+	if m := r.URL.Query().Get("mode"); m != "" {
+		mode = book.ImportMode(m)
+	}
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, i18n.MsgInvalidDryRun)
+			return
+		}
+	}
+
+	rows, err := catalogfile.NewImportReader(format, r.Body)
+	if err != nil {
+		status := bodyReadStatus(err)
+		response.ErrorWithCause(w, r, status, response.CodeForStatus(status), i18n.MsgImportReadFailed, err)
+		return
+	}
+
+	report, err := h.importBooksService.Execute(r.Context(), book.ImportBooksCommand{
+		Rows:   rows,
+		Mode:   mode,
+		DryRun: dryRun,
+	})
+	if err != nil {
+		writeError(w, r, i18n.MsgImportBooksFailed, err)
+		return
+	}
+
+	// 1件も取り込めなかった（または取り込めない）場合は422とし、行ごとのエラーをレポートで返す
+	report.Localize(i18n.FromContext(r.Context()))
+	status := http.StatusOK
+	if report.FailedRows > 0 && report.ImportedRows == 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	response.JSON(w, r, status, report)
+}
+
+// exportFormat は書き出し形式ごとのレスポンスのContent-Typeとファイル名です。
+type exportFormat struct {
+	contentType string
+	filename    string
+}
+
+var exportFormats = map[catalogfile.Format]exportFormat{
+	catalogfile.CSV:    {"text/csv; charset=utf-8", "books.csv"},
+	catalogfile.NDJSON: {"application/x-ndjson", "books.ndjson"},
+	catalogfile.ONIX:   {"application/xml", "books.xml"},
+}
+
+// exportMediaTypes はAcceptヘッダーで選べる書き出し形式です（優先順）。
+var exportMediaTypes = []string{response.MediaTypeCSV, response.MediaTypeNDJSON, response.MediaTypeXML}
+
+// exportFormatsByMediaType はAcceptヘッダーで選んだメディアタイプと書き出し形式の対応です。
+var exportFormatsByMediaType = map[string]catalogfile.Format{
+	response.MediaTypeCSV:    catalogfile.CSV,
+	response.MediaTypeNDJSON: catalogfile.NDJSON,
+	response.MediaTypeXML:    catalogfile.ONIX,
+}
+
+// ExportBooks は全ての書籍を指定された形式で書き出します。
+// 形式はクエリパラメータ format（csv|ndjson|onix）で指定し、ない場合はAcceptヘッダーで選びます（既定はcsv）。
+// 書籍は読み込みながらレスポンスへ書き出すため、カタログ全体をメモリに保持しません。
+func (h *CatalogHandler) ExportBooks(w http.ResponseWriter, r *http.Request) {
+	var format catalogfile.Format
+	if f := r.URL.Query().Get("format"); f != "" {
+		format = catalogfile.Format(f)
+	} else {
+		w.Header().Add("Vary", "Accept")
+		mediaType := response.Negotiate(r.Header.Get("Accept"), exportMediaTypes)
+		if mediaType == "" {
+			response.Error(w, r, http.StatusNotAcceptable, response.CodeNotAcceptable, i18n.MsgNotAcceptable, strings.Join(exportMediaTypes, ", "))
+			return
+		}
+		format = exportFormatsByMediaType[mediaType]
+	}
+	ef, ok := exportFormats[format]
+	if !ok {
+		response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, i18n.MsgUnsupportedExportFormat, format)
+		return
+	}
+
+	cw := &countingWriter{w: w}
+	exporter, err := catalogfile.NewExportWriter(format, cw)
+	if err != nil {
+		response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, i18n.MsgUnsupportedExportFormat, format)
+		return
+	}
+
+	// カタログ全体の送信はサーバーのWriteTimeoutを超えうるため、このレスポンスでは書き込み期限を解除する
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", ef.contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": ef.filename}))
+
+	if _, err := h.exportBooksService.Execute(r.Context(), exporter); err != nil {
+		if cw.n == 0 {
+			// まだ何も送信していなければ通常のエラーレスポンスを返せる
+			w.Header().Del("Content-Disposition")
+			writeError(w, r, i18n.MsgExportBooksFailed, err)
+			return
+		}
+		// 送信済みのレスポンスは途中で打ち切り、不完全なファイルであることをクライアントに伝える
+		logging.FromContext(r.Context()).Error("書籍の書き出しに失敗しました", "error", err, "bytes_sent", cw.n)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// countingWriter は書き込んだバイト数を数えるio.Writerです。
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// RegisterRoutes はCatalogHandlerのルートをmuxに登録します。
+// ルートを追加・変更した場合は api/openapi.json も更新してください。
+func (h *CatalogHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /books", h.ListBooks)
+	mux.HandleFunc("GET /books/search", h.SearchBooks)
+	mux.HandleFunc("POST /books/import", h.ImportBooks)
+	mux.HandleFunc("GET /books/export", h.ExportBooks)
+}
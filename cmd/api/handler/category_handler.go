@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/application/category"
+	"ddd-hands-on-go/internal/i18n"
+	"net/http"
+	"net/url"
+)
+
+// CategoryHandler はカテゴリの登録・取得・一覧・更新・削除と、書籍へのカテゴリの割り当てを処理するハンドラーです。
+type CategoryHandler struct {
+	registerCategoryService *category.RegisterCategoryApplicationService
+	getCategoryService      *category.GetCategoryApplicationService
+	listCategoriesService   *category.ListCategoriesApplicationService
+	updateCategoryService   *category.UpdateCategoryApplicationService
+	deleteCategoryService   *category.DeleteCategoryApplicationService
+	assignCategoriesService *book.AssignCategoriesApplicationService
+}
+
+// NewCategoryHandler は新しいCategoryHandlerを生成します。
+func NewCategoryHandler(
+	registerCategoryService *category.RegisterCategoryApplicationService,
+	getCategoryService *category.GetCategoryApplicationService,
+	listCategoriesService *category.ListCategoriesApplicationService,
+	updateCategoryService *category.UpdateCategoryApplicationService,
+	deleteCategoryService *category.DeleteCategoryApplicationService,
+	assignCategoriesService *book.AssignCategoriesApplicationService,
+) *CategoryHandler {
+	return &CategoryHandler{
+		registerCategoryService: registerCategoryService,
+		getCategoryService:      getCategoryService,
+		listCategoriesService:   listCategoriesService,
+		updateCategoryService:   updateCategoryService,
+		deleteCategoryService:   deleteCategoryService,
+		assignCategoriesService: assignCategoriesService,
+	}
+}
+
+// categoryRequest はカテゴリの登録・更新リクエストのボディです。parent_idを省略した場合は最上位のカテゴリです。
+type categoryRequest struct {
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id"`
+}
+
+// categoryTreeResponse はカテゴリの木構造のレスポンスボディです。
+type categoryTreeResponse struct {
+	Items []*category.CategoryTreeNodeDTO `json:"items"`
+}
+
+// assignCategoriesRequest は書籍へのカテゴリの割り当てリクエストのボディです。
+type assignCategoriesRequest struct {
+	CategoryIds []string `json:"category_ids"`
+}
+
+// RegisterCategory はカテゴリ登録リクエストを処理し、登録したカテゴリとそのURL（Locationヘッダー）を返します。
+func (h *CategoryHandler) RegisterCategory(w http.ResponseWriter, r *http.Request) {
+	var req categoryRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	dto, err := h.registerCategoryService.Execute(r.Context(), category.RegisterCategoryCommand{Name: req.Name, ParentID: req.ParentID})
+	if err != nil {
+		writeError(w, r, i18n.MsgRegisterCategoryFailed, err)
+		return
+	}
+
+	w.Header().Set("Location", "/categories/"+url.PathEscape(dto.ID))
+	response.JSON(w, r, http.StatusCreated, dto)
+}
+
+// GetCategory はカテゴリ取得リクエストを処理します。
+func (h *CategoryHandler) GetCategory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	dto, err := h.getCategoryService.Execute(r.Context(), id)
+	if err != nil {
+		writeError(w, r, i18n.MsgGetCategoryFailed, err)
+		return
+	}
+	if dto == nil {
+		response.Error(w, r, http.StatusNotFound, response.CodeNotFound, i18n.MsgCategoryNotFound, id)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, dto)
+}
+
+// ListCategories は全てのカテゴリを木構造として返します。各階層のカテゴリはカテゴリ名順です。
+func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	nodes, err := h.listCategoriesService.Execute(r.Context())
+	if err != nil {
+		writeError(w, r, i18n.MsgListCategoriesFailed, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, categoryTreeResponse{Items: nodes})
+}
+
+// UpdateCategory はカテゴリ名と親カテゴリの変更リクエストを処理します。
+func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	var req categoryRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	cmd := category.UpdateCategoryCommand{ID: r.PathValue("id"), Name: req.Name, ParentID: req.ParentID}
+	dto, err := h.updateCategoryService.Execute(r.Context(), cmd)
+	if err != nil {
+		writeError(w, r, i18n.MsgUpdateCategoryFailed, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, dto)
+}
+
+// DeleteCategory はカテゴリ削除リクエストを処理します。子カテゴリのあるカテゴリと、書籍に割り当てられているカテゴリは削除できません（409）。
+func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	if err := h.deleteCategoryService.Execute(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, r, i18n.MsgDeleteCategoryFailed, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AssignCategories は書籍のカテゴリを指定したカテゴリに置き換えるリクエストを処理し、変更後の書籍を返します。
+func (h *CategoryHandler) AssignCategories(w http.ResponseWriter, r *http.Request) {
+	var req assignCategoriesRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	cmd := book.AssignCategoriesCommand{ISBN: r.PathValue("isbn"), CategoryIds: req.CategoryIds}
+	dto, err := h.assignCategoriesService.Execute(r.Context(), cmd)
+	if err != nil {
+		writeError(w, r, i18n.MsgAssignCategoriesFailed, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, dto)
+}
+
+// RegisterRoutes はCategoryHandlerのルートをmuxに登録します。
+// ルートを追加・変更した場合は api/openapi.json とRouteRoles・RouteMediaTypesも更新してください。
+func (h *CategoryHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("POST /categories", traced("CategoryHandler.RegisterCategory", h.RegisterCategory))
+	mux.Handle("GET /categories", traced("CategoryHandler.ListCategories", h.ListCategories))
+	mux.Handle("GET /categories/{id}", traced("CategoryHandler.GetCategory", h.GetCategory))
+	mux.Handle("PUT /categories/{id}", traced("CategoryHandler.UpdateCategory", h.UpdateCategory))
+	mux.Handle("DELETE /categories/{id}", traced("CategoryHandler.DeleteCategory", h.DeleteCategory))
+	mux.Handle("PUT /books/{isbn}/categories", traced("CategoryHandler.AssignCategories", h.AssignCategories))
+}
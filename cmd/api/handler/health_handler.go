@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"ddd-hands-on-go/cmd/api/response"
+	"net/http"
+	"time"
+)
+
+// readinessTimeout はレディネスプローブ全体のタイムアウトです。
+const readinessTimeout = 2 * time.Second
+
+// HealthCheck はレディネスプローブで実行する確認項目です。
+type HealthCheck interface {
+	// Name はレスポンスに表示する確認項目の名前を返します。
+	Name() string
+	// Check は依存先が利用可能であればnilを返します。
+	Check(ctx context.Context) error
+}
+
+// HealthHandler はライブネスプローブとレディネスプローブを処理するハンドラーです。
+type HealthHandler struct {
+	checks []HealthCheck
+}
+
+// NewHealthHandler は新しいHealthHandlerを生成します。checksはレディネスプローブで順に実行されます。
+func NewHealthHandler(checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// healthResponse はプローブのレスポンスボディです。
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Healthz はプロセスが応答可能であることを返すライブネスプローブです。依存先は確認しません。
+func (h *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	response.JSON(w, r, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// Readyz は全ての確認項目が成功した場合に200を、いずれかが失敗した場合に503を返すレディネスプローブです。
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	resp := healthResponse{Status: "ok", Checks: make(map[string]string, len(h.checks))}
+	status := http.StatusOK
+	for _, c := range h.checks {
+		if err := c.Check(ctx); err != nil {
+			resp.Checks[c.Name()] = err.Error()
+			resp.Status = "unavailable"
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		resp.Checks[c.Name()] = "ok"
+	}
+
+	response.JSON(w, r, status, resp)
+}
+
+// RegisterRoutes はHealthHandlerのルートをmuxに登録します。
+// ルートを追加・変更した場合は api/openapi.json も更新してください。
+func (h *HealthHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", h.Healthz)
+	mux.HandleFunc("GET /readyz", h.Readyz)
+}
@@ -0,0 +1,12 @@
+package handler
+
+import (
+	"ddd-hands-on-go/api"
+	"net/http"
+)
+
+// GetOpenAPISpec はAPIのOpenAPIドキュメントを返します。
+func GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(api.OpenAPISpec)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/application/publisher"
+	"ddd-hands-on-go/internal/i18n"
+	"net/http"
+	"net/url"
+)
+
+// PublisherHandler は出版社の登録・取得・一覧・更新・削除を処理するハンドラーです。
+type PublisherHandler struct {
+	registerPublisherService *publisher.RegisterPublisherApplicationService
+	getPublisherService      *publisher.GetPublisherApplicationService
+	listPublishersService    *publisher.ListPublishersApplicationService
+	updatePublisherService   *publisher.UpdatePublisherApplicationService
+	deletePublisherService   *publisher.DeletePublisherApplicationService
+}
+
+// NewPublisherHandler は新しいPublisherHandlerを生成します。
+func NewPublisherHandler(
+	registerPublisherService *publisher.RegisterPublisherApplicationService,
+	getPublisherService *publisher.GetPublisherApplicationService,
+	listPublishersService *publisher.ListPublishersApplicationService,
+	updatePublisherService *publisher.UpdatePublisherApplicationService,
+	deletePublisherService *publisher.DeletePublisherApplicationService,
+) *PublisherHandler {
+	return &PublisherHandler{
+		registerPublisherService: registerPublisherService,
+		getPublisherService:      getPublisherService,
+		listPublishersService:    listPublishersService,
+		updatePublisherService:   updatePublisherService,
+		deletePublisherService:   deletePublisherService,
+	}
+}
+
+// publisherRequest は出版社の登録・更新リクエストのボディです。
+type publisherRequest struct {
+	Name string `json:"name"`
+}
+
+// publisherListResponse は出版社一覧のレスポンスボディです。
+type publisherListResponse struct {
+	Items  []*publisher.PublisherDTO `json:"items"`
+	Limit  int                       `json:"limit"`
+	Offset int                       `json:"offset"`
+}
+
+// RegisterPublisher は出版社登録リクエストを処理し、登録した出版社とそのURL（Locationヘッダー）を返します。
+func (h *PublisherHandler) RegisterPublisher(w http.ResponseWriter, r *http.Request) {
+	var req publisherRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	dto, err := h.registerPublisherService.Execute(r.Context(), publisher.RegisterPublisherCommand{Name: req.Name})
+	if err != nil {
+		writeError(w, r, i18n.MsgRegisterPublisherFailed, err)
+		return
+	}
+
+	w.Header().Set("Location", "/publishers/"+url.PathEscape(dto.ID))
+	response.JSON(w, r, http.StatusCreated, dto)
+}
+
+// GetPublisher は出版社取得リクエストを処理します。
+func (h *PublisherHandler) GetPublisher(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	dto, err := h.getPublisherService.Execute(r.Context(), id)
+	if err != nil {
+		writeError(w, r, i18n.MsgGetPublisherFailed, err)
+		return
+	}
+	if dto == nil {
+		response.Error(w, r, http.StatusNotFound, response.CodeNotFound, i18n.MsgPublisherNotFound, id)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, dto)
+}
+
+// ListPublishers は出版社の一覧を出版社名順に返します。クエリパラメータ limit・offset でページを指定します。
+func (h *PublisherHandler) ListPublishers(w http.ResponseWriter, r *http.Request) {
+	var query publisher.ListPublishersQuery
+	if !parsePage(w, r, &query.Limit, &query.Offset) {
+		return
+	}
+
+	dtos, err := h.listPublishersService.Execute(r.Context(), query)
+	if err != nil {
+		writeError(w, r, i18n.MsgListPublishersFailed, err)
+		return
+	}
+
+	if query.Limit == 0 {
+		query.Limit = publisher.DefaultListLimit
+	}
+	response.JSON(w, r, http.StatusOK, publisherListResponse{Items: dtos, Limit: query.Limit, Offset: query.Offset})
+}
+
+// UpdatePublisher は出版社名の変更リクエストを処理します。
+func (h *PublisherHandler) UpdatePublisher(w http.ResponseWriter, r *http.Request) {
+	var req publisherRequest
+	if err := decodeJSONBody(r, &req); err != nil {
+		writeBodyError(w, r, err)
+		return
+	}
+
+	dto, err := h.updatePublisherService.Execute(r.Context(), publisher.UpdatePublisherCommand{ID: r.PathValue("id"), Name: req.Name})
+	if err != nil {
+		writeError(w, r, i18n.MsgUpdatePublisherFailed, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, dto)
+}
+
+// DeletePublisher は出版社削除リクエストを処理します。書籍から参照されている出版社は削除できません（409）。
+func (h *PublisherHandler) DeletePublisher(w http.ResponseWriter, r *http.Request) {
+	if err := h.deletePublisherService.Execute(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, r, i18n.MsgDeletePublisherFailed, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterRoutes はPublisherHandlerのルートをmuxに登録します。
+// ルートを追加・変更した場合は api/openapi.json とRouteRoles・RouteMediaTypesも更新してください。
+func (h *PublisherHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("POST /publishers", traced("PublisherHandler.RegisterPublisher", h.RegisterPublisher))
+	mux.Handle("GET /publishers", traced("PublisherHandler.ListPublishers", h.ListPublishers))
+	mux.Handle("GET /publishers/{id}", traced("PublisherHandler.GetPublisher", h.GetPublisher))
+	mux.Handle("PUT /publishers/{id}", traced("PublisherHandler.UpdatePublisher", h.UpdatePublisher))
+	mux.Handle("DELETE /publishers/{id}", traced("PublisherHandler.DeletePublisher", h.DeletePublisher))
+}
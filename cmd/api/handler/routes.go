@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/auth"
+	"ddd-hands-on-go/internal/tracing"
+	"net/http"
+)
+
+// RouteRoles はルートのパターンごとの、操作に必要なロールです。認証が有効な場合にmiddleware.Authが参照します。
+// ここにないルート（ヘルスチェック、メトリクス、OpenAPIドキュメント）は認証なしで利用できます。
+// ルートを追加・変更した場合は、api/openapi.json の security と x-required-role も更新してください。
+var RouteRoles = map[string]auth.Role{
+	"POST /books":                          auth.RoleEditor,
+	"GET /books":                           auth.RoleViewer,
+	"GET /books/search":                    auth.RoleViewer,
+	"GET /books/{isbn}":                    auth.RoleViewer,
+	"PUT /books/{isbn}":                    auth.RoleEditor,
+	"DELETE /books/{isbn}":                 auth.RoleEditor,
+	"POST /books/{isbn}/stock/adjustments": auth.RoleWarehouse,
+	"POST /books/import":                   auth.RoleEditor,
+	"GET /books/export":                    auth.RoleViewer,
+	"POST /authors":                        auth.RoleEditor,
+	"GET /authors":                         auth.RoleViewer,
+	"GET /authors/{id}":                    auth.RoleViewer,
+	"PUT /authors/{id}":                    auth.RoleEditor,
+	"DELETE /authors/{id}":                 auth.RoleEditor,
+	"POST /publishers":                     auth.RoleEditor,
+	"GET /publishers":                      auth.RoleViewer,
+	"GET /publishers/{id}":                 auth.RoleViewer,
+	"PUT /publishers/{id}":                 auth.RoleEditor,
+	"DELETE /publishers/{id}":              auth.RoleEditor,
+	"POST /categories":                     auth.RoleEditor,
+	"GET /categories":                      auth.RoleViewer,
+	"GET /categories/{id}":                 auth.RoleViewer,
+	"PUT /categories/{id}":                 auth.RoleEditor,
+	"DELETE /categories/{id}":              auth.RoleEditor,
+	"PUT /books/{isbn}/categories":         auth.RoleEditor,
+	// GraphQLは参照を含むため、ミューテーションごとのロールはリゾルバーで確認する
+	"POST /graphql": auth.RoleViewer,
+}
+
+// IdempotentRoutes はIdempotency-Keyヘッダーを受け付けるルートのパターンです。middleware.Idempotencyが参照します。
+// 一括登録は行ごとにトランザクションを分けるため、1つのトランザクションで実行する冪等キーの対象にしていません。
+var IdempotentRoutes = map[string]bool{
+	"POST /books":                          true,
+	"POST /books/{isbn}/stock/adjustments": true,
+}
+
+// RouteMediaTypes はルートのパターンごとの、レスポンスとして返せるメディアタイプ（優先順）です。middleware.Negotiationが参照し、
+// Acceptヘッダーで受け付けられる形式がない場合は406を返します。書き出しはformatパラメータを優先するため、ハンドラーで形式を選びます。
+var RouteMediaTypes = map[string][]string{
+	"POST /books":                          {response.MediaTypeJSON},
+	"GET /books":                           {response.MediaTypeJSON, response.MediaTypeCSV},
+	"GET /books/search":                    {response.MediaTypeJSON},
+	"GET /books/{isbn}":                    {response.MediaTypeJSON},
+	"PUT /books/{isbn}":                    {response.MediaTypeJSON},
+	"POST /books/{isbn}/stock/adjustments": {response.MediaTypeJSON},
+	"POST /books/import":                   {response.MediaTypeJSON},
+	"POST /authors":                        {response.MediaTypeJSON},
+	"GET /authors":                         {response.MediaTypeJSON},
+	"GET /authors/{id}":                    {response.MediaTypeJSON},
+	"PUT /authors/{id}":                    {response.MediaTypeJSON},
+	"POST /publishers":                     {response.MediaTypeJSON},
+	"GET /publishers":                      {response.MediaTypeJSON},
+	"GET /publishers/{id}":                 {response.MediaTypeJSON},
+	"PUT /publishers/{id}":                 {response.MediaTypeJSON},
+	"POST /categories":                     {response.MediaTypeJSON},
+	"GET /categories":                      {response.MediaTypeJSON},
+	"GET /categories/{id}":                 {response.MediaTypeJSON},
+	"PUT /categories/{id}":                 {response.MediaTypeJSON},
+	"PUT /books/{isbn}/categories":         {response.MediaTypeJSON},
+}
+
+// RateLimitExemptRoutes はレート制限を適用しないルートのパターンです。middleware.RateLimiterが参照します。
+// ロードバランサーやPrometheusから定期的に呼び出されるため、制限の対象外にしています。
+var RateLimitExemptRoutes = map[string]bool{
+	"GET /healthz": true,
+	"GET /readyz":  true,
+	"GET /metrics": true,
+}
+
+// RegisterRoutes はBookHandlerのルートをmuxに登録します。
+// ルートを追加・変更した場合は api/openapi.json とRouteRoles・RouteMediaTypesも更新してください。
+func (h *BookHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("POST /books", traced("BookHandler.RegisterBook", h.RegisterBook))
+	mux.Handle("GET /books/{isbn}", traced("BookHandler.GetBook", h.GetBook))
+	mux.Handle("PUT /books/{isbn}", traced("BookHandler.UpdateBook", h.UpdateBook))
+	mux.Handle("DELETE /books/{isbn}", traced("BookHandler.DeleteBook", h.DeleteBook))
+	mux.Handle("POST /books/{isbn}/stock/adjustments", traced("BookHandler.AdjustStock", h.AdjustStock))
+	mux.HandleFunc("GET /openapi.json", GetOpenAPISpec)
+}
+
+// traced はハンドラーの処理をスパンとして記録します。
+// 内部エラーはwriteErrorがこのスパンに記録します。
+func traced(name string, f http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracing.Start(r.Context(), name)
+		defer span.End()
+		if isbn := r.PathValue("isbn"); isbn != "" {
+			span.SetAttributes(tracing.Attrs("isbn", isbn)...)
+		}
+		f(w, r.WithContext(ctx))
+	})
+}
@@ -1,69 +1,84 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
-	"net/http"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
-	"ddd-hands-on-go/cmd/api/handler"
-	"ddd-hands-on-go/internal/application/book"
-	"ddd-hands-on-go/internal/domain/service"
-	"ddd-hands-on-go/internal/infrastructure/event"
-	"ddd-hands-on-go/internal/infrastructure/postgres"
-	"ddd-hands-on-go/internal/infrastructure/subscriber"
+	"ddd-hands-on-go/internal/config"
+	"ddd-hands-on-go/internal/logging"
 )
 
 func main() {
-	// 環境変数の取得 (デフォルト値を設定)
-	dbHost := getEnv("DB_HOST", "localhost")
-	dbPort := getEnv("DB_PORT", "5433")
-	dbUser := getEnv("DB_USER", "ddd")
-	dbPass := getEnv("DB_PASS", "ddd")
-	dbName := getEnv("DB_NAME", "ddd_hands_on")
-
-	// 1. インフラストラクチャ層の初期化
-	db, err := postgres.NewDB(dbHost, dbPort, dbUser, dbPass, dbName)
-	if err != nil {
-		log.Fatalf("データベース接続エラー: %v", err)
+	if err := run(); err != nil {
+		log.Fatal(err)
 	}
-	defer db.Close()
-
-	// 依存関係の注入
-	bookRepo := postgres.NewPostgresBookRepository(db)
-	txManager := postgres.NewPostgresTransactionManager(db)
-	eventEmitter := event.NewEventEmitter()
-
-	// サブスクライバーの登録
-	logSubscriber := subscriber.NewLogSubscriber()
-	eventEmitter.Subscribe("BookCreated", logSubscriber.Subscribe)
-
-	// 2. ドメインサービスの初期化
-	isbnDupCheckService := service.NewISBNDuplicationCheckDomainService(bookRepo)
+}
 
-	// 3. アプリケーションサービスの初期化
-	registerBookService := book.NewRegisterBookApplicationService(bookRepo, txManager, isbnDupCheckService, eventEmitter)
-	getBookService := book.NewGetBookApplicationService(bookRepo)
+// run はサーバーを起動し、SIGINTまたはSIGTERMを受けると停止処理を行ってから戻ります。
+// log.Fatalfで終了すると遅延処理（データベースのクローズなど）が実行されないため、エラーは全て呼び出し元へ返します。
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// 4. ハンドラーの初期化
-	bookHandler := handler.NewBookHandler(registerBookService, getBookService)
+	// 設定の読み込み (既定値 < 設定ファイル < 環境変数 < フラグ)
+	loader := config.NewLoader(os.LookupEnv)
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	loader.RegisterFlags(fs)
+	printConfig := fs.Bool("print-config", false, "有効な設定を表示して終了する")
+	_ = fs.Parse(os.Args[1:])
 
-	// ルーティングの設定
-	mux := http.NewServeMux()
-	mux.HandleFunc("POST /books", bookHandler.RegisterBook)
-	mux.HandleFunc("GET /books/{isbn}", bookHandler.GetBook)
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("設定の読み込みエラー: %w", err)
+	}
+	if *printConfig {
+		return cfg.Write(os.Stdout)
+	}
 
-	// サーバーの起動
-	port := "8080"
-	log.Printf("サーバーをポート %s で起動しています...", port)
-	if err := http.ListenAndServe(":"+port, mux); err != nil {
-		log.Fatalf("サーバー起動エラー: %v", err)
+	// 構造化ログ。log パッケージの出力もこのロガーへ送られる
+	logger, err := logging.New(os.Stderr, cfg.Log.Format, cfg.Log.Level)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	logger.Info("設定を読み込みました",
+		"http_port", cfg.Server.HTTPPort,
+		"grpc", cfg.Features.GRPC,
+		"grpc_port", cfg.Server.GRPCPort,
+		"graphql", cfg.Features.GraphQL,
+		"event_dispatch_mode", cfg.Events.DispatchMode,
+		"backend", cfg.Database.Backend,
+		"auth", cfg.Auth.Enabled,
+	)
+	if !cfg.Auth.Enabled {
+		logger.Warn("認証が無効です。全てのAPIを誰でも利用できます")
 	}
-}
 
-// getEnv は環境変数を取得し、設定されていない場合はデフォルト値を返します。
-func getEnv(key, fallback string) string {
-	if value, ok := os.LookupEnv(key); ok {
-		return value
+	// 依存関係の解決 (wire_gen.go)
+	initialize := InitializeApp
+	if cfg.Database.Backend == config.BackendMemory {
+		logger.Warn("データはメモリ上に保存されます。サーバーを停止するとデータは失われます")
+		initialize = InitializeInMemoryApp
+	} else {
+		logger.Info("データベースに接続しています", "dsn", cfg.Redacted().Database.ConnString())
 	}
-	return fallback
+	app, cleanup, err := initialize(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// 停止処理中にもう一度シグナルを受けた場合は、停止処理を待たずに即座に終了する
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	return app.Run(ctx)
 }
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/auth"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// APIKeyHeader はAPIキーを受け渡すHTTPヘッダーです。
+const APIKeyHeader = "X-API-Key"
+
+// Auth はリクエストのアクターを認証し、ルートに必要なロールを持っているかを確認するミドルウェアです。
+// 認証されたアクターはコンテキストに格納し、ロガーにもactorとして付与します。
+type Auth struct {
+	authenticators []auth.Authenticator
+	roles          map[string]auth.Role
+}
+
+// NewAuth は新しいAuthを生成します。
+// rolesはmuxに登録したパターン（GET /books/{isbn}）ごとの必要なロールです。rolesにないルートは認証なしで利用できます。
+func NewAuth(authenticators []auth.Authenticator, roles map[string]auth.Role) *Auth {
+	return &Auth{authenticators: authenticators, roles: roles}
+}
+
+// Middleware は認証と認可を行うミドルウェアです。
+// 認証情報がないか不正な場合は401を、ロールが足りない場合は403を返します。
+func (a *Auth) Middleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		required, ok := a.roles[pattern]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		logger := logging.FromContext(ctx)
+		principal, err := auth.Authenticate(ctx, a.authenticators, credentialsFromRequest(r))
+		if err != nil {
+			logger.Warn("認証に失敗しました", "error", err)
+			unauthorized(w, r, i18n.MsgInvalidCredentials)
+			return
+		}
+		if principal == nil {
+			unauthorized(w, r, i18n.MsgAuthenticationRequired)
+			return
+		}
+
+		logger = logger.With("actor", principal.ID)
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("enduser.id", principal.ID))
+		if !principal.Can(required) {
+			logger.Warn("ロールが不足しています", "required_role", required, "roles", principal.Roles)
+			response.Error(w, r, http.StatusForbidden, response.CodeForbidden, i18n.MsgRoleRequired, required)
+			return
+		}
+
+		ctx = auth.WithPrincipal(ctx, principal)
+		ctx = logging.WithLogger(ctx, logger)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// credentialsFromRequest はX-API-KeyヘッダーとAuthorization: Bearer ヘッダーから認証情報を取り出します。
+func credentialsFromRequest(r *http.Request) auth.Credentials {
+	return auth.Credentials{
+		APIKey:      r.Header.Get(APIKeyHeader),
+		BearerToken: auth.BearerToken(r.Header.Get("Authorization")),
+	}
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, key i18n.Key) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="book-api"`)
+	response.Error(w, r, http.StatusUnauthorized, response.CodeUnauthorized, key)
+}
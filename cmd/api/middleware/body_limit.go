@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/i18n"
+	"errors"
+	"net/http"
+)
+
+// BodyLimit はリクエストボディの大きさを制限するミドルウェアです。
+type BodyLimit struct {
+	defaultMax int64
+	routes     map[string]int64
+}
+
+// NewBodyLimit は新しいBodyLimitを生成します。
+// routesはmuxに登録したパターン（POST /books/import）ごとに、defaultMaxの代わりに適用する上限（バイト）です。
+func NewBodyLimit(defaultMax int64, routes map[string]int64) *BodyLimit {
+	return &BodyLimit{defaultMax: defaultMax, routes: routes}
+}
+
+// Middleware はリクエストボディの大きさを制限するミドルウェアです。
+// Content-Lengthが上限を超える場合はボディを読まずに413を返します。
+// Content-Lengthがない場合（チャンク転送など）は上限を超えた時点で読み込みをエラーにし、読み込んだハンドラーが413を返します。
+func (b *BodyLimit) Middleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		limit, ok := b.routes[pattern]
+		if !ok {
+			limit = b.defaultMax
+		}
+		if r.ContentLength > limit {
+			response.Error(w, r, http.StatusRequestEntityTooLarge, response.CodePayloadTooLarge, i18n.MsgPayloadTooLarge, limit)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bodyReadStatus はリクエストボディの読み込みエラーに対応するステータスコードを返します。
+// 上限を超えた場合は413、それ以外は400です。
+func bodyReadStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
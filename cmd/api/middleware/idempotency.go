@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/application/idempotency"
+	"ddd-hands-on-go/internal/auth"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// 冪等キーのHTTPヘッダー
+const (
+	// IdempotencyKeyHeader はクライアントがリクエストごとに割り当てる冪等キーです。
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader は保存したレスポンスを再送した場合にtrueを返すヘッダーです。
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength は冪等キーの最大長です。
+const maxIdempotencyKeyLength = 255
+
+// Idempotency はIdempotency-Keyヘッダーを指定した更新系のリクエストを1回だけ実行するミドルウェアです。
+// 同じキーで再送されたリクエストには、ハンドラーを実行せずに最初のレスポンスを返します。
+type Idempotency struct {
+	service *idempotency.Service
+	routes  map[string]bool
+}
+
+// NewIdempotency は新しいIdempotencyを生成します。
+// routesはmuxに登録したパターン（POST /books）のうち、冪等キーを受け付けるものです。
+func NewIdempotency(service *idempotency.Service, routes map[string]bool) *Idempotency {
+	return &Idempotency{service: service, routes: routes}
+}
+
+// Middleware は冪等キーを処理するミドルウェアです。
+// ハンドラーは冪等キーの予約と同じトランザクション内で実行され、成功（2xx）したレスポンスはコミット後に返します。
+// 冪等キーが異なる内容のリクエストで再利用された場合は422を返します。
+func (m *Idempotency) Middleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		_, pattern := mux.Handler(r)
+		if key == "" || !m.routes[pattern] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !validIdempotencyKey(key) {
+			response.Error(w, r, http.StatusBadRequest, response.CodeBadRequest, i18n.MsgInvalidIdempotencyKey, IdempotencyKeyHeader, maxIdempotencyKeyLength)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			status := bodyReadStatus(err)
+			response.Error(w, r, status, response.CodeForStatus(status), i18n.MsgInvalidRequestBody, err)
+			return
+		}
+
+		ctx := r.Context()
+		req := idempotency.Request{
+			Actor:       auth.Actor(ctx),
+			Key:         key,
+			Fingerprint: idempotency.Fingerprint(r.Method, r.URL.Path, body),
+		}
+		resp, replayed, err := m.service.Execute(ctx, req, func(ctx context.Context) (*idempotency.Response, error) {
+			rec := &responseRecorder{header: make(http.Header), status: http.StatusOK}
+			// トランザクションが再試行された場合に備えて、実行のたびにボディを読み直せるようにする
+			inner := r.WithContext(ctx)
+			inner.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(rec, inner)
+			return &idempotency.Response{StatusCode: rec.status, Header: rec.header, Body: rec.body.Bytes()}, nil
+		})
+		if errors.Is(err, idempotency.ErrKeyReused) {
+			response.Error(w, r, http.StatusUnprocessableEntity, response.CodeIdempotencyKeyReused, i18n.MsgIdempotencyKeyReused, key)
+			return
+		}
+		if err != nil {
+			logging.FromContext(ctx).Error("冪等キーの処理に失敗しました", "idempotency_key", key, "error", err)
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, i18n.MsgIdempotencyFailed)
+			return
+		}
+
+		for k, values := range resp.Header {
+			w.Header()[k] = values
+		}
+		if replayed {
+			w.Header().Set(IdempotentReplayedHeader, "true")
+		}
+		w.WriteHeader(resp.StatusCode)
+		_, _ = w.Write(resp.Body)
+	})
+}
+
+// validIdempotencyKey は冪等キーが1〜255文字の表示可能なASCII文字（空白を含まない）かどうかを返します。
+func validIdempotencyKey(key string) bool {
+	if len(key) == 0 || len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
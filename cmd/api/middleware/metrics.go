@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"ddd-hands-on-go/internal/metrics"
+	"net/http"
+	"time"
+)
+
+// HTTPMetrics はリクエストの件数と処理時間をルートごとに記録します。
+type HTTPMetrics struct {
+	metrics *metrics.Metrics
+}
+
+// NewHTTPMetrics は新しいHTTPMetricsを生成します。
+func NewHTTPMetrics(m *metrics.Metrics) *HTTPMetrics {
+	return &HTTPMetrics{metrics: m}
+}
+
+// Middleware はリクエストを計測するミドルウェアです。
+// ルートはパス（/books/978-...）ではなくmuxに登録したパターン（GET /books/{isbn}）で集計し、
+// どのパターンにも一致しないリクエストはmetrics.UnmatchedRouteとして集計します。
+func (m *HTTPMetrics) Middleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		route := metrics.UnmatchedRoute
+		if _, pattern := mux.Handler(r); pattern != "" {
+			route = pattern
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			m.metrics.ObserveHTTPRequest(route, r.Method, rec.status, time.Since(start))
+		}()
+
+		next.ServeHTTP(rec, r)
+	})
+}
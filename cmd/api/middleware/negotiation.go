@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/i18n"
+	"net/http"
+	"strings"
+)
+
+// Locale はAccept-Languageヘッダーからメッセージの言語を選び、コンテキストに格納するミドルウェアです。
+// エラーレスポンスのメッセージはこの言語で返し、Content-Languageヘッダーに言語を設定します。
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+		ctx := i18n.WithLocale(r.Context(), i18n.ParseAcceptLanguage(r.Header.Get("Accept-Language")))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Negotiation はAcceptヘッダーに基づいてレスポンスの形式を選ぶミドルウェアです。
+type Negotiation struct {
+	routes map[string][]string
+}
+
+// NewNegotiation は新しいNegotiationを生成します。
+// routesはmuxに登録したパターン（GET /books）ごとの、返せるメディアタイプ（優先順）です。routesにないルートは形式を選びません。
+func NewNegotiation(routes map[string][]string) *Negotiation {
+	return &Negotiation{routes: routes}
+}
+
+// Middleware はルートが返せる形式のうちAcceptヘッダーで受け付けられるものを選び、コンテキストに格納します。
+// 受け付けられる形式がない場合は406を返します。
+// muxに登録されていないパスとメソッドに対するmuxのエラー（404・405）も、JSONのエラーレスポンスに置き換えます。
+func (n *Negotiation) Middleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		if pattern == "" {
+			serveUnmatched(mux, w, r)
+			return
+		}
+		offers, ok := n.routes[pattern]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept")
+		mediaType := response.Negotiate(r.Header.Get("Accept"), offers)
+		if mediaType == "" {
+			response.Error(w, r, http.StatusNotAcceptable, response.CodeNotAcceptable, i18n.MsgNotAcceptable, strings.Join(offers, ", "))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(response.WithMediaType(r.Context(), mediaType)))
+	})
+}
+
+// serveUnmatched はmuxが返すエラーのステータスコードとAllowヘッダーを、JSONのエラーレスポンスで返します。
+func serveUnmatched(mux *http.ServeMux, w http.ResponseWriter, r *http.Request) {
+	rec := &responseRecorder{header: make(http.Header), status: http.StatusOK}
+	mux.ServeHTTP(rec, r)
+	if allow := rec.header.Get("Allow"); allow != "" {
+		w.Header().Set("Allow", allow)
+	}
+	switch rec.status {
+	case http.StatusMethodNotAllowed:
+		response.Error(w, r, rec.status, response.CodeMethodNotAllowed, i18n.MsgMethodNotAllowed)
+	case http.StatusNotFound:
+		response.Error(w, r, rec.status, response.CodeNotFound, i18n.MsgRouteNotFound)
+	default:
+		// リダイレクト（末尾のスラッシュの補完など）はそのまま返す
+		rec.flush(w)
+	}
+}
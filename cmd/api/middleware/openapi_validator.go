@@ -0,0 +1,566 @@
+// Package middleware はHTTPハンドラーに共通の前後処理を提供するミドルウェアを実装します。
+package middleware
+
+import (
+	"bytes"
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// errUnsupportedMediaType はリクエストのContent-Typeが仕様に記述されていないことを表すエラーです。
+var errUnsupportedMediaType = errors.New("サポートされていないContent-Typeです")
+
+// OpenAPIValidator はOpenAPI 3ドキュメントに基づいてリクエスト（および任意でレスポンス）を検証します。
+// サポートするのは本APIの仕様で使用している機能のサブセットです
+// （$ref、type、properties、required、additionalProperties: false、enum、minimum/maximum、minLength/maxLength、items）。
+type OpenAPIValidator struct {
+	doc               *openAPIDocument
+	routes            []*openAPIRoute
+	validateResponses bool
+}
+
+// OpenAPIValidatorOption はOpenAPIValidatorの設定を変更する関数です。
+type OpenAPIValidatorOption func(*OpenAPIValidator)
+
+// WithResponseValidation はレスポンスの検証を有効にします。
+// レスポンス全体をバッファリングするため、テストでの使用を想定しています。
+func WithResponseValidation() OpenAPIValidatorOption {
+	return func(v *OpenAPIValidator) {
+		v.validateResponses = true
+	}
+}
+
+// NewOpenAPIValidator はOpenAPIドキュメント(JSON)から新しいOpenAPIValidatorを生成します。
+func NewOpenAPIValidator(spec []byte, opts ...OpenAPIValidatorOption) (*OpenAPIValidator, error) {
+	var doc openAPIDocument
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("OpenAPIドキュメントの読み込みに失敗しました: %w", err)
+	}
+
+	v := &OpenAPIValidator{doc: &doc}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	for path, item := range doc.Paths {
+		for method, op := range item.operations() {
+			params, err := v.resolveParameters(append(append([]*openAPIParameter{}, item.Parameters...), op.Parameters...))
+			if err != nil {
+				return nil, err
+			}
+			v.routes = append(v.routes, &openAPIRoute{
+				method:    method,
+				segments:  splitPath(path),
+				operation: op,
+				params:    params,
+			})
+		}
+	}
+
+	return v, nil
+}
+
+// Middleware はリクエストを検証し、仕様に適合しない場合は400を返すミドルウェアです。
+// 仕様に記述されていないルートはそのまま次のハンドラーへ渡します。
+func (v *OpenAPIValidator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams := v.findRoute(r.Method, r.URL.Path)
+		if route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := v.validateRequest(r, route, pathParams); err != nil {
+			status := bodyReadStatus(err)
+			if errors.Is(err, errUnsupportedMediaType) {
+				status = http.StatusUnsupportedMediaType
+			}
+			response.Error(w, r, status, response.CodeForStatus(status), i18n.MsgRequestSpecViolation, err)
+			return
+		}
+
+		if !v.validateResponses {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &responseRecorder{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if err := v.validateResponse(route, rec); err != nil {
+			logging.FromContext(r.Context()).Error("レスポンスがAPI仕様に適合しません",
+				"method", r.Method, "path", r.URL.Path, "error", err)
+			response.Error(w, r, http.StatusInternalServerError, response.CodeInternal, i18n.MsgResponseSpecViolation, err)
+			return
+		}
+		rec.flush(w)
+	})
+}
+
+// findRoute はメソッドとパスに一致するルートを返します。
+// 複数のルートが一致する場合は、固定セグメントの多いルートを優先します。
+func (v *OpenAPIValidator) findRoute(method, path string) (*openAPIRoute, map[string]string) {
+	segments := splitPath(path)
+
+	var best *openAPIRoute
+	var bestParams map[string]string
+	bestLiterals := -1
+	for _, route := range v.routes {
+		if route.method != method {
+			continue
+		}
+		params, literals, ok := route.match(segments)
+		if ok && literals > bestLiterals {
+			best, bestParams, bestLiterals = route, params, literals
+		}
+	}
+	return best, bestParams
+}
+
+func (v *OpenAPIValidator) validateRequest(r *http.Request, route *openAPIRoute, pathParams map[string]string) error {
+	query := r.URL.Query()
+	for _, p := range route.params {
+		var raw string
+		var present bool
+		switch p.In {
+		case "path":
+			raw, present = pathParams[p.Name]
+		case "query":
+			present = query.Has(p.Name)
+			raw = query.Get(p.Name)
+		case "header":
+			raw = r.Header.Get(p.Name)
+			present = raw != ""
+		default:
+			continue
+		}
+
+		if !present {
+			if p.Required {
+				return fmt.Errorf("パラメータ %s は必須です", p.Name)
+			}
+			continue
+		}
+		if p.Schema == nil {
+			continue
+		}
+		value, err := coerceParameter(raw, v.resolveSchema(p.Schema))
+		if err != nil {
+			return fmt.Errorf("パラメータ %s: %w", p.Name, err)
+		}
+		if err := v.validateValue(p.Schema, value, p.Name); err != nil {
+			return err
+		}
+	}
+
+	body := route.operation.RequestBody
+	if body == nil {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	content, ok := body.Content[mediaType]
+	if !ok {
+		if r.ContentLength == 0 && !body.Required {
+			return nil
+		}
+		return fmt.Errorf("%w: %q", errUnsupportedMediaType, r.Header.Get("Content-Type"))
+	}
+	// JSON以外（CSVなど）はストリームで処理できるよう、読み込まずにそのまま渡す
+	if mediaType != "application/json" || content.Schema == nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("リクエストボディの読み込みに失敗しました: %w", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		if body.Required {
+			return fmt.Errorf("リクエストボディは必須です")
+		}
+		return nil
+	}
+
+	value, err := decodeJSON(data)
+	if err != nil {
+		return fmt.Errorf("リクエストボディがJSONとして不正です: %w", err)
+	}
+	return v.validateValue(content.Schema, value, "$")
+}
+
+func (v *OpenAPIValidator) validateResponse(route *openAPIRoute, rec *responseRecorder) error {
+	resp := route.operation.Responses[strconv.Itoa(rec.status)]
+	if resp == nil {
+		resp = route.operation.Responses[fmt.Sprintf("%dXX", rec.status/100)]
+	}
+	if resp == nil {
+		resp = route.operation.Responses["default"]
+	}
+	if resp == nil {
+		return fmt.Errorf("ステータスコード %d は仕様に記述されていません", rec.status)
+	}
+	resp = v.resolveResponse(resp)
+
+	if len(resp.Content) == 0 {
+		if rec.body.Len() > 0 {
+			return fmt.Errorf("ステータスコード %d ではレスポンスボディを返せません", rec.status)
+		}
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(rec.header.Get("Content-Type"))
+	content, ok := resp.Content[mediaType]
+	if !ok {
+		return fmt.Errorf("ステータスコード %d のContent-Type %q は仕様に記述されていません", rec.status, mediaType)
+	}
+	if mediaType != "application/json" || content.Schema == nil {
+		return nil
+	}
+
+	value, err := decodeJSON(rec.body.Bytes())
+	if err != nil {
+		return fmt.Errorf("レスポンスボディがJSONとして不正です: %w", err)
+	}
+	return v.validateValue(content.Schema, value, "$")
+}
+
+// validateValue は値がスキーマに適合するかを検証します。pathはエラーメッセージに使用する値の位置です。
+func (v *OpenAPIValidator) validateValue(s *openAPISchema, value interface{}, path string) error {
+	s = v.resolveSchema(s)
+
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		return fmt.Errorf("%s: 許可されていない値です: %v", path, value)
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: オブジェクトである必要があります", path)
+		}
+		return v.validateObject(s, obj, path)
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: 配列である必要があります", path)
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				if err := v.validateValue(s.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: 文字列である必要があります", path)
+		}
+		length := len([]rune(str))
+		if s.MinLength != nil && length < *s.MinLength {
+			return fmt.Errorf("%s: %d文字以上である必要があります", path, *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			return fmt.Errorf("%s: %d文字以下である必要があります", path, *s.MaxLength)
+		}
+	case "number", "integer":
+		num, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: 数値である必要があります", path)
+		}
+		f, err := num.Float64()
+		if err != nil {
+			return fmt.Errorf("%s: 数値である必要があります", path)
+		}
+		if s.Type == "integer" {
+			if _, err := num.Int64(); err != nil {
+				return fmt.Errorf("%s: 整数である必要があります", path)
+			}
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			return fmt.Errorf("%s: %v以上である必要があります", path, *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			return fmt.Errorf("%s: %v以下である必要があります", path, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: 真偽値である必要があります", path)
+		}
+	}
+
+	return nil
+}
+
+func (v *OpenAPIValidator) validateObject(s *openAPISchema, obj map[string]interface{}, path string) error {
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			return fmt.Errorf("%s.%s: 必須項目です", path, name)
+		}
+	}
+
+	// エラーメッセージを安定させるため、キーの順序を固定して検証する
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		prop, ok := s.Properties[k]
+		if !ok {
+			if s.disallowsAdditionalProperties() {
+				return fmt.Errorf("%s.%s: 未知の項目です", path, k)
+			}
+			continue
+		}
+		if err := v.validateValue(prop, obj[k], path+"."+k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *OpenAPIValidator) resolveSchema(s *openAPISchema) *openAPISchema {
+	for s.Ref != "" {
+		resolved, ok := v.doc.Components.Schemas[refName(s.Ref, "schemas")]
+		if !ok {
+			return &openAPISchema{}
+		}
+		s = resolved
+	}
+	return s
+}
+
+func (v *OpenAPIValidator) resolveResponse(r *openAPIResponse) *openAPIResponse {
+	for r.Ref != "" {
+		resolved, ok := v.doc.Components.Responses[refName(r.Ref, "responses")]
+		if !ok {
+			return &openAPIResponse{}
+		}
+		r = resolved
+	}
+	return r
+}
+
+func (v *OpenAPIValidator) resolveParameters(params []*openAPIParameter) ([]*openAPIParameter, error) {
+	resolved := make([]*openAPIParameter, 0, len(params))
+	for _, p := range params {
+		if p.Ref != "" {
+			target, ok := v.doc.Components.Parameters[refName(p.Ref, "parameters")]
+			if !ok {
+				return nil, fmt.Errorf("OpenAPIドキュメントの参照を解決できません: %s", p.Ref)
+			}
+			p = target
+		}
+		resolved = append(resolved, p)
+	}
+	return resolved, nil
+}
+
+// openAPIDocument はOpenAPIドキュメントのうち検証に必要な部分です。
+type openAPIDocument struct {
+	Paths      map[string]*openAPIPathItem `json:"paths"`
+	Components struct {
+		Schemas    map[string]*openAPISchema    `json:"schemas"`
+		Parameters map[string]*openAPIParameter `json:"parameters"`
+		Responses  map[string]*openAPIResponse  `json:"responses"`
+	} `json:"components"`
+}
+
+type openAPIPathItem struct {
+	Parameters []*openAPIParameter `json:"parameters"`
+	Get        *openAPIOperation   `json:"get"`
+	Put        *openAPIOperation   `json:"put"`
+	Post       *openAPIOperation   `json:"post"`
+	Delete     *openAPIOperation   `json:"delete"`
+	Patch      *openAPIOperation   `json:"patch"`
+}
+
+func (p *openAPIPathItem) operations() map[string]*openAPIOperation {
+	ops := make(map[string]*openAPIOperation)
+	for method, op := range map[string]*openAPIOperation{
+		http.MethodGet:    p.Get,
+		http.MethodPut:    p.Put,
+		http.MethodPost:   p.Post,
+		http.MethodDelete: p.Delete,
+		http.MethodPatch:  p.Patch,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+type openAPIOperation struct {
+	Parameters  []*openAPIParameter         `json:"parameters"`
+	RequestBody *openAPIRequestBody         `json:"requestBody"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Ref      string         `json:"$ref"`
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Ref     string                      `json:"$ref"`
+	Content map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref"`
+	Type                 string                    `json:"type"`
+	Properties           map[string]*openAPISchema `json:"properties"`
+	Required             []string                  `json:"required"`
+	AdditionalProperties json.RawMessage           `json:"additionalProperties"`
+	Items                *openAPISchema            `json:"items"`
+	Enum                 []interface{}             `json:"enum"`
+	Minimum              *float64                  `json:"minimum"`
+	Maximum              *float64                  `json:"maximum"`
+	MinLength            *int                      `json:"minLength"`
+	MaxLength            *int                      `json:"maxLength"`
+}
+
+func (s *openAPISchema) disallowsAdditionalProperties() bool {
+	return string(bytes.TrimSpace(s.AdditionalProperties)) == "false"
+}
+
+// openAPIRoute はパステンプレートとメソッドの組に対応する操作です。
+type openAPIRoute struct {
+	method    string
+	segments  []string
+	operation *openAPIOperation
+	params    []*openAPIParameter
+}
+
+// match はパスのセグメントがルートに一致するかを判定し、パスパラメータと固定セグメントの数を返します。
+func (r *openAPIRoute) match(segments []string) (map[string]string, int, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, 0, false
+	}
+	params := make(map[string]string)
+	literals := 0
+	for i, seg := range r.segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if segments[i] == "" {
+				return nil, 0, false
+			}
+			params[seg[1:len(seg)-1]] = segments[i]
+			continue
+		}
+		if seg != segments[i] {
+			return nil, 0, false
+		}
+		literals++
+	}
+	return params, literals, true
+}
+
+// responseRecorder はレスポンスを検証するためにバッファリングするResponseWriterです。
+type responseRecorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.status = status
+	r.wroteHeader = true
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) flush(w http.ResponseWriter) {
+	for k, values := range r.header {
+		w.Header()[k] = values
+	}
+	w.WriteHeader(r.status)
+	_, _ = w.Write(r.body.Bytes())
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func refName(ref, kind string) string {
+	return strings.TrimPrefix(ref, "#/components/"+kind+"/")
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("複数のJSON値が含まれています")
+	}
+	return value, nil
+}
+
+// coerceParameter はパス・クエリ・ヘッダーの文字列値をスキーマの型に変換します。
+func coerceParameter(raw string, s *openAPISchema) (interface{}, error) {
+	switch s.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return nil, fmt.Errorf("数値である必要があります")
+		}
+		return json.Number(raw), nil
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("真偽値である必要があります")
+		}
+		return b, nil
+	default:
+		return raw, nil
+	}
+}
+
+func containsValue(candidates []interface{}, value interface{}) bool {
+	for _, c := range candidates {
+		if fmt.Sprint(c) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
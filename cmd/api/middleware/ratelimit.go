@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"ddd-hands-on-go/cmd/api/response"
+	"ddd-hands-on-go/internal/auth"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/ratelimit"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitConfig はRateLimiterの設定です。
+type RateLimitConfig struct {
+	// Default は全てのルートに適用するクライアントごとのレートです。ゼロ値の場合は制限しません。
+	Default ratelimit.Rate
+	// Routes はmuxに登録したパターン（POST /books）ごとに、Defaultに加えて適用するレートです。
+	Routes map[string]ratelimit.Rate
+	// AuthFailures は認証に失敗したリクエスト（401）に適用するIPアドレスごとのレートです。ゼロ値の場合は制限しません。
+	// 認証情報を総当たりで試すクライアントは、アクターで区別するDefaultやRoutesでは制限できないため、別に制限します。
+	AuthFailures ratelimit.Rate
+	// Exempt はレート制限を適用しないルートのパターンです。
+	Exempt map[string]bool
+	// TrustForwardedFor がtrueの場合、X-Forwarded-Forヘッダーの末尾（直前のプロキシが追加した値）をクライアントのIPアドレスとします。
+	// 信頼できるリバースプロキシの背後で動作する場合にのみ有効にしてください。
+	TrustForwardedFor bool
+}
+
+// RateLimiter はクライアントごとのトークンバケットでリクエストの頻度を制限するミドルウェアです。
+// クライアントは認証されたアクター（APIキー・JWTのアクター）で、認証されていない場合はIPアドレスで区別します。
+// 認証に失敗したリクエストは、AuthFailureMiddlewareでIPアドレスごとに別に制限します。
+type RateLimiter struct {
+	defaultLimiter    *ratelimit.Limiter
+	routeLimiters     map[string]*ratelimit.Limiter
+	authFailures      *ratelimit.Limiter
+	exempt            map[string]bool
+	trustForwardedFor bool
+}
+
+// NewRateLimiter は新しいRateLimiterを生成します。
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	l := &RateLimiter{
+		routeLimiters:     make(map[string]*ratelimit.Limiter, len(cfg.Routes)),
+		exempt:            cfg.Exempt,
+		trustForwardedFor: cfg.TrustForwardedFor,
+	}
+	if !cfg.Default.IsZero() {
+		l.defaultLimiter = ratelimit.NewLimiter(cfg.Default)
+	}
+	if !cfg.AuthFailures.IsZero() {
+		l.authFailures = ratelimit.NewLimiter(cfg.AuthFailures)
+	}
+	for pattern, rate := range cfg.Routes {
+		l.routeLimiters[pattern] = ratelimit.NewLimiter(rate)
+	}
+	return l
+}
+
+// Middleware はレート制限を行うミドルウェアです。
+// 上限を超えた場合は、次のリクエストを受け付けるまでの秒数をRetry-Afterヘッダーに設定して429を返します。
+// アクターで区別するため、認証の後に適用します。
+func (l *RateLimiter) Middleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		if l.exempt[pattern] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client := l.clientKey(r)
+		for _, limiter := range []*ratelimit.Limiter{l.defaultLimiter, l.routeLimiters[pattern]} {
+			if limiter == nil {
+				continue
+			}
+			if ok, retryAfter := limiter.Allow(client); !ok {
+				tooManyRequests(w, r, client, pattern, retryAfter)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AuthFailureMiddleware は認証に失敗したリクエストの頻度をIPアドレスごとに制限するミドルウェアです。
+// 認証に失敗した（401を返した）リクエストごとにIPアドレスのトークンを取り出し、トークンがなくなったIPアドレスからのリクエストは
+// 認証の前に429で拒否します。認証されたリクエストはトークンを消費しません。
+// 認証されていないリクエストを制限するため、認証の前（外側）に適用します。
+func (l *RateLimiter) AuthFailureMiddleware(mux *http.ServeMux, next http.Handler) http.Handler {
+	if l.authFailures == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		if l.exempt[pattern] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client := "ip:" + l.clientIP(r)
+		if ok, retryAfter := l.authFailures.Available(client); !ok {
+			tooManyRequests(w, r, client, pattern, retryAfter)
+			return
+		}
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		if rec.status == http.StatusUnauthorized {
+			l.authFailures.Allow(client)
+		}
+	})
+}
+
+// tooManyRequests は次のリクエストを受け付けるまでの秒数をRetry-Afterヘッダーに設定して429を返します。
+func tooManyRequests(w http.ResponseWriter, r *http.Request, client, pattern string, retryAfter time.Duration) {
+	seconds := retryAfterSeconds(retryAfter)
+	logging.FromContext(r.Context()).Warn("レート制限を超えました", "client", client, "route", pattern, "retry_after", seconds)
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	response.Error(w, r, http.StatusTooManyRequests, response.CodeTooManyRequests, i18n.MsgTooManyRequests, seconds)
+}
+
+// clientKey はリクエストを送ったクライアントを区別するキーを返します。
+func (l *RateLimiter) clientKey(r *http.Request) string {
+	if actor := auth.Actor(r.Context()); actor != "" {
+		return "actor:" + actor
+	}
+	return "ip:" + l.clientIP(r)
+}
+
+// clientIP はクライアントのIPアドレスを返します。
+func (l *RateLimiter) clientIP(r *http.Request) string {
+	if l.trustForwardedFor {
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			hops := strings.Split(forwarded[len(forwarded)-1], ",")
+			if ip := strings.TrimSpace(hops[len(hops)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// retryAfterSeconds はRetry-Afterヘッダーに設定する秒数（切り上げ、最短1秒）を返します。
+func retryAfterSeconds(d time.Duration) int {
+	return max(1, int(math.Ceil(d.Seconds())))
+}
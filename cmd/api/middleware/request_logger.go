@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// RequestIDHeader はリクエストIDを受け渡すHTTPヘッダーです。
+const RequestIDHeader = "X-Request-ID"
+
+// RequestLogger はリクエストごとにリクエストIDを割り当て、リクエストIDを付与したロガーをコンテキストに格納します。
+// クライアントがX-Request-IDヘッダーを指定した場合はその値を引き継ぎ、レスポンスのヘッダーにも同じ値を返します。
+type RequestLogger struct {
+	logger *slog.Logger
+}
+
+// NewRequestLogger は新しいRequestLoggerを生成します。
+func NewRequestLogger(logger *slog.Logger) *RequestLogger {
+	return &RequestLogger{logger: logger}
+}
+
+// Middleware はリクエストIDの割り当てと、リクエストの完了時のアクセスログの出力を行うミドルウェアです。
+func (l *RequestLogger) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(RequestIDHeader)
+		if !logging.ValidRequestID(requestID) {
+			requestID = logging.NewRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		logger := l.logger.With(logging.RequestIDKey, requestID)
+		// Tracingの内側で実行された場合は、トレースIDからログとトレースを結び付けられるようにする
+		if traceID := tracing.TraceID(r.Context()); traceID != "" {
+			logger = logger.With("trace_id", traceID)
+		}
+		ctx := logging.WithRequestID(r.Context(), requestID)
+		ctx = logging.WithLogger(ctx, logger)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			level := slog.LevelInfo
+			if rec.status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(ctx, level, "リクエストを処理しました",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", rec.status),
+				slog.Int64("bytes", rec.bytes),
+				slog.Duration("duration", time.Since(start)),
+			)
+		}()
+
+		next.ServeHTTP(rec, r.WithContext(ctx))
+	})
+}
+
+// statusRecorder はアクセスログのためにステータスコードと書き込んだバイト数を記録するResponseWriterです。
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap はhttp.ResponseControllerが元のResponseWriterの機能（書き込み期限の変更など）を使用できるようにします。
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,448 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"ddd-hands-on-go/api"
+	bookv1 "ddd-hands-on-go/api/gen/book/v1"
+	"ddd-hands-on-go/cmd/api/graphqlserver"
+	"ddd-hands-on-go/cmd/api/grpcserver"
+	"ddd-hands-on-go/cmd/api/handler"
+	"ddd-hands-on-go/cmd/api/middleware"
+	"ddd-hands-on-go/internal/application/author"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/application/category"
+	"ddd-hands-on-go/internal/application/idempotency"
+	"ddd-hands-on-go/internal/application/publisher"
+	"ddd-hands-on-go/internal/auth"
+	"ddd-hands-on-go/internal/config"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/infrastructure/event"
+	"ddd-hands-on-go/internal/infrastructure/memory"
+	"ddd-hands-on-go/internal/infrastructure/postgres"
+	"ddd-hands-on-go/internal/infrastructure/subscriber"
+	"ddd-hands-on-go/internal/metrics"
+	"ddd-hands-on-go/internal/ratelimit"
+	"ddd-hands-on-go/internal/tracing"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/wire"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+)
+
+// 依存関係はレイヤーごとのプロバイダーセットとして定義し、wire.goのインジェクターで組み合わせます。
+// プロバイダーセットを変更した場合は `go generate ./cmd/api` でwire_gen.goを再生成してください。
+
+// postgresSet はPostgreSQLに保存するリポジトリ・クエリサービス・トランザクションマネージャーです。
+var postgresSet = wire.NewSet(
+	provideDB,
+	postgres.NewPostgresBookRepository,
+	wire.Bind(new(repository.BookRepository), new(*postgres.PostgresBookRepository)),
+	postgres.NewPostgresBookQueryService,
+	wire.Bind(new(book.BookQueryService), new(*postgres.PostgresBookQueryService)),
+	wire.Bind(new(metrics.BookStatusCounter), new(*postgres.PostgresBookQueryService)),
+	postgres.NewPostgresSearchIndex,
+	wire.Bind(new(book.SearchIndex), new(*postgres.PostgresSearchIndex)),
+	postgres.NewPostgresAuthorRepository,
+	wire.Bind(new(repository.AuthorRepository), new(*postgres.PostgresAuthorRepository)),
+	postgres.NewPostgresAuthorQueryService,
+	wire.Bind(new(author.AuthorQueryService), new(*postgres.PostgresAuthorQueryService)),
+	postgres.NewPostgresPublisherRepository,
+	wire.Bind(new(repository.PublisherRepository), new(*postgres.PostgresPublisherRepository)),
+	postgres.NewPostgresPublisherQueryService,
+	wire.Bind(new(publisher.PublisherQueryService), new(*postgres.PostgresPublisherQueryService)),
+	postgres.NewPostgresCategoryRepository,
+	wire.Bind(new(repository.CategoryRepository), new(*postgres.PostgresCategoryRepository)),
+	postgres.NewPostgresCategoryQueryService,
+	wire.Bind(new(category.CategoryQueryService), new(*postgres.PostgresCategoryQueryService)),
+	postgres.NewPostgresStockHistoryQueryService,
+	wire.Bind(new(book.StockHistoryQueryService), new(*postgres.PostgresStockHistoryQueryService)),
+	postgres.NewPostgresTransactionManager,
+	wire.Bind(new(shared.TransactionManager), new(*postgres.PostgresTransactionManager)),
+	postgres.NewPostgresIdempotencyStore,
+	wire.Bind(new(idempotency.Store), new(*postgres.PostgresIdempotencyStore)),
+	providePostgresHealthChecks,
+)
+
+// inMemorySet はメモリ上に保存するリポジトリ・クエリサービス・トランザクションマネージャーです。
+var inMemorySet = wire.NewSet(
+	memory.NewStore,
+	memory.NewInMemoryBookRepository,
+	wire.Bind(new(repository.BookRepository), new(*memory.InMemoryBookRepository)),
+	memory.NewInMemoryBookQueryService,
+	wire.Bind(new(book.BookQueryService), new(*memory.InMemoryBookQueryService)),
+	wire.Bind(new(metrics.BookStatusCounter), new(*memory.InMemoryBookQueryService)),
+	memory.NewInMemorySearchIndex,
+	wire.Bind(new(book.SearchIndex), new(*memory.InMemorySearchIndex)),
+	memory.NewInMemoryAuthorRepository,
+	wire.Bind(new(repository.AuthorRepository), new(*memory.InMemoryAuthorRepository)),
+	memory.NewInMemoryAuthorQueryService,
+	wire.Bind(new(author.AuthorQueryService), new(*memory.InMemoryAuthorQueryService)),
+	memory.NewInMemoryPublisherRepository,
+	wire.Bind(new(repository.PublisherRepository), new(*memory.InMemoryPublisherRepository)),
+	memory.NewInMemoryPublisherQueryService,
+	wire.Bind(new(publisher.PublisherQueryService), new(*memory.InMemoryPublisherQueryService)),
+	memory.NewInMemoryCategoryRepository,
+	wire.Bind(new(repository.CategoryRepository), new(*memory.InMemoryCategoryRepository)),
+	memory.NewInMemoryCategoryQueryService,
+	wire.Bind(new(category.CategoryQueryService), new(*memory.InMemoryCategoryQueryService)),
+	memory.NewInMemoryStockHistoryQueryService,
+	wire.Bind(new(book.StockHistoryQueryService), new(*memory.InMemoryStockHistoryQueryService)),
+	memory.NewInMemoryTransactionManager,
+	wire.Bind(new(shared.TransactionManager), new(*memory.InMemoryTransactionManager)),
+	memory.NewInMemoryIdempotencyStore,
+	wire.Bind(new(idempotency.Store), new(*memory.InMemoryIdempotencyStore)),
+	provideInMemoryHealthChecks,
+)
+
+// eventSet はドメインイベントの配信とサブスクライバーです。
+var eventSet = wire.NewSet(
+	provideEventEmitter,
+	wire.Bind(new(shared.DomainEventPublisher), new(*event.EventEmitter)),
+	wire.Bind(new(shared.DomainEventSubscriber), new(*event.EventEmitter)),
+	subscriber.NewLogSubscriber,
+)
+
+// metricsSet はPrometheus形式のメトリクスです。
+var metricsSet = wire.NewSet(
+	provideMetrics,
+)
+
+// tracingSet はOpenTelemetryによるトレースの出力です。
+var tracingSet = wire.NewSet(
+	provideTracerProvider,
+)
+
+// authSet はAPIの利用者の認証です。
+var authSet = wire.NewSet(
+	provideAuthenticators,
+)
+
+// domainSet はドメインサービスです。
+var domainSet = wire.NewSet(
+	service.NewISBNDuplicationCheckDomainService,
+	service.NewBookReferenceCheckDomainService,
+	service.NewCategoryHierarchyDomainService,
+)
+
+// applicationSet はアプリケーションサービスです。
+var applicationSet = wire.NewSet(
+	book.NewBookDTOAssembler,
+	book.NewRegisterBookApplicationService,
+	book.NewGetBookApplicationService,
+	book.NewUpdateBookApplicationService,
+	book.NewDeleteBookApplicationService,
+	book.NewAdjustStockApplicationService,
+	book.NewListBooksApplicationService,
+	book.NewSearchBooksApplicationService,
+	book.NewImportBooksApplicationService,
+	book.NewExportBooksApplicationService,
+	book.NewAssignCategoriesApplicationService,
+	book.NewListStockHistoryApplicationService,
+	author.NewRegisterAuthorApplicationService,
+	author.NewGetAuthorApplicationService,
+	author.NewListAuthorsApplicationService,
+	author.NewUpdateAuthorApplicationService,
+	author.NewDeleteAuthorApplicationService,
+	publisher.NewRegisterPublisherApplicationService,
+	publisher.NewGetPublisherApplicationService,
+	publisher.NewListPublishersApplicationService,
+	publisher.NewUpdatePublisherApplicationService,
+	publisher.NewDeletePublisherApplicationService,
+	category.NewRegisterCategoryApplicationService,
+	category.NewGetCategoryApplicationService,
+	category.NewListCategoriesApplicationService,
+	category.NewUpdateCategoryApplicationService,
+	category.NewDeleteCategoryApplicationService,
+	provideIdempotencyService,
+)
+
+// presentationSet はHTTP・GraphQL・gRPCのハンドラーとサーバーです。
+var presentationSet = wire.NewSet(
+	handler.NewBookHandler,
+	handler.NewCatalogHandler,
+	handler.NewAuthorHandler,
+	handler.NewPublisherHandler,
+	handler.NewCategoryHandler,
+	middleware.NewRequestLogger,
+	middleware.NewHTTPMetrics,
+	provideRateLimiter,
+	grpcserver.NewRequestLogger,
+	provideHealthHandler,
+	graphqlserver.NewResolver,
+	provideGraphQLHandler,
+	grpcserver.NewBookWatcher,
+	grpcserver.NewBookServer,
+	provideGRPCServer,
+	provideHTTPServer,
+	wire.Struct(new(App), "*"),
+)
+
+// provideDB はデータベースに接続し、接続を閉じるクリーンアップ関数とともに返します。
+func provideDB(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*sql.DB, func(), error) {
+	db, err := postgres.NewDB(ctx, postgres.DBConfig{
+		DSN:             cfg.Database.ConnString(),
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.Database.ConnMaxIdleTime,
+		ConnectRetry: postgres.RetryPolicy{
+			MaxAttempts:    cfg.Database.ConnectAttempts,
+			InitialBackoff: cfg.Database.ConnectBackoff,
+			MaxBackoff:     cfg.Database.ConnectMaxBackoff,
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("データベース接続エラー: %w", err)
+	}
+	cleanup := func() {
+		if err := db.Close(); err != nil {
+			logger.Error("データベースのクローズに失敗しました", "error", err)
+		}
+	}
+
+	if cfg.Database.MigrateOnStart {
+		applied, err := postgres.Migrate(ctx, db)
+		for _, m := range applied {
+			logger.Info("マイグレーションを適用しました", "name", m.Name)
+		}
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("マイグレーションの適用に失敗しました: %w", err)
+		}
+	}
+	return db, cleanup, nil
+}
+
+// healthChecks はレディネスプローブで確認する依存先です。
+type healthChecks []handler.HealthCheck
+
+// providePostgresHealthChecks はデータベースへの接続とマイグレーションの適用状況を確認します。
+func providePostgresHealthChecks(db *sql.DB) healthChecks {
+	return healthChecks{
+		postgres.NewDBHealthCheck(db),
+		postgres.NewMigrationHealthCheck(db),
+	}
+}
+
+// provideInMemoryHealthChecks はメモリ上の保存先には確認する依存先がないため、空を返します。
+func provideInMemoryHealthChecks() healthChecks {
+	return nil
+}
+
+func provideHealthHandler(checks healthChecks) *handler.HealthHandler {
+	return handler.NewHealthHandler(checks...)
+}
+
+// provideMetrics はメトリクスを生成し、在庫ステータスごとの書籍数のゲージを登録します。
+// メトリクスが無効な場合はnilを返します。nilのMetricsは何も記録しません。
+func provideMetrics(cfg *config.Config, counter metrics.BookStatusCounter) *metrics.Metrics {
+	if !cfg.Features.Metrics {
+		return nil
+	}
+	m := metrics.New()
+	m.RegisterBookStatusCounter(counter)
+	return m
+}
+
+// provideTracerProvider は設定された出力先へスパンを送信するTracerProviderを生成し、グローバルに登録します。
+// 出力先がnoneの場合はnilを返します。クリーンアップ関数は送信待ちのスパンを出力してから停止します。
+func provideTracerProvider(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*sdktrace.TracerProvider, func(), error) {
+	tp, err := tracing.NewTracerProvider(ctx, tracing.Config{
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		ServiceName:  cfg.Tracing.ServiceName,
+		Stdout:       os.Stdout,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := tracing.Shutdown(ctx, tp); err != nil {
+			logger.Error("トレースの出力の停止に失敗しました", "error", err)
+		}
+	}
+	return tp, cleanup, nil
+}
+
+// provideIdempotencyService は設定された期間だけ冪等キーを保持するServiceを生成します。
+func provideIdempotencyService(cfg *config.Config, store idempotency.Store, txManager shared.TransactionManager) *idempotency.Service {
+	return idempotency.NewService(store, txManager, cfg.Idempotency.TTL)
+}
+
+// authenticators はHTTP・gRPCのリクエストのアクターを認証するAuthenticatorです。
+type authenticators []auth.Authenticator
+
+// provideAuthenticators は設定されたAPIキーとJWTの検証方法からAuthenticatorを生成します。
+// 認証が無効な場合はnilを返し、HTTP・gRPCサーバーは認証と認可を行いません。
+func provideAuthenticators(cfg *config.Config) (authenticators, error) {
+	if !cfg.Auth.Enabled {
+		return nil, nil
+	}
+	var as authenticators
+	if cfg.Auth.APIKeys != "" {
+		a, err := auth.NewAPIKeyAuthenticator(cfg.Auth.APIKeys)
+		if err != nil {
+			return nil, fmt.Errorf("APIキーの設定エラー: %w", err)
+		}
+		as = append(as, a)
+	}
+	if cfg.Auth.JWTSecret != "" || cfg.Auth.JWTPublicKeyFile != "" {
+		a, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+			HMACSecret:    cfg.Auth.JWTSecret,
+			PublicKeyFile: cfg.Auth.JWTPublicKeyFile,
+			Issuer:        cfg.Auth.JWTIssuer,
+			Audience:      cfg.Auth.JWTAudience,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("JWTの設定エラー: %w", err)
+		}
+		as = append(as, a)
+	}
+	return as, nil
+}
+
+// provideRateLimiter は設定されたレートでクライアントごとのリクエストの頻度を制限するRateLimiterを生成します。
+func provideRateLimiter(cfg *config.Config) (*middleware.RateLimiter, error) {
+	defaultRate, err := ratelimit.ParseRate(cfg.Limits.RateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("レート制限の設定エラー: %w", err)
+	}
+	routeRates, err := ratelimit.ParseRouteRates(cfg.Limits.RouteRateLimits)
+	if err != nil {
+		return nil, fmt.Errorf("ルートごとのレート制限の設定エラー: %w", err)
+	}
+	authFailureRate, err := ratelimit.ParseRate(cfg.Limits.AuthFailureRateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("認証の失敗のレート制限の設定エラー: %w", err)
+	}
+	return middleware.NewRateLimiter(middleware.RateLimitConfig{
+		Default:           defaultRate,
+		Routes:            routeRates,
+		AuthFailures:      authFailureRate,
+		Exempt:            handler.RateLimitExemptRoutes,
+		TrustForwardedFor: cfg.Limits.TrustForwardedFor,
+	}), nil
+}
+
+// provideEventEmitter は設定された配信方式のEventEmitterを生成し、サブスクライバーを登録します。
+func provideEventEmitter(cfg *config.Config, m *metrics.Metrics, logSubscriber *subscriber.LogSubscriber) *event.EventEmitter {
+	emitter := event.NewEventEmitter(
+		event.WithDispatchMode(event.DispatchMode(cfg.Events.DispatchMode)),
+		event.WithMetrics(m),
+	)
+	emitter.Subscribe("BookCreated", logSubscriber.Subscribe)
+	return emitter
+}
+
+// provideGraphQLHandler はGraphQLのハンドラーを生成します。GraphQLが無効な場合はnilを返します。
+func provideGraphQLHandler(cfg *config.Config, resolver *graphqlserver.Resolver) (*graphqlserver.Handler, error) {
+	if !cfg.Features.GraphQL {
+		return nil, nil
+	}
+	schema, err := graphqlserver.NewSchema(resolver)
+	if err != nil {
+		return nil, fmt.Errorf("GraphQLスキーマの初期化エラー: %w", err)
+	}
+	return graphqlserver.NewHandler(schema, resolver), nil
+}
+
+// provideGRPCServer はgRPCサーバーを生成します。gRPCが無効な場合はnilを返します。
+// エラーのメッセージはメタデータのaccept-languageで選んだ言語で返します。
+// 認証が有効な場合は、リクエストIDの割り当ての後にアクターの認証とロールの確認を行います。
+func provideGRPCServer(cfg *config.Config, bookServer *grpcserver.BookServer, requestLogger *grpcserver.RequestLogger, authenticators authenticators) *grpc.Server {
+	if !cfg.Features.GRPC {
+		return nil
+	}
+	unary := []grpc.UnaryServerInterceptor{grpcserver.LocaleUnaryInterceptor, requestLogger.UnaryInterceptor}
+	stream := []grpc.StreamServerInterceptor{grpcserver.LocaleStreamInterceptor, requestLogger.StreamInterceptor}
+	if authenticators != nil {
+		authInterceptor := grpcserver.NewAuthInterceptor(authenticators, grpcserver.MethodRoles)
+		unary = append(unary, authInterceptor.UnaryInterceptor)
+		stream = append(stream, authInterceptor.StreamInterceptor)
+	}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	bookv1.RegisterBookServiceServer(server, bookServer)
+	return server
+}
+
+// provideHTTPServer はルーティング、トレースのスパンの開始、リクエストIDの割り当て、メトリクスの記録、OpenAPI仕様によるリクエスト検証を設定したHTTPサーバーを生成します。
+// リクエストボディの大きさはハンドラーの前に制限します。
+// エラーレスポンスはAccept-Languageヘッダーで選んだ言語のJSONで返し、レスポンスの形式はAcceptヘッダーで選びます。
+// 認証が有効な場合は、リクエスト検証の前にアクターの認証とロールの確認を行います。
+// レート制限と冪等キーはアクターごとに区別するため、認証の後に処理します。認証に失敗したリクエストのレート制限は、認証の前にIPアドレスごとに処理します。
+func provideHTTPServer(
+	cfg *config.Config,
+	logger *slog.Logger,
+	m *metrics.Metrics,
+	requestLogger *middleware.RequestLogger,
+	httpMetrics *middleware.HTTPMetrics,
+	authenticators authenticators,
+	rateLimiter *middleware.RateLimiter,
+	idempotencyService *idempotency.Service,
+	bookHandler *handler.BookHandler,
+	catalogHandler *handler.CatalogHandler,
+	authorHandler *handler.AuthorHandler,
+	publisherHandler *handler.PublisherHandler,
+	categoryHandler *handler.CategoryHandler,
+	healthHandler *handler.HealthHandler,
+	graphqlHandler *graphqlserver.Handler,
+) (*http.Server, error) {
+	mux := http.NewServeMux()
+	bookHandler.RegisterRoutes(mux)
+	catalogHandler.RegisterRoutes(mux)
+	authorHandler.RegisterRoutes(mux)
+	publisherHandler.RegisterRoutes(mux)
+	categoryHandler.RegisterRoutes(mux)
+	healthHandler.RegisterRoutes(mux)
+	if graphqlHandler != nil {
+		mux.Handle("POST /graphql", graphqlHandler)
+	}
+	if m != nil {
+		mux.Handle("GET /metrics", m.Handler())
+	}
+
+	var validatorOpts []middleware.OpenAPIValidatorOption
+	if cfg.Features.ResponseValidation {
+		validatorOpts = append(validatorOpts, middleware.WithResponseValidation())
+	}
+	validator, err := middleware.NewOpenAPIValidator(api.OpenAPISpec, validatorOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAPIバリデーターの初期化エラー: %w", err)
+	}
+	var h http.Handler = validator.Middleware(mux)
+	h = middleware.NewIdempotency(idempotencyService, handler.IdempotentRoutes).Middleware(mux, h)
+	h = rateLimiter.Middleware(mux, h)
+	if authenticators != nil {
+		h = middleware.NewAuth(authenticators, handler.RouteRoles).Middleware(mux, h)
+		h = rateLimiter.AuthFailureMiddleware(mux, h)
+	}
+	h = middleware.NewNegotiation(handler.RouteMediaTypes).Middleware(mux, h)
+	h = middleware.NewBodyLimit(int64(cfg.Limits.MaxBodyBytes), map[string]int64{
+		"POST /books/import": int64(cfg.Limits.MaxImportBodyBytes),
+	}).Middleware(mux, h)
+
+	return &http.Server{
+		Addr:              ":" + strconv.Itoa(cfg.Server.HTTPPort),
+		Handler:           middleware.Tracing(mux, requestLogger.Middleware(middleware.Locale(httpMetrics.Middleware(mux, h)))),
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+	}, nil
+}
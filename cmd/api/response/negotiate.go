@@ -0,0 +1,80 @@
+package response
+
+import (
+	"context"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// Negotiate はAcceptヘッダーに基づいて、offers（サーバーが返せるメディアタイプ、優先順）から返す形式を選びます。
+// Acceptヘッダーがない場合はoffersの先頭を、受け付けられる形式がない場合は空文字列を返します。
+// 品質値（q）が同じ場合は、より具体的な指定（text/* より text/csv）に一致したものを、さらに同じ場合はoffersの順序を優先します。
+func Negotiate(accept string, offers []string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	type acceptRange struct {
+		typ, subtype string
+		q            float64
+	}
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		typ, subtype, _ := strings.Cut(mediaType, "/")
+		ranges = append(ranges, acceptRange{typ, subtype, q})
+	}
+
+	best, bestQ, bestSpecificity := "", 0.0, -1
+	for _, offer := range offers {
+		typ, subtype, _ := strings.Cut(offer, "/")
+		// offerに一致する最も具体的な範囲の品質値がofferの品質値になる
+		q, specificity := 0.0, -1
+		for _, r := range ranges {
+			s := -1
+			switch {
+			case r.typ == typ && r.subtype == subtype:
+				s = 2
+			case r.typ == typ && r.subtype == "*":
+				s = 1
+			case r.typ == "*" && r.subtype == "*":
+				s = 0
+			}
+			if s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+		if q > bestQ || (q == bestQ && q > 0 && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+	return best
+}
+
+type mediaTypeKey struct{}
+
+// WithMediaType はコンテキストにAcceptヘッダーで選んだレスポンスの形式を格納します。
+func WithMediaType(ctx context.Context, mediaType string) context.Context {
+	return context.WithValue(ctx, mediaTypeKey{}, mediaType)
+}
+
+// MediaType はコンテキストに格納されたレスポンスの形式を返します。格納されていない場合はdefaultTypeを返します。
+func MediaType(ctx context.Context, defaultType string) string {
+	if mt, ok := ctx.Value(mediaTypeKey{}).(string); ok {
+		return mt
+	}
+	return defaultType
+}
@@ -0,0 +1,126 @@
+// Package response はHTTP APIのレスポンスの表現（JSONのエラーレスポンス、Acceptヘッダーによる形式の選択）を提供します。
+// ハンドラーとミドルウェアは、このパッケージを通して同じ形式のレスポンスを返します。
+package response
+
+import (
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+	"encoding/json"
+	"net/http"
+)
+
+// レスポンスのメディアタイプ
+const (
+	MediaTypeJSON   = "application/json"
+	MediaTypeCSV    = "text/csv"
+	MediaTypeNDJSON = "application/x-ndjson"
+	MediaTypeXML    = "application/xml"
+)
+
+// Code はエラーレスポンスの種類を表す、クライアントが判別に使用できる安定したコードです。
+type Code string
+
+const (
+	CodeBadRequest           Code = "bad_request"
+	CodeUnauthorized         Code = "unauthorized"
+	CodeForbidden            Code = "forbidden"
+	CodeNotFound             Code = "not_found"
+	CodeMethodNotAllowed     Code = "method_not_allowed"
+	CodeNotAcceptable        Code = "not_acceptable"
+	CodeConflict             Code = "conflict"
+	CodePayloadTooLarge      Code = "payload_too_large"
+	CodeUnsupportedMediaType Code = "unsupported_media_type"
+	CodeIdempotencyKeyReused Code = "idempotency_key_reused"
+	CodeTooManyRequests      Code = "too_many_requests"
+	CodeInternal             Code = "internal_error"
+)
+
+// CodeForStatus はステータスコードに対応する既定のCodeを返します。
+func CodeForStatus(status int) Code {
+	switch status {
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusNotAcceptable:
+		return CodeNotAcceptable
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusUnsupportedMediaType:
+		return CodeUnsupportedMediaType
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// ErrorResponse はエラーレスポンスのボディです。
+//
+//	{"error": {"code": "not_found", "reason": "book.not_found", "message": "書籍が見つかりません: 978-4-00-111111-1", "request_id": "..."}}
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody はエラーの内容です。messageはリクエストの言語（Accept-Language）で記述します。
+// reasonは原因がドメインエラーの場合のドメインエラーのコードで、言語によらずエラーを判別できます。
+type ErrorBody struct {
+	Code      Code   `json:"code"`
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// JSON はvをJSONとしてレスポンスに書き込みます。
+func JSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		logging.FromContext(r.Context()).Error("レスポンスのエンコードに失敗しました", "error", err)
+		Error(w, r, http.StatusInternalServerError, CodeInternal, i18n.MsgResponseEncodeFailed)
+		return
+	}
+	w.Header().Set("Content-Type", MediaTypeJSON)
+	w.WriteHeader(status)
+	_, _ = w.Write(append(body, '\n'))
+}
+
+// Error はメッセージカタログのkeyのメッセージを、リクエストの言語でJSONのエラーレスポンスとして書き込みます。
+func Error(w http.ResponseWriter, r *http.Request, status int, code Code, key i18n.Key, args ...interface{}) {
+	locale := i18n.FromContext(r.Context())
+	writeError(w, status, locale, ErrorBody{
+		Code:      code,
+		Message:   i18n.Message(locale, key, args...),
+		RequestID: logging.RequestID(r.Context()),
+	})
+}
+
+// ErrorWithCause はkeyのメッセージに原因のエラーのメッセージを続けて、JSONのエラーレスポンスとして書き込みます。
+// 原因がドメインエラー（i18n.LocalizedError）の場合は、そのメッセージもリクエストの言語で記述し、コードをreasonに設定します。
+func ErrorWithCause(w http.ResponseWriter, r *http.Request, status int, code Code, key i18n.Key, cause error) {
+	locale := i18n.FromContext(r.Context())
+	writeError(w, status, locale, ErrorBody{
+		Code:      code,
+		Reason:    string(i18n.ErrorCode(cause)),
+		Message:   i18n.Message(locale, key) + ": " + i18n.ErrorMessage(locale, cause),
+		RequestID: logging.RequestID(r.Context()),
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, locale i18n.Locale, body ErrorBody) {
+	data, _ := json.Marshal(ErrorResponse{Error: body})
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", MediaTypeJSON)
+	h.Set("Content-Language", string(locale))
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(data, '\n'))
+}
@@ -4,44 +4,41 @@
 package main
 
 import (
-	"database/sql"
-	"ddd-hands-on-go/cmd/api/handler"
-	"ddd-hands-on-go/internal/application/book"
-	"ddd-hands-on-go/internal/domain/service"
-	"ddd-hands-on-go/internal/infrastructure/event"
-	"ddd-hands-on-go/internal/infrastructure/postgres"
-	"ddd-hands-on-go/internal/infrastructure/subscriber"
+	"context"
+	"ddd-hands-on-go/internal/config"
+	"log/slog"
 
 	"github.com/google/wire"
 )
 
-// InitializeApp はアプリケーションの依存関係を解決し、ハンドラーを返します。
-func InitializeApp(db *sql.DB) (*handler.BookHandler, error) {
+// InitializeApp はPostgreSQLに保存するアプリケーションの依存関係を解決します。
+// 返されるクリーンアップ関数はデータベースの接続を閉じ、送信待ちのトレースを出力します。App.Runが戻った後に呼び出してください。
+func InitializeApp(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*App, func(), error) {
 	wire.Build(
-		// リポジトリ
-		postgres.NewPostgresBookRepository,
-		wire.Bind(new(repository.BookRepository), new(*postgres.PostgresBookRepository)),
-
-		// トランザクションマネージャー
-		postgres.NewPostgresTransactionManager,
-		wire.Bind(new(shared.TransactionManager), new(*postgres.PostgresTransactionManager)),
-
-		// イベント配信
-		event.NewEventEmitter,
-		wire.Bind(new(shared.DomainEventPublisher), new(*event.EventEmitter)),
-
-		// サブスクライバー
-		subscriber.NewLogSubscriber,
-
-		// ドメインサービス
-		service.NewISBNDuplicationCheckDomainService,
-
-		// アプリケーションサービス
-		book.NewRegisterBookApplicationService,
-		book.NewGetBookApplicationService,
+		postgresSet,
+		metricsSet,
+		tracingSet,
+		eventSet,
+		authSet,
+		domainSet,
+		applicationSet,
+		presentationSet,
+	)
+	return nil, nil, nil
+}
 
-		// ハンドラー
-		handler.NewBookHandler,
+// InitializeInMemoryApp はメモリ上に保存するアプリケーションの依存関係を解決します。
+// 返されるクリーンアップ関数は送信待ちのトレースを出力します。
+func InitializeInMemoryApp(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*App, func(), error) {
+	wire.Build(
+		inMemorySet,
+		metricsSet,
+		tracingSet,
+		eventSet,
+		authSet,
+		domainSet,
+		applicationSet,
+		presentationSet,
 	)
-	return nil, nil
+	return nil, nil, nil
 }
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/config"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/infrastructure/event"
+	"ddd-hands-on-go/internal/infrastructure/postgres"
+	"ddd-hands-on-go/internal/infrastructure/subscriber"
+	"ddd-hands-on-go/internal/logging"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+)
+
+// cli はサブコマンドの実行に必要な依存関係と出力先を保持します。
+type cli struct {
+	stdout io.Writer
+	stderr io.Writer
+	output string
+	// locale はエラーメッセージと取り込み結果のメッセージの言語です。
+	locale i18n.Locale
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// openDB はデータベースに接続します。接続はコマンド終了時に閉じられます。
+// 接続先はAPIサーバーと同じ設定（環境変数 CONFIG_FILE の設定ファイルと DB_* 環境変数）から読み込みます。
+func (c *cli) openDB(ctx context.Context) (*sql.DB, error) {
+	if c.db != nil {
+		return c.db, nil
+	}
+	// CLIはAPIを公開しないため、認証の設定（AUTH_API_KEYSなど）がなくても読み込めるようにする
+	cfg, err := config.NewLoader(os.LookupEnv).WithProfile(config.ProfileCLI).Load()
+	if err != nil {
+		return nil, err
+	}
+	// ログはコマンドの出力と混ざらないよう、標準エラー出力へ読みやすい形式で出力する
+	c.logger, err = logging.New(c.stderr, logging.FormatText, cfg.Log.Level)
+	if err != nil {
+		return nil, err
+	}
+	db, err := postgres.NewDB(ctx, postgres.DBConfig{
+		DSN:             cfg.Database.ConnString(),
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.Database.ConnMaxIdleTime,
+		ConnectRetry: postgres.RetryPolicy{
+			MaxAttempts:    cfg.Database.ConnectAttempts,
+			InitialBackoff: cfg.Database.ConnectBackoff,
+			MaxBackoff:     cfg.Database.ConnectMaxBackoff,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.db = db
+	return db, nil
+}
+
+func (c *cli) close() {
+	if c.db != nil {
+		c.db.Close()
+	}
+}
+
+// services はアプリケーションサービスの集合です。
+type services struct {
+	registerBook *book.RegisterBookApplicationService
+	getBook      *book.GetBookApplicationService
+	adjustStock  *book.AdjustStockApplicationService
+	listBooks    *book.ListBooksApplicationService
+	importBooks  *book.ImportBooksApplicationService
+	exportBooks  *book.ExportBooksApplicationService
+}
+
+// newServices はcmd/apiと同じ構成でアプリケーションサービスを組み立てます。
+func (c *cli) newServices(ctx context.Context) (*services, error) {
+	db, err := c.openDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// CLIはメトリクスを公開しないため記録しない
+	bookRepo := postgres.NewPostgresBookRepository(db, nil)
+	bookQueryService := postgres.NewPostgresBookQueryService(db)
+	txManager := postgres.NewPostgresTransactionManager(db, nil)
+	eventEmitter := event.NewEventEmitter()
+	eventEmitter.Subscribe("BookCreated", subscriber.NewLogSubscriber(c.logger).Subscribe)
+	isbnDupCheckService := service.NewISBNDuplicationCheckDomainService(bookRepo)
+	authorRepo := postgres.NewPostgresAuthorRepository(db, nil)
+	publisherRepo := postgres.NewPostgresPublisherRepository(db, nil)
+	categoryRepo := postgres.NewPostgresCategoryRepository(db, nil)
+	refCheckService := service.NewBookReferenceCheckDomainService(authorRepo, publisherRepo, categoryRepo)
+	dtoAssembler := book.NewBookDTOAssembler(authorRepo, publisherRepo, categoryRepo)
+
+	return &services{
+		registerBook: book.NewRegisterBookApplicationService(bookRepo, txManager, isbnDupCheckService, refCheckService, dtoAssembler, eventEmitter),
+		getBook:      book.NewGetBookApplicationService(bookRepo, dtoAssembler),
+		adjustStock:  book.NewAdjustStockApplicationService(bookRepo, txManager, dtoAssembler, eventEmitter),
+		listBooks:    book.NewListBooksApplicationService(bookQueryService),
+		importBooks:  book.NewImportBooksApplicationService(bookRepo, txManager, isbnDupCheckService, eventEmitter),
+		exportBooks:  book.NewExportBooksApplicationService(bookQueryService),
+	}, nil
+}
+
+// printBooks は書籍情報を指定された形式で出力します。
+func (c *cli) printBooks(books ...*book.BookDTO) error {
+	if c.output == "json" {
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		if len(books) == 1 {
+			return enc.Encode(books[0])
+		}
+		return enc.Encode(books)
+	}
+
+	tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ISBN\tTITLE\tPRICE\tQUANTITY\tSTATUS")
+	for _, b := range books {
+		fmt.Fprintf(tw, "%s\t%s\t%.0f\t%d\t%s\n", b.ISBN, b.Title, b.PriceAmount, b.QuantityAvailable, b.Status)
+	}
+	return tw.Flush()
+}
+
+// printImportReport は一括取り込みの結果を指定された形式で出力します。
+func (c *cli) printImportReport(report *book.ImportReport) error {
+	report.Localize(c.locale)
+	if c.output == "json" {
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "mode\t%s\n", report.Mode)
+	fmt.Fprintf(tw, "dry run\t%t\n", report.DryRun)
+	fmt.Fprintf(tw, "total rows\t%d\n", report.TotalRows)
+	fmt.Fprintf(tw, "imported rows\t%d\n", report.ImportedRows)
+	fmt.Fprintf(tw, "failed rows\t%d\n", report.FailedRows)
+	if len(report.Errors) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "LINE\tISBN\tERROR")
+		for _, e := range report.Errors {
+			fmt.Fprintf(tw, "%d\t%s\t%s\n", e.Line, e.ISBN, e.Message)
+		}
+	}
+	return tw.Flush()
+}
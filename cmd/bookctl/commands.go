@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/infrastructure/catalogfile"
+	"ddd-hands-on-go/internal/infrastructure/postgres"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+)
+
+// newFlagSet はサブコマンド用のFlagSetを生成します。
+func newFlagSet(c *cli, name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "使い方: bookctl %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags はフラグを解析し、誤りがあればusageErrorを返します。
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return &usageError{message: err.Error()}
+	}
+	return nil
+}
+
+func requireFlag(name, value string) error {
+	if value == "" {
+		return &usageError{message: fmt.Sprintf("-%s は必須です", name)}
+	}
+	return nil
+}
+
+func runRegister(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "register", "-isbn ISBN -title TITLE [-subtitle SUBTITLE] -price PRICE [-author-id ID]... [書誌情報のフラグ]")
+	isbn := fs.String("isbn", "", "ISBN")
+	title := fs.String("title", "", "タイトル")
+	subtitle := fs.String("subtitle", "", "副題")
+	price := fs.Float64("price", 0, "価格 (JPY)")
+	var authorIds []string
+	fs.Func("author-id", "登録済みの著者のID (複数の著者は筆頭著者から順に繰り返し指定)", func(v string) error {
+		authorIds = append(authorIds, v)
+		return nil
+	})
+	publisherId := fs.String("publisher-id", "", "登録済みの出版社のID")
+	publicationDate := fs.String("publication-date", "", "出版日 (YYYY-MM-DD)")
+	language := fs.String("language", "", "本文の言語 (ISO 639の言語コード)")
+	pageCount := fs.Int("pages", 0, "ページ数")
+	format := fs.String("format", "", "形態 (HARDCOVER, PAPERBACK, EBOOK)")
+	edition := fs.Int("edition", 0, "版")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := requireFlag("isbn", *isbn); err != nil {
+		return err
+	}
+
+	svc, err := c.newServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	dto, err := svc.registerBook.Execute(ctx, book.RegisterBookCommand{
+		ISBN:        *isbn,
+		Title:       *title,
+		Subtitle:    *subtitle,
+		PriceAmount: *price,
+		Metadata: book.MetadataInput{
+			AuthorIds:       authorIds,
+			PublisherId:     *publisherId,
+			PublicationDate: *publicationDate,
+			Language:        *language,
+			PageCount:       *pageCount,
+			Format:          *format,
+			Edition:         *edition,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return c.printBooks(dto)
+}
+
+func runShow(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "show", "ISBN")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return &usageError{message: "ISBNを1つ指定してください"}
+	}
+	isbn := fs.Arg(0)
+
+	svc, err := c.newServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	dto, err := svc.getBook.Execute(ctx, isbn)
+	if err != nil {
+		return err
+	}
+	if dto == nil {
+		return shared.NewDomainError(shared.KindNotFound, i18n.MsgBookNotFoundByISBN, isbn)
+	}
+	return c.printBooks(dto)
+}
+
+func runAdjustStock(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "adjust-stock", "-isbn ISBN -delta N")
+	isbn := fs.String("isbn", "", "ISBN")
+	delta := fs.Int("delta", 0, "在庫数の増減量 (正の値で入荷、負の値で出荷)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := requireFlag("isbn", *isbn); err != nil {
+		return err
+	}
+
+	svc, err := c.newServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	dto, err := svc.adjustStock.Execute(ctx, book.AdjustStockCommand{ISBN: *isbn, Delta: *delta})
+	if err != nil {
+		return err
+	}
+	return c.printBooks(dto)
+}
+
+func runLowStock(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "low-stock", "[-threshold N] [-limit N] [-offset N]")
+	threshold := fs.Int("threshold", 5, "この数以下の在庫数の書籍を表示する")
+	limit := fs.Int("limit", book.DefaultListLimit, "表示件数")
+	offset := fs.Int("offset", 0, "表示開始位置")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	svc, err := c.newServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	books, err := svc.listBooks.Execute(ctx, book.ListBooksQuery{
+		MaxQuantityAvailable: threshold,
+		Limit:                *limit,
+		Offset:               *offset,
+	})
+	if err != nil {
+		return err
+	}
+	return c.printBooks(books...)
+}
+
+func runMigrate(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "migrate", "[-status]")
+	statusOnly := fs.Bool("status", false, "適用せずに現在のバージョンを表示する")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	db, err := c.openDB(ctx)
+	if err != nil {
+		return err
+	}
+
+	applied := []postgres.Migration{}
+	if !*statusOnly {
+		if applied, err = postgres.Migrate(ctx, db); err != nil {
+			return err
+		}
+	}
+
+	current, err := postgres.MigrationVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	latest, err := postgres.LatestMigrationVersion()
+	if err != nil {
+		return err
+	}
+
+	if c.output == "json" {
+		names := make([]string, len(applied))
+		for i, m := range applied {
+			names[i] = m.Name
+		}
+		return json.NewEncoder(c.stdout).Encode(map[string]interface{}{
+			"applied":        names,
+			"currentVersion": current,
+			"latestVersion":  latest,
+		})
+	}
+
+	tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	for _, m := range applied {
+		fmt.Fprintf(tw, "applied\t%s\n", m.Name)
+	}
+	fmt.Fprintf(tw, "current version\t%d\n", current)
+	fmt.Fprintf(tw, "latest version\t%d\n", latest)
+	return tw.Flush()
+}
+
+func runImport(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "import", "-file PATH [-format csv|ndjson] [-mode all-or-nothing|best-effort] [-dry-run]")
+	path := fs.String("file", "", "取り込むファイルのパス")
+	format := fs.String("format", "", "ファイル形式 (csv|ndjson)。省略時は拡張子から判定する")
+	mode := fs.String("mode", string(book.ImportAllOrNothing), "失敗した行がある場合の振る舞い (all-or-nothing|best-effort)")
+	dryRun := fs.Bool("dry-run", false, "検証と重複チェックのみを行い、保存しない")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := requireFlag("file", *path); err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*path)), ".")
+	}
+	if *format != string(catalogfile.CSV) && *format != string(catalogfile.NDJSON) {
+		return &usageError{message: fmt.Sprintf("ファイル形式を判定できません。-format で csv または ndjson を指定してください: %s", *path)}
+	}
+
+	f, err := os.Open(*path)
+	if err != nil {
+		return fmt.Errorf("ファイルを開けませんでした: %w", err)
+	}
+	defer f.Close()
+
+	rows, err := catalogfile.NewImportReader(catalogfile.Format(*format), f)
+	if err != nil {
+		return shared.NewDomainError(shared.KindInvalid, i18n.MsgImportReadFailed).Wrap(err)
+	}
+
+	svc, err := c.newServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	report, err := svc.importBooks.Execute(ctx, book.ImportBooksCommand{
+		Rows:   rows,
+		Mode:   book.ImportMode(*mode),
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.printImportReport(report); err != nil {
+		return err
+	}
+
+	if report.FailedRows > 0 {
+		return shared.NewDomainError(shared.KindInvalid, i18n.MsgImportRowsFailed, report.FailedRows)
+	}
+	return nil
+}
+
+// exportExtensions はファイルの拡張子と書き出し形式の対応です。
+var exportExtensions = map[string]catalogfile.Format{
+	".csv":    catalogfile.CSV,
+	".ndjson": catalogfile.NDJSON,
+	".xml":    catalogfile.ONIX,
+}
+
+func runExport(ctx context.Context, c *cli, args []string) error {
+	fs := newFlagSet(c, "export", "[-format csv|ndjson|onix] [-file PATH]")
+	format := fs.String("format", "", "書き出し形式 (csv|ndjson|onix)。省略時は拡張子から判定し、判定できなければcsv")
+	path := fs.String("file", "", "書き出し先のファイルのパス。省略時は標準出力")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = string(catalogfile.CSV)
+		if f, ok := exportExtensions[strings.ToLower(filepath.Ext(*path))]; ok {
+			*format = string(f)
+		}
+	}
+	switch catalogfile.Format(*format) {
+	case catalogfile.CSV, catalogfile.NDJSON, catalogfile.ONIX:
+	default:
+		return &usageError{message: fmt.Sprintf("不正な書き出し形式です: %s", *format)}
+	}
+
+	svc, err := c.newServices(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *path == "" {
+		exporter, err := catalogfile.NewExportWriter(catalogfile.Format(*format), c.stdout)
+		if err != nil {
+			return err
+		}
+		_, err = svc.exportBooks.Execute(ctx, exporter)
+		return err
+	}
+
+	f, err := os.Create(*path)
+	if err != nil {
+		return fmt.Errorf("ファイルを作成できませんでした: %w", err)
+	}
+	exporter, err := catalogfile.NewExportWriter(catalogfile.Format(*format), f)
+	if err != nil {
+		f.Close()
+		os.Remove(*path)
+		return err
+	}
+
+	count, err := svc.exportBooks.Execute(ctx, exporter)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("ファイルの書き込みに失敗しました: %w", closeErr)
+	}
+	if err != nil {
+		// 不完全なファイルを残さない
+		os.Remove(*path)
+		return err
+	}
+
+	fmt.Fprintf(c.stderr, "%d件の書籍を %s に書き出しました\n", count, *path)
+	return nil
+}
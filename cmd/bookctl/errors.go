@@ -0,0 +1,45 @@
+package main
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"errors"
+	"flag"
+)
+
+// 終了コード
+const (
+	exitOK       = 0
+	exitError    = 1 // 分類されていないエラー（DB接続エラーなど）
+	exitUsage    = 2 // コマンドライン引数の誤り
+	exitInvalid  = 3 // 入力値がドメインのルールに違反している
+	exitNotFound = 4 // 対象が存在しない
+	exitConflict = 5 // 現在の状態と矛盾する操作（ISBNの重複、在庫不足など）
+)
+
+// usageError はコマンドライン引数の誤りを表すエラーです。
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+// exitCodeFromError はエラーを終了コードへ変換します。
+func exitCodeFromError(err error) int {
+	var ue *usageError
+	if errors.As(err, &ue) || errors.Is(err, flag.ErrHelp) {
+		return exitUsage
+	}
+
+	switch shared.KindOf(err) {
+	case shared.KindInvalid:
+		return exitInvalid
+	case shared.KindNotFound:
+		return exitNotFound
+	case shared.KindConflict:
+		return exitConflict
+	default:
+		return exitError
+	}
+}
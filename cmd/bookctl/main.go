@@ -0,0 +1,119 @@
+// bookctl はアプリケーションサービスを直接呼び出して書籍カタログを操作する管理用CLIです。
+//
+// 使い方:
+//
+//	bookctl [-o table|json] [-lang ja|en] <command> [flags]
+//
+// コマンド:
+//
+//	register      書籍を登録する
+//	show          書籍を表示する
+//	adjust-stock  在庫数を増減させる
+//	low-stock     在庫僅少の書籍を一覧表示する
+//	import        CSVまたはNDJSONファイルから書籍を一括登録する
+//	export        書籍カタログをCSV、NDJSONまたはONIXで書き出す
+//	migrate       データベースのマイグレーションを適用する
+package main
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/i18n"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// command はサブコマンドを表します。
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, c *cli, args []string) error
+}
+
+var commands = []command{
+	{"register", "書籍を登録する", runRegister},
+	{"show", "書籍を表示する", runShow},
+	{"adjust-stock", "在庫数を増減させる", runAdjustStock},
+	{"low-stock", "在庫僅少の書籍を一覧表示する", runLowStock},
+	{"import", "CSVまたはNDJSONファイルから書籍を一括登録する", runImport},
+	{"export", "書籍カタログをCSV、NDJSONまたはONIXで書き出す", runExport},
+	{"migrate", "データベースのマイグレーションを適用する", runMigrate},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run はCLIを実行し、終了コードを返します。
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("bookctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	output := fs.String("o", "table", "出力形式 (table|json)")
+	lang := fs.String("lang", "", "エラーメッセージの言語 (ja|en)。省略時は環境変数 LC_ALL、LC_MESSAGES、LANG から判定する")
+	fs.Usage = func() { printUsage(stderr, fs) }
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "不正な出力形式です: %s\n", *output)
+		return exitUsage
+	}
+	locale := i18n.LocaleFromEnv(os.LookupEnv)
+	if *lang != "" {
+		l, ok := i18n.ParseLocale(*lang)
+		if !ok {
+			fmt.Fprintf(stderr, "サポートされていない言語です: %s\n", *lang)
+			return exitUsage
+		}
+		locale = l
+	}
+	if fs.NArg() == 0 {
+		printUsage(stderr, fs)
+		return exitUsage
+	}
+
+	name, rest := fs.Arg(0), fs.Args()[1:]
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		c := &cli{stdout: stdout, stderr: stderr, output: *output, locale: locale}
+		defer c.close()
+
+		if err := cmd.run(ctx, c, rest); err != nil {
+			if !errors.Is(err, flag.ErrHelp) {
+				fmt.Fprintf(stderr, "%s: %s\n", i18n.Message(locale, i18n.MsgCLIError), i18n.ErrorMessage(locale, err))
+			}
+			return exitCodeFromError(err)
+		}
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "不明なコマンドです: %s\n", name)
+	printUsage(stderr, fs)
+	return exitUsage
+}
+
+func printUsage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintln(w, "使い方: bookctl [-o table|json] [-lang ja|en] <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "コマンド:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "グローバルフラグ:")
+	fs.PrintDefaults()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	// データベースへの接続を必要とするコマンドが、接続できずに終了するよう設定する
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DB_DSN", "postgres://bookctl@127.0.0.1:1/bookctl?sslmode=disable&connect_timeout=1")
+	t.Setenv("DB_CONNECT_ATTEMPTS", "1")
+	// 認証が有効で認証の方法の設定がなくても、CLIは設定を読み込める
+	t.Setenv("AUTH_ENABLED", "true")
+	t.Setenv("AUTH_API_KEYS", "")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "")
+
+	dir := t.TempDir()
+	invalidCSV := filepath.Join(dir, "invalid.csv")
+	if err := os.WriteFile(invalidCSV, []byte("unknown,columns\n1,2\n"), 0o600); err != nil {
+		t.Fatalf("ファイルの作成に失敗しました: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStderr string
+	}{
+		{"ヘルプ", []string{"-h"}, exitOK, "使い方: bookctl"},
+		{"コマンドなし", nil, exitUsage, "コマンド:"},
+		{"不明なコマンド", []string{"unknown"}, exitUsage, "不明なコマンドです: unknown"},
+		{"不明なグローバルフラグ", []string{"-x", "show"}, exitUsage, "flag provided but not defined: -x"},
+		{"不正な出力形式", []string{"-o", "xml", "show", "978-4-00-111111-1"}, exitUsage, "不正な出力形式です: xml"},
+		{"サポートされていない言語", []string{"-lang", "fr", "show", "978-4-00-111111-1"}, exitUsage, "サポートされていない言語です: fr"},
+		{"サブコマンドのヘルプ", []string{"show", "-h"}, exitUsage, "使い方: bookctl show ISBN"},
+		{"ISBNの指定なし", []string{"show"}, exitUsage, "ISBNを1つ指定してください"},
+		{"必須フラグの指定なし", []string{"adjust-stock", "-delta", "1"}, exitUsage, "-isbn は必須です"},
+		{"数値でないフラグの値", []string{"adjust-stock", "-isbn", "978-4-00-111111-1", "-delta", "x"}, exitUsage, `invalid value "x" for flag -delta`},
+		{"判定できないファイル形式", []string{"import", "-file", "books.txt"}, exitUsage, "ファイル形式を判定できません"},
+		{"不正な書き出し形式", []string{"export", "-format", "pdf"}, exitUsage, "不正な書き出し形式です: pdf"},
+		{"読み込めない取り込みファイル", []string{"import", "-file", invalidCSV}, exitInvalid, "エラー"},
+		{"英語のエラーメッセージ", []string{"-lang", "en", "import", "-file", invalidCSV}, exitInvalid, "Error"},
+		{"存在しない取り込みファイル", []string{"import", "-file", filepath.Join(dir, "missing.csv")}, exitError, "ファイルを開けませんでした"},
+		// 認証の設定がなくても設定の検証に失敗せず、データベースへの接続まで進む
+		{"データベースに接続できない", []string{"show", "978-4-00-111111-1"}, exitError, "データベースへのPingに失敗しました"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), tt.args, &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("期待する終了コード: %d, 実際: %d (stderr: %s)", tt.wantCode, code, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("標準エラー出力に %q が含まれていません: %s", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestExitCodeFromError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"分類されていないエラー", errors.New("connection refused"), exitError},
+		{"引数の誤り", &usageError{message: "-isbn は必須です"}, exitUsage},
+		{"ラップされた引数の誤り", fmt.Errorf("wrapped: %w", &usageError{message: "invalid"}), exitUsage},
+		{"ヘルプの表示", flag.ErrHelp, exitUsage},
+		{"入力値の誤り", shared.NewDomainError(shared.KindInvalid, i18n.MsgListLimitOutOfRange, 100), exitInvalid},
+		{"対象が存在しない", shared.NewDomainError(shared.KindNotFound, i18n.MsgBookNotFoundByISBN, "978-4-00-999999-9"), exitNotFound},
+		{"重複", shared.NewDomainError(shared.KindConflict, i18n.MsgBookAlreadyExists, "978-4-00-111111-1"), exitConflict},
+		{"ラップされたドメインエラー", fmt.Errorf("wrapped: %w", shared.NewDomainError(shared.KindConflict, i18n.MsgBookAlreadyExists, "978-4-00-111111-1")), exitConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCodeFromError(tt.err); got != tt.want {
+				t.Errorf("期待する終了コード: %d, 実際: %d", tt.want, got)
+			}
+		})
+	}
+}
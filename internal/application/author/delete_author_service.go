@@ -0,0 +1,57 @@
+package author
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/author"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// DeleteAuthorApplicationService は著者削除ユースケースを実装するアプリケーションサービスです。
+type DeleteAuthorApplicationService struct {
+	authorRepository   repository.AuthorRepository
+	transactionManager shared.TransactionManager
+}
+
+// NewDeleteAuthorApplicationService は新しいDeleteAuthorApplicationServiceを生成します。
+func NewDeleteAuthorApplicationService(
+	authorRepo repository.AuthorRepository,
+	txManager shared.TransactionManager,
+) *DeleteAuthorApplicationService {
+	return &DeleteAuthorApplicationService{
+		authorRepository:   authorRepo,
+		transactionManager: txManager,
+	}
+}
+
+// Execute は指定されたIDの著者を削除します。
+// 著者が存在しない場合はKindNotFound、書籍から参照されている場合はKindConflictのエラーを返します。
+func (s *DeleteAuthorApplicationService) Execute(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "DeleteAuthorApplicationService.Execute", tracing.WithAttrs("author_id", id))
+	defer tracing.End(span, &err)
+
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		authorId, err := author.NewAuthorId(id)
+		if err != nil {
+			return err
+		}
+
+		foundAuthor, err := s.authorRepository.Find(ctx, authorId)
+		if err != nil {
+			return err
+		}
+		if foundAuthor == nil {
+			return newAuthorNotFoundError(id)
+		}
+
+		return s.authorRepository.Delete(ctx, authorId)
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("著者を削除しました", "author_id", id)
+	return nil
+}
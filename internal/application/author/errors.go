@@ -0,0 +1,11 @@
+package author
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// newAuthorNotFoundError は著者が存在しないことを表すエラーを生成します。
+func newAuthorNotFoundError(id string) error {
+	return shared.NewDomainError(shared.KindNotFound, i18n.MsgAuthorNotFound, id)
+}
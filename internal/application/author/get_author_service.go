@@ -0,0 +1,50 @@
+package author
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/author"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// GetAuthorApplicationService は著者取得ユースケースを実装するアプリケーションサービスです。
+type GetAuthorApplicationService struct {
+	authorRepository repository.AuthorRepository
+}
+
+// NewGetAuthorApplicationService は新しいGetAuthorApplicationServiceを生成します。
+func NewGetAuthorApplicationService(authorRepository repository.AuthorRepository) *GetAuthorApplicationService {
+	return &GetAuthorApplicationService{authorRepository: authorRepository}
+}
+
+// AuthorDTO は著者のデータ転送オブジェクトです。
+type AuthorDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Execute は指定されたIDの著者を取得します。著者が存在しない場合はnilを返します。
+func (s *GetAuthorApplicationService) Execute(ctx context.Context, id string) (_ *AuthorDTO, err error) {
+	ctx, span := tracing.Start(ctx, "GetAuthorApplicationService.Execute", tracing.WithAttrs("author_id", id))
+	defer tracing.End(span, &err)
+
+	authorId, err := author.NewAuthorId(id)
+	if err != nil {
+		return nil, err
+	}
+
+	foundAuthor, err := s.authorRepository.Find(ctx, authorId)
+	if err != nil {
+		return nil, err
+	}
+	if foundAuthor == nil {
+		return nil, nil
+	}
+
+	return newAuthorDTO(foundAuthor), nil
+}
+
+// newAuthorDTO はAuthorエンティティからAuthorDTOを生成します。
+func newAuthorDTO(a *author.Author) *AuthorDTO {
+	return &AuthorDTO{ID: a.AuthorId().Value(), Name: a.Name().Value()}
+}
@@ -0,0 +1,55 @@
+package author
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+const (
+	// DefaultListLimit は一覧取得件数の既定値です。
+	DefaultListLimit = 20
+	// MaxListLimit は一覧取得件数の上限です。
+	MaxListLimit = 100
+)
+
+// ListAuthorsQuery は著者一覧の検索条件です。
+type ListAuthorsQuery struct {
+	Limit  int
+	Offset int
+}
+
+// AuthorQueryService は著者の参照系クエリを提供するインターフェースです。
+type AuthorQueryService interface {
+	// ListAuthors は著者を著者名順（同じ名前の場合はID順）に返します。
+	ListAuthors(ctx context.Context, query ListAuthorsQuery) ([]*AuthorDTO, error)
+}
+
+// ListAuthorsApplicationService は著者一覧取得ユースケースを実装するアプリケーションサービスです。
+type ListAuthorsApplicationService struct {
+	queryService AuthorQueryService
+}
+
+// NewListAuthorsApplicationService は新しいListAuthorsApplicationServiceを生成します。
+func NewListAuthorsApplicationService(queryService AuthorQueryService) *ListAuthorsApplicationService {
+	return &ListAuthorsApplicationService{queryService: queryService}
+}
+
+// Execute は検索条件を検証し、著者一覧を取得します。Limitが0の場合はDefaultListLimitを使用します。
+func (s *ListAuthorsApplicationService) Execute(ctx context.Context, query ListAuthorsQuery) (_ []*AuthorDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ListAuthorsApplicationService.Execute")
+	defer tracing.End(span, &err)
+
+	if query.Limit == 0 {
+		query.Limit = DefaultListLimit
+	}
+	if query.Limit < 0 || query.Limit > MaxListLimit {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListLimitOutOfRange, MaxListLimit)
+	}
+	if query.Offset < 0 {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListOffsetNegative)
+	}
+
+	return s.queryService.ListAuthors(ctx, query)
+}
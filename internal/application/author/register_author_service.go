@@ -0,0 +1,60 @@
+package author
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/author"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// RegisterAuthorCommand は著者登録に必要なパラメータを保持する構造体です。
+type RegisterAuthorCommand struct {
+	Name string
+}
+
+// RegisterAuthorApplicationService は著者登録ユースケースを実装するアプリケーションサービスです。
+type RegisterAuthorApplicationService struct {
+	authorRepository   repository.AuthorRepository
+	transactionManager shared.TransactionManager
+}
+
+// NewRegisterAuthorApplicationService は新しいRegisterAuthorApplicationServiceを生成します。
+func NewRegisterAuthorApplicationService(
+	authorRepo repository.AuthorRepository,
+	txManager shared.TransactionManager,
+) *RegisterAuthorApplicationService {
+	return &RegisterAuthorApplicationService{
+		authorRepository:   authorRepo,
+		transactionManager: txManager,
+	}
+}
+
+// Execute は著者登録処理を実行し、登録した著者を返します。著者IDは新しく採番します。
+func (s *RegisterAuthorApplicationService) Execute(ctx context.Context, cmd RegisterAuthorCommand) (_ *AuthorDTO, err error) {
+	ctx, span := tracing.Start(ctx, "RegisterAuthorApplicationService.Execute")
+	defer tracing.End(span, &err)
+
+	var dto *AuthorDTO
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		name, err := author.NewName(cmd.Name)
+		if err != nil {
+			return err
+		}
+
+		newAuthor := author.NewAuthor(author.GenerateAuthorId(), name)
+		if err := s.authorRepository.Save(ctx, newAuthor); err != nil {
+			return err
+		}
+
+		dto = newAuthorDTO(newAuthor)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("著者を登録しました", "author_id", dto.ID)
+	return dto, nil
+}
@@ -0,0 +1,74 @@
+package author
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/author"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// UpdateAuthorCommand は著者の更新に必要なパラメータを保持する構造体です。
+type UpdateAuthorCommand struct {
+	ID   string
+	Name string
+}
+
+// UpdateAuthorApplicationService は著者名の変更ユースケースを実装するアプリケーションサービスです。
+// 書籍は著者をIDで参照するため、変更後の著者名は全ての書籍に反映されます。
+type UpdateAuthorApplicationService struct {
+	authorRepository   repository.AuthorRepository
+	transactionManager shared.TransactionManager
+}
+
+// NewUpdateAuthorApplicationService は新しいUpdateAuthorApplicationServiceを生成します。
+func NewUpdateAuthorApplicationService(
+	authorRepo repository.AuthorRepository,
+	txManager shared.TransactionManager,
+) *UpdateAuthorApplicationService {
+	return &UpdateAuthorApplicationService{
+		authorRepository:   authorRepo,
+		transactionManager: txManager,
+	}
+}
+
+// Execute は著者名を変更し、変更後の著者を返します。著者が存在しない場合はKindNotFoundのエラーを返します。
+func (s *UpdateAuthorApplicationService) Execute(ctx context.Context, cmd UpdateAuthorCommand) (_ *AuthorDTO, err error) {
+	ctx, span := tracing.Start(ctx, "UpdateAuthorApplicationService.Execute", tracing.WithAttrs("author_id", cmd.ID))
+	defer tracing.End(span, &err)
+
+	var dto *AuthorDTO
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		authorId, err := author.NewAuthorId(cmd.ID)
+		if err != nil {
+			return err
+		}
+		name, err := author.NewName(cmd.Name)
+		if err != nil {
+			return err
+		}
+
+		foundAuthor, err := s.authorRepository.Find(ctx, authorId)
+		if err != nil {
+			return err
+		}
+		if foundAuthor == nil {
+			return newAuthorNotFoundError(cmd.ID)
+		}
+
+		foundAuthor.Rename(name)
+		if err := s.authorRepository.Save(ctx, foundAuthor); err != nil {
+			return err
+		}
+
+		dto = newAuthorDTO(foundAuthor)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("著者を更新しました", "author_id", cmd.ID)
+	return dto, nil
+}
@@ -0,0 +1,89 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// AdjustStockCommand は在庫調整に必要なパラメータを保持する構造体です。
+type AdjustStockCommand struct {
+	ISBN string
+	// Delta は在庫数の増減量です。正の値で入荷、負の値で出荷を表します。
+	Delta int
+}
+
+// AdjustStockApplicationService は在庫調整ユースケースを実装するアプリケーションサービスです。
+type AdjustStockApplicationService struct {
+	bookRepository     repository.BookRepository
+	transactionManager shared.TransactionManager
+	dtoAssembler       *BookDTOAssembler
+	eventPublisher     shared.DomainEventPublisher
+}
+
+// NewAdjustStockApplicationService は新しいAdjustStockApplicationServiceを生成します。
+func NewAdjustStockApplicationService(
+	bookRepo repository.BookRepository,
+	txManager shared.TransactionManager,
+	dtoAssembler *BookDTOAssembler,
+	eventPublisher shared.DomainEventPublisher,
+) *AdjustStockApplicationService {
+	return &AdjustStockApplicationService{
+		bookRepository:     bookRepo,
+		transactionManager: txManager,
+		dtoAssembler:       dtoAssembler,
+		eventPublisher:     eventPublisher,
+	}
+}
+
+// Execute は在庫調整処理を実行し、調整後の書籍情報を返します。
+// 在庫が不足する場合はKindConflictのエラーを返します。
+func (s *AdjustStockApplicationService) Execute(ctx context.Context, cmd AdjustStockCommand) (_ *BookDTO, err error) {
+	ctx, span := tracing.Start(ctx, "AdjustStockApplicationService.Execute", tracing.WithAttrs("isbn", cmd.ISBN, "delta", cmd.Delta))
+	defer tracing.End(span, &err)
+
+	var dto *BookDTO
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		bookId, err := book.NewBookId(cmd.ISBN)
+		if err != nil {
+			return err
+		}
+
+		// 同時の在庫調整で更新が失われないよう、コミットまで在庫をロックして読み取る
+		foundBook, err := s.bookRepository.FindForUpdate(ctx, bookId)
+		if err != nil {
+			return err
+		}
+		if foundBook == nil {
+			return newBookNotFoundError(cmd.ISBN)
+		}
+
+		if cmd.Delta >= 0 {
+			err = foundBook.IncreaseStock(cmd.Delta)
+		} else {
+			err = foundBook.DecreaseStock(-cmd.Delta)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := s.bookRepository.Save(ctx, foundBook); err != nil {
+			return err
+		}
+
+		publishAfterCommit(ctx, s.transactionManager, s.eventPublisher, foundBook.PullEvents())
+
+		dto, err = s.dtoAssembler.Assemble(ctx, foundBook)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("在庫を調整しました",
+		"isbn", cmd.ISBN, "delta", cmd.Delta, "quantity_available", dto.QuantityAvailable)
+	return dto, nil
+}
@@ -0,0 +1,98 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// AssignCategoriesCommand は書籍へのカテゴリの割り当てに必要なパラメータを保持する構造体です。
+type AssignCategoriesCommand struct {
+	ISBN string
+	// CategoryIds は割り当てる全てのカテゴリのIDです。現在の割り当てを置き換え、空の場合は全ての割り当てを解除します。
+	CategoryIds []string
+}
+
+// AssignCategoriesApplicationService は書籍へのカテゴリの割り当てユースケースを実装するアプリケーションサービスです。
+type AssignCategoriesApplicationService struct {
+	bookRepository        repository.BookRepository
+	transactionManager    shared.TransactionManager
+	referenceCheckService *service.BookReferenceCheckDomainService
+	dtoAssembler          *BookDTOAssembler
+	eventPublisher        shared.DomainEventPublisher
+}
+
+// NewAssignCategoriesApplicationService は新しいAssignCategoriesApplicationServiceを生成します。
+func NewAssignCategoriesApplicationService(
+	bookRepo repository.BookRepository,
+	txManager shared.TransactionManager,
+	refCheckService *service.BookReferenceCheckDomainService,
+	dtoAssembler *BookDTOAssembler,
+	eventPublisher shared.DomainEventPublisher,
+) *AssignCategoriesApplicationService {
+	return &AssignCategoriesApplicationService{
+		bookRepository:        bookRepo,
+		transactionManager:    txManager,
+		referenceCheckService: refCheckService,
+		dtoAssembler:          dtoAssembler,
+		eventPublisher:        eventPublisher,
+	}
+}
+
+// Execute は書籍に割り当てるカテゴリを置き換え、変更後の書籍情報を返します。
+// 書籍が存在しない場合はKindNotFound、存在しないカテゴリを指定した場合はKindInvalidのエラーを返します。
+func (s *AssignCategoriesApplicationService) Execute(ctx context.Context, cmd AssignCategoriesCommand) (_ *BookDTO, err error) {
+	ctx, span := tracing.Start(ctx, "AssignCategoriesApplicationService.Execute", tracing.WithAttrs("isbn", cmd.ISBN))
+	defer tracing.End(span, &err)
+
+	var dto *BookDTO
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		bookId, err := book.NewBookId(cmd.ISBN)
+		if err != nil {
+			return err
+		}
+		categoryIds := make([]*category.CategoryId, 0, len(cmd.CategoryIds))
+		for _, v := range cmd.CategoryIds {
+			id, err := category.NewCategoryId(v)
+			if err != nil {
+				return err
+			}
+			categoryIds = append(categoryIds, id)
+		}
+
+		// 保存時に在庫も書き戻すため、同時の在庫調整を上書きしないようコミットまで在庫をロックして読み取る
+		foundBook, err := s.bookRepository.FindForUpdate(ctx, bookId)
+		if err != nil {
+			return err
+		}
+		if foundBook == nil {
+			return newBookNotFoundError(cmd.ISBN)
+		}
+
+		if err := foundBook.AssignCategories(categoryIds); err != nil {
+			return err
+		}
+		if err := s.referenceCheckService.CheckCategories(ctx, categoryIds); err != nil {
+			return err
+		}
+		if err := s.bookRepository.Save(ctx, foundBook); err != nil {
+			return err
+		}
+
+		publishAfterCommit(ctx, s.transactionManager, s.eventPublisher, foundBook.PullEvents())
+
+		dto, err = s.dtoAssembler.Assemble(ctx, foundBook)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("書籍のカテゴリを変更しました", "isbn", cmd.ISBN, "categories", len(cmd.CategoryIds))
+	return dto, nil
+}
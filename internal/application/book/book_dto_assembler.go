@@ -0,0 +1,167 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/author"
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"sort"
+)
+
+// BookDTOAssembler はBookエンティティからBookDTOを組み立てます。
+// 書籍は著者・出版社・カテゴリをIDで参照するため、それぞれのリポジトリから名前を取得して補います。
+type BookDTOAssembler struct {
+	authorRepository    repository.AuthorRepository
+	publisherRepository repository.PublisherRepository
+	categoryRepository  repository.CategoryRepository
+}
+
+// NewBookDTOAssembler は新しいBookDTOAssemblerを生成します。
+func NewBookDTOAssembler(
+	authorRepository repository.AuthorRepository,
+	publisherRepository repository.PublisherRepository,
+	categoryRepository repository.CategoryRepository,
+) *BookDTOAssembler {
+	return &BookDTOAssembler{
+		authorRepository:    authorRepository,
+		publisherRepository: publisherRepository,
+		categoryRepository:  categoryRepository,
+	}
+}
+
+// Assemble は書籍1件のBookDTOを組み立てます。
+func (a *BookDTOAssembler) Assemble(ctx context.Context, b *book.Book) (*BookDTO, error) {
+	dtos, err := a.AssembleMany(ctx, []*book.Book{b})
+	if err != nil {
+		return nil, err
+	}
+	return dtos[0], nil
+}
+
+// AssembleMany は複数の書籍のBookDTOを、booksと同じ順に組み立てます。
+// 著者・出版社・カテゴリの問い合わせは、書籍の件数によらずそれぞれ1回です。
+// 参照先の著者・出版社・カテゴリが存在しない場合はデータ整合性のエラーを返します。
+func (a *BookDTOAssembler) AssembleMany(ctx context.Context, books []*book.Book) ([]*BookDTO, error) {
+	var authorIds []*author.AuthorId
+	var publisherIds []*publisher.PublisherId
+	var categoryIds []*category.CategoryId
+	for _, b := range books {
+		authorIds = append(authorIds, b.Metadata().AuthorIds()...)
+		if id := b.Metadata().PublisherId(); id != nil {
+			publisherIds = append(publisherIds, id)
+		}
+		categoryIds = append(categoryIds, b.CategoryIds()...)
+	}
+
+	authorNames := make(map[string]string)
+	if len(authorIds) > 0 {
+		authors, err := a.authorRepository.FindMany(ctx, authorIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, au := range authors {
+			authorNames[au.AuthorId().Value()] = au.Name().Value()
+		}
+	}
+	publisherNames := make(map[string]string)
+	if len(publisherIds) > 0 {
+		publishers, err := a.publisherRepository.FindMany(ctx, publisherIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range publishers {
+			publisherNames[p.PublisherId().Value()] = p.Name().Value()
+		}
+	}
+
+	categoryNames := make(map[string]string)
+	if len(categoryIds) > 0 {
+		categories, err := a.categoryRepository.FindMany(ctx, categoryIds)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range categories {
+			categoryNames[c.CategoryId().Value()] = c.Name().Value()
+		}
+	}
+
+	dtos := make([]*BookDTO, 0, len(books))
+	for _, b := range books {
+		dto := newBookDTO(b)
+		for i, au := range dto.Authors {
+			name, ok := authorNames[au.ID]
+			if !ok {
+				return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "authorId")
+			}
+			dto.Authors[i].Name = name
+		}
+		if dto.Publisher != nil {
+			name, ok := publisherNames[dto.Publisher.ID]
+			if !ok {
+				return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "publisherId")
+			}
+			dto.Publisher.Name = name
+		}
+		for i, c := range dto.Categories {
+			name, ok := categoryNames[c.ID]
+			if !ok {
+				return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "categoryId")
+			}
+			dto.Categories[i].Name = name
+		}
+		sortBookCategories(dto.Categories)
+		dtos = append(dtos, dto)
+	}
+	return dtos, nil
+}
+
+// sortBookCategories はカテゴリをカテゴリ名順（同じ名前の場合はID順）に並べます。
+func sortBookCategories(categories []BookCategoryDTO) {
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Name != categories[j].Name {
+			return categories[i].Name < categories[j].Name
+		}
+		return categories[i].ID < categories[j].ID
+	})
+}
+
+// newBookDTO はBookエンティティからBookDTOを生成します。著者・出版社・カテゴリはIDのみを設定します。
+func newBookDTO(b *book.Book) *BookDTO {
+	dto := &BookDTO{
+		ISBN:              b.BookId().Value(),
+		Title:             b.Title().Value(),
+		Subtitle:          b.Title().Subtitle(),
+		PriceAmount:       b.Price().Amount(),
+		QuantityAvailable: b.Stock().QuantityAvailable().Value(),
+		Status:            b.Stock().Status().Value().String(),
+		Format:            b.Metadata().Format().String(),
+	}
+
+	m := b.Metadata()
+	for _, id := range m.AuthorIds() {
+		dto.Authors = append(dto.Authors, BookAuthorDTO{ID: id.Value()})
+	}
+	if m.PublisherId() != nil {
+		dto.Publisher = &BookPublisherDTO{ID: m.PublisherId().Value()}
+	}
+	if m.PublicationDate() != nil {
+		dto.PublicationDate = m.PublicationDate().String()
+	}
+	if m.Language() != nil {
+		dto.Language = m.Language().Code()
+	}
+	if m.PageCount() != nil {
+		dto.PageCount = m.PageCount().Value()
+	}
+	if m.Edition() != nil {
+		dto.Edition = m.Edition().Value()
+	}
+	for _, id := range b.CategoryIds() {
+		dto.Categories = append(dto.Categories, BookCategoryDTO{ID: id.Value()})
+	}
+	return dto
+}
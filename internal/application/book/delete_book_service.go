@@ -0,0 +1,66 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// DeleteBookApplicationService は書籍削除ユースケースを実装するアプリケーションサービスです。
+type DeleteBookApplicationService struct {
+	bookRepository     repository.BookRepository
+	transactionManager shared.TransactionManager
+	eventPublisher     shared.DomainEventPublisher
+}
+
+// NewDeleteBookApplicationService は新しいDeleteBookApplicationServiceを生成します。
+func NewDeleteBookApplicationService(
+	bookRepo repository.BookRepository,
+	txManager shared.TransactionManager,
+	eventPublisher shared.DomainEventPublisher,
+) *DeleteBookApplicationService {
+	return &DeleteBookApplicationService{
+		bookRepository:     bookRepo,
+		transactionManager: txManager,
+		eventPublisher:     eventPublisher,
+	}
+}
+
+// Execute は指定されたISBNの書籍を削除します。
+// 書籍が存在しない場合はKindNotFoundのエラーを返します。
+func (s *DeleteBookApplicationService) Execute(ctx context.Context, isbn string) (err error) {
+	ctx, span := tracing.Start(ctx, "DeleteBookApplicationService.Execute", tracing.WithAttrs("isbn", isbn))
+	defer tracing.End(span, &err)
+
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		bookId, err := book.NewBookId(isbn)
+		if err != nil {
+			return err
+		}
+
+		foundBook, err := s.bookRepository.Find(ctx, bookId)
+		if err != nil {
+			return err
+		}
+		if foundBook == nil {
+			return newBookNotFoundError(isbn)
+		}
+
+		foundBook.Delete()
+		if err := s.bookRepository.Delete(ctx, bookId); err != nil {
+			return err
+		}
+
+		publishAfterCommit(ctx, s.transactionManager, s.eventPublisher, foundBook.PullEvents())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("書籍を削除しました", "isbn", isbn)
+	return nil
+}
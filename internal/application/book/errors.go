@@ -0,0 +1,11 @@
+package book
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// newBookNotFoundError は書籍が存在しないことを表すエラーを生成します。
+func newBookNotFoundError(isbn string) error {
+	return shared.NewDomainError(shared.KindNotFound, i18n.MsgBookNotFoundByISBN, isbn)
+}
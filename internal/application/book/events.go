@@ -0,0 +1,39 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/auth"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// publishAfterCommit はドメインイベントをトランザクションのコミット後に発行するよう登録します。
+// ロールバックされ得る変更のイベントを購読者に通知しないため、トランザクション内で直接発行してはいけません。
+// イベントにはリクエストIDを相関IDとして、現在のスパンをトレースコンテキストとして付与し、
+// サブスクライバーのログとスパンを発生元のリクエストと結び付けられるようにします。
+// 認証されたアクターがいる場合は、そのIDもイベントに記録します。
+func publishAfterCommit(
+	ctx context.Context,
+	txManager shared.TransactionManager,
+	publisher shared.DomainEventPublisher,
+	events []shared.DomainEvent,
+) {
+	requestID := logging.RequestID(ctx)
+	traceContext := tracing.Inject(ctx)
+	actor := auth.Actor(ctx)
+	for _, event := range events {
+		if e, ok := event.(shared.CorrelatedEvent); ok {
+			if requestID != "" {
+				e.SetCorrelationID(requestID)
+			}
+			e.SetTraceContext(traceContext)
+			if actor != "" {
+				e.SetActor(actor)
+			}
+		}
+		txManager.AfterCommit(ctx, func() {
+			publisher.Publish(event)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/tracing"
+	"fmt"
+)
+
+// BookExportWriter は書籍を1件ずつ外部のファイル形式で書き出すインターフェースです。
+type BookExportWriter interface {
+	// Write は書籍を1件書き出します。
+	Write(b *BookDTO) error
+	// Close は末尾の要素（フッターなど）を書き出し、バッファをフラッシュします。
+	Close() error
+}
+
+// ExportBooksApplicationService は書籍カタログの書き出しユースケースを実装するアプリケーションサービスです。
+type ExportBooksApplicationService struct {
+	queryService BookQueryService
+}
+
+// NewExportBooksApplicationService は新しいExportBooksApplicationServiceを生成します。
+func NewExportBooksApplicationService(queryService BookQueryService) *ExportBooksApplicationService {
+	return &ExportBooksApplicationService{queryService: queryService}
+}
+
+// Execute は全ての書籍をISBN順に読み込みながらwへ書き出し、書き出した件数を返します。
+// 書籍は1件ずつ書き出されるため、カタログ全体をメモリに保持しません。
+func (s *ExportBooksApplicationService) Execute(ctx context.Context, w BookExportWriter) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "ExportBooksApplicationService.Execute")
+	defer tracing.End(span, &err)
+
+	count := 0
+	err = s.queryService.EachBook(ctx, func(b *BookDTO) error {
+		if err := w.Write(b); err != nil {
+			return fmt.Errorf("書籍の書き出しに失敗しました: %w", err)
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	if err := w.Close(); err != nil {
+		return count, fmt.Errorf("書籍の書き出しに失敗しました: %w", err)
+	}
+	return count, nil
+}
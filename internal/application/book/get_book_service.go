@@ -4,29 +4,63 @@ import (
 	"context"
 	"ddd-hands-on-go/internal/domain/model/book"
 	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/tracing"
 )
 
 // GetBookApplicationService は書籍情報取得ユースケースを実装するアプリケーションサービスです。
 type GetBookApplicationService struct {
 	bookRepository repository.BookRepository
+	dtoAssembler   *BookDTOAssembler
 }
 
 // NewGetBookApplicationService は新しいGetBookApplicationServiceを生成します。
-func NewGetBookApplicationService(bookRepository repository.BookRepository) *GetBookApplicationService {
-	return &GetBookApplicationService{bookRepository: bookRepository}
+func NewGetBookApplicationService(bookRepository repository.BookRepository, dtoAssembler *BookDTOAssembler) *GetBookApplicationService {
+	return &GetBookApplicationService{bookRepository: bookRepository, dtoAssembler: dtoAssembler}
 }
 
 // BookDTO は書籍情報のデータ転送オブジェクトです。
 type BookDTO struct {
 	ISBN              string  `json:"isbn"`
 	Title             string  `json:"title"`
+	Subtitle          string  `json:"subtitle,omitempty"`
 	PriceAmount       float64 `json:"price_amount"`
 	QuantityAvailable int     `json:"quantity_available"`
 	Status            string  `json:"status"`
+	// 書誌情報。不明な項目は省略します。
+	Authors         []BookAuthorDTO   `json:"authors,omitempty"`
+	Publisher       *BookPublisherDTO `json:"publisher,omitempty"`
+	PublicationDate string            `json:"publication_date,omitempty"`
+	Language        string            `json:"language,omitempty"`
+	PageCount       int               `json:"page_count,omitempty"`
+	Format          string            `json:"format,omitempty"`
+	Edition         int               `json:"edition,omitempty"`
+	// Categories は割り当てられたカテゴリをカテゴリ名順（同じ名前の場合はID順）に保持します。割り当てがない場合は省略します。
+	Categories []BookCategoryDTO `json:"categories,omitempty"`
+}
+
+// BookAuthorDTO は書籍の著者（IDと著者名）です。
+type BookAuthorDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// BookPublisherDTO は書籍の出版社（IDと出版社名）です。
+type BookPublisherDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// BookCategoryDTO は書籍に割り当てられたカテゴリ（IDとカテゴリ名）です。
+type BookCategoryDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 // Execute は指定されたISBNの書籍情報を取得します。
-func (s *GetBookApplicationService) Execute(ctx context.Context, isbn string) (*BookDTO, error) {
+func (s *GetBookApplicationService) Execute(ctx context.Context, isbn string) (_ *BookDTO, err error) {
+	ctx, span := tracing.Start(ctx, "GetBookApplicationService.Execute", tracing.WithAttrs("isbn", isbn))
+	defer tracing.End(span, &err)
+
 	bookId, err := book.NewBookId(isbn)
 	if err != nil {
 		return nil, err
@@ -40,11 +74,37 @@ func (s *GetBookApplicationService) Execute(ctx context.Context, isbn string) (*
 		return nil, nil
 	}
 
-	return &BookDTO{
-		ISBN:              foundBook.BookId().Value(),
-		Title:             foundBook.Title().Value(),
-		PriceAmount:       foundBook.Price().Amount(),
-		QuantityAvailable: foundBook.Stock().QuantityAvailable().Value(),
-		Status:            foundBook.Stock().Status().Value().String(),
-	}, nil
+	return s.dtoAssembler.Assemble(ctx, foundBook)
+}
+
+// ExecuteBatch は指定された複数のISBNの書籍情報をまとめて取得し、ISBNをキーとしたマップで返します。
+// 見つからない書籍はマップに含まれません。
+func (s *GetBookApplicationService) ExecuteBatch(ctx context.Context, isbns []string) (_ map[string]*BookDTO, err error) {
+	ctx, span := tracing.Start(ctx, "GetBookApplicationService.ExecuteBatch", tracing.WithAttrs("count", len(isbns)))
+	defer tracing.End(span, &err)
+
+	bookIds := make([]*book.BookId, 0, len(isbns))
+	for _, isbn := range isbns {
+		bookId, err := book.NewBookId(isbn)
+		if err != nil {
+			return nil, err
+		}
+		bookIds = append(bookIds, bookId)
+	}
+
+	foundBooks, err := s.bookRepository.FindMany(ctx, bookIds)
+	if err != nil {
+		return nil, err
+	}
+
+	assembled, err := s.dtoAssembler.AssembleMany(ctx, foundBooks)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make(map[string]*BookDTO, len(assembled))
+	for _, dto := range assembled {
+		dtos[dto.ISBN] = dto
+	}
+	return dtos, nil
 }
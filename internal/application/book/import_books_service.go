@@ -0,0 +1,323 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/book/price"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+	"errors"
+	"io"
+)
+
+// ImportMode は一括取り込みで一部の行が失敗した場合の振る舞いです。
+type ImportMode string
+
+const (
+	// ImportAllOrNothing は1行でも失敗した場合に全ての行を取り込みません。
+	ImportAllOrNothing ImportMode = "all-or-nothing"
+	// ImportBestEffort は失敗した行を除いて取り込みます。
+	ImportBestEffort ImportMode = "best-effort"
+)
+
+// importBatchSize は重複チェックと保存をまとめて行う行数です。
+const importBatchSize = 500
+
+// errImportAborted はall-or-nothingモードでトランザクションをロールバックさせるためのエラーです。
+var errImportAborted = errors.New("取り込みに失敗した行があるため中止しました")
+
+// ImportRow は取り込み対象の1行です。
+type ImportRow struct {
+	// Line は入力中の行番号(1始まり)です。
+	Line        int
+	ISBN        string
+	Title       string
+	Subtitle    string
+	PriceAmount float64
+	// Err は行の解析に失敗した場合のエラーです（数値として読めない価格など）。
+	Err error
+}
+
+// ImportRowReader は取り込み行を先頭から順に返すインターフェースです。
+// 全ての行を読み終えた場合はio.EOFを返します。
+type ImportRowReader interface {
+	Read() (ImportRow, error)
+}
+
+// ImportBooksCommand は一括取り込みに必要なパラメータを保持する構造体です。
+type ImportBooksCommand struct {
+	Rows ImportRowReader
+	Mode ImportMode
+	// DryRun がtrueの場合は検証と重複チェックのみを行い、保存しません。
+	DryRun bool
+}
+
+// ImportRowError は取り込みに失敗した行の情報です。
+// Codeはドメインエラーのコード、Messageは既定の言語のメッセージです。Localizeで他の言語のメッセージにできます。
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	ISBN    string `json:"isbn,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+	// Err は行の取り込みに失敗した原因のエラーです。
+	Err error `json:"-"`
+}
+
+// ImportReport は一括取り込みの結果です。
+type ImportReport struct {
+	Mode         ImportMode       `json:"mode"`
+	DryRun       bool             `json:"dry_run"`
+	TotalRows    int              `json:"total_rows"`
+	ImportedRows int              `json:"imported_rows"`
+	FailedRows   int              `json:"failed_rows"`
+	Errors       []ImportRowError `json:"errors"`
+}
+
+// Localize は失敗した行のメッセージを指定された言語にします。
+func (r *ImportReport) Localize(l i18n.Locale) {
+	for i, e := range r.Errors {
+		if e.Err != nil {
+			r.Errors[i].Message = i18n.ErrorMessage(l, e.Err)
+		}
+	}
+}
+
+// ImportBooksApplicationService は書籍の一括取り込みユースケースを実装するアプリケーションサービスです。
+type ImportBooksApplicationService struct {
+	bookRepository          repository.BookRepository
+	transactionManager      shared.TransactionManager
+	duplicationCheckService *service.ISBNDuplicationCheckDomainService
+	eventPublisher          shared.DomainEventPublisher
+}
+
+// NewImportBooksApplicationService は新しいImportBooksApplicationServiceを生成します。
+func NewImportBooksApplicationService(
+	bookRepo repository.BookRepository,
+	txManager shared.TransactionManager,
+	dupCheck *service.ISBNDuplicationCheckDomainService,
+	eventPublisher shared.DomainEventPublisher,
+) *ImportBooksApplicationService {
+	return &ImportBooksApplicationService{
+		bookRepository:          bookRepo,
+		transactionManager:      txManager,
+		duplicationCheckService: dupCheck,
+		eventPublisher:          eventPublisher,
+	}
+}
+
+// Execute は行を順に読み込みながら一括取り込みを実行し、行ごとの結果を返します。
+// 行の検証エラーは返り値のエラーではなくレポートに記録されます。
+// 返り値のエラーは入力の読み込みやデータベースの障害など、取り込み全体が続行できない場合のみです。
+func (s *ImportBooksApplicationService) Execute(ctx context.Context, cmd ImportBooksCommand) (_ *ImportReport, err error) {
+	ctx, span := tracing.Start(ctx, "ImportBooksApplicationService.Execute", tracing.WithAttrs("import.mode", string(cmd.Mode), "import.dry_run", cmd.DryRun))
+	defer tracing.End(span, &err)
+
+	if cmd.Mode != ImportAllOrNothing && cmd.Mode != ImportBestEffort {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgImportInvalidMode, cmd.Mode)
+	}
+
+	run := &importRun{
+		service: s,
+		cmd:     cmd,
+		report:  &ImportReport{Mode: cmd.Mode, DryRun: cmd.DryRun, Errors: []ImportRowError{}},
+		seen:    make(map[string]int),
+	}
+
+	if cmd.Mode == ImportAllOrNothing && !cmd.DryRun {
+		// 全ての行を1つのトランザクションで取り込み、失敗した行があればロールバックする
+		err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+			if err := run.readAll(ctx); err != nil {
+				return err
+			}
+			if run.report.FailedRows > 0 {
+				return errImportAborted
+			}
+			return nil
+		})
+		if errors.Is(err, errImportAborted) {
+			run.report.ImportedRows = 0
+			err = nil
+		}
+	} else {
+		err = run.readAll(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("書籍の一括取り込みが完了しました",
+		"mode", cmd.Mode,
+		"dry_run", cmd.DryRun,
+		"total_rows", run.report.TotalRows,
+		"imported_rows", run.report.ImportedRows,
+		"failed_rows", run.report.FailedRows,
+	)
+	return run.report, nil
+}
+
+// importRun は1回の一括取り込みの状態です。
+type importRun struct {
+	service *ImportBooksApplicationService
+	cmd     ImportBooksCommand
+	report  *ImportReport
+	// seen はファイル内で既に現れたISBNと、その行番号です。
+	seen map[string]int
+}
+
+// validatedRow は値オブジェクトの生成に成功した行です。
+type validatedRow struct {
+	line  int
+	isbn  *book.BookId
+	title *book.Title
+	price *price.Price
+}
+
+// readAll は入力を最後まで読み込み、importBatchSize行ごとに処理します。
+func (r *importRun) readAll(ctx context.Context) error {
+	batch := make([]validatedRow, 0, importBatchSize)
+	for {
+		row, err := r.cmd.Rows.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// 入力の形式が壊れている場合は以降の行を読み進められないため、取り込み全体を失敗とする
+			// 読み込みのエラー（ボディの上限超過など）を呼び出し元が判別できるよう、チェーンに残す
+			return shared.NewDomainError(shared.KindInvalid, i18n.MsgImportReadFailed).Wrap(err)
+		}
+
+		r.report.TotalRows++
+		if v, ok := r.validate(row); ok {
+			batch = append(batch, v)
+		}
+
+		if len(batch) == importBatchSize {
+			if err := r.processBatch(ctx, batch); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	return r.processBatch(ctx, batch)
+}
+
+// validate は行から値オブジェクトを生成します。失敗した場合はレポートに記録してfalseを返します。
+func (r *importRun) validate(row ImportRow) (validatedRow, bool) {
+	if row.Err != nil {
+		r.fail(row.Line, row.ISBN, row.Err)
+		return validatedRow{}, false
+	}
+
+	isbn, err := book.NewBookId(row.ISBN)
+	if err != nil {
+		r.fail(row.Line, row.ISBN, err)
+		return validatedRow{}, false
+	}
+	title, err := book.NewTitleWithSubtitle(row.Title, row.Subtitle)
+	if err != nil {
+		r.fail(row.Line, row.ISBN, err)
+		return validatedRow{}, false
+	}
+	// 通貨は現在JPY固定
+	p, err := price.NewPrice(row.PriceAmount, price.JPY)
+	if err != nil {
+		r.fail(row.Line, row.ISBN, err)
+		return validatedRow{}, false
+	}
+
+	if first, ok := r.seen[row.ISBN]; ok {
+		r.fail(row.Line, row.ISBN, shared.NewDomainError(shared.KindInvalid, i18n.MsgImportDuplicateRow, first))
+		return validatedRow{}, false
+	}
+	r.seen[row.ISBN] = row.Line
+
+	return validatedRow{line: row.Line, isbn: isbn, title: title, price: p}, true
+}
+
+// processBatch は検証済みの行について重複をまとめてチェックし、保存します。
+func (r *importRun) processBatch(ctx context.Context, batch []validatedRow) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	isbns := make([]*book.BookId, len(batch))
+	for i, row := range batch {
+		isbns[i] = row.isbn
+	}
+	duplicates, err := r.service.duplicationCheckService.ExecuteBatch(ctx, isbns)
+	if err != nil {
+		return err
+	}
+
+	if r.cmd.DryRun {
+		for _, row := range batch {
+			if duplicates[row.isbn.Value()] {
+				r.failDuplicate(row)
+			}
+		}
+		return nil
+	}
+
+	// best-effortではバッチごとにコミットする。all-or-nothingでは外側のトランザクションに参加する
+	return r.service.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		for _, row := range batch {
+			if duplicates[row.isbn.Value()] {
+				r.failDuplicate(row)
+				continue
+			}
+			if err := r.save(ctx, row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// save は1行分の書籍を保存します。
+// best-effortでは行ごとにセーブポイントを作成し、保存に失敗した行だけを取り消します。
+func (r *importRun) save(ctx context.Context, row validatedRow) error {
+	var saveErr error
+	err := r.service.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		newBook, err := book.NewBook(row.isbn, row.title, row.price, nil)
+		if err != nil {
+			saveErr = err
+			return err
+		}
+		if err := r.service.bookRepository.Save(ctx, newBook); err != nil {
+			saveErr = err
+			return err
+		}
+		publishAfterCommit(ctx, r.service.transactionManager, r.service.eventPublisher, newBook.PullEvents())
+		return nil
+	}, shared.WithPropagation(shared.PropagationNested))
+
+	if err != nil {
+		if saveErr == nil || r.cmd.Mode == ImportAllOrNothing {
+			return err
+		}
+		r.fail(row.line, row.isbn.Value(), saveErr)
+		return nil
+	}
+
+	r.report.ImportedRows++
+	return nil
+}
+
+func (r *importRun) failDuplicate(row validatedRow) {
+	r.fail(row.line, row.isbn.Value(), shared.NewDomainError(shared.KindConflict, i18n.MsgBookAlreadyExists, row.isbn.Value()))
+}
+
+func (r *importRun) fail(line int, isbn string, err error) {
+	r.report.FailedRows++
+	r.report.Errors = append(r.report.Errors, ImportRowError{
+		Line:    line,
+		ISBN:    isbn,
+		Code:    string(i18n.ErrorCode(err)),
+		Message: err.Error(),
+		Err:     err,
+	})
+}
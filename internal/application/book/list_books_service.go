@@ -0,0 +1,100 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/book/stock/status"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+const (
+	// DefaultListLimit は一覧取得件数の既定値です。
+	DefaultListLimit = 20
+	// MaxListLimit は一覧取得件数の上限です。
+	MaxListLimit = 100
+)
+
+// ListBooksSort は書籍一覧の並び順です。
+type ListBooksSort string
+
+const (
+	// SortByISBN はISBN順です。
+	SortByISBN ListBooksSort = "isbn"
+	// SortByTitle はタイトルの照合キー（book.Title.CollationKey）順です。照合キーが同じ場合はISBN順です。
+	SortByTitle ListBooksSort = "title"
+)
+
+// ListBooksQuery は書籍一覧の検索条件です。
+type ListBooksQuery struct {
+	// Status は在庫ステータス（IN_STOCK など）による絞り込みです。空の場合は絞り込みません。
+	Status string
+	// MaxQuantityAvailable は在庫数の上限による絞り込みです（在庫僅少の書籍の抽出など）。nilの場合は絞り込みません。
+	MaxQuantityAvailable *int
+	// CategoryId はカテゴリによる絞り込みです。指定したカテゴリとその子孫のカテゴリのいずれかが割り当てられた書籍を返します。
+	// 空の場合は絞り込みません。
+	CategoryId string
+	// Sort は並び順です。空の場合はSortByISBNです。
+	Sort   ListBooksSort
+	Limit  int
+	Offset int
+}
+
+// BookQueryService は書籍の参照系クエリを提供するインターフェースです。
+// 一覧表示のように集約を復元する必要のない読み取りは、リポジトリではなくこのインターフェースを通して行います。
+type BookQueryService interface {
+	// ListBooks は条件に一致する書籍をquery.Sortの順に返します。
+	ListBooks(ctx context.Context, query ListBooksQuery) ([]*BookDTO, error)
+	// EachBook は全ての書籍をISBN順に1件ずつfへ渡します。fがエラーを返した場合はそこで中断します。
+	EachBook(ctx context.Context, f func(*BookDTO) error) error
+}
+
+// ListBooksApplicationService は書籍一覧取得ユースケースを実装するアプリケーションサービスです。
+type ListBooksApplicationService struct {
+	queryService BookQueryService
+}
+
+// NewListBooksApplicationService は新しいListBooksApplicationServiceを生成します。
+func NewListBooksApplicationService(queryService BookQueryService) *ListBooksApplicationService {
+	return &ListBooksApplicationService{queryService: queryService}
+}
+
+// Execute は検索条件を検証し、書籍一覧を取得します。
+// Limitが0の場合はDefaultListLimitを、Sortが空の場合はSortByISBNを使用します。
+func (s *ListBooksApplicationService) Execute(ctx context.Context, query ListBooksQuery) (_ []*BookDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ListBooksApplicationService.Execute")
+	defer tracing.End(span, &err)
+
+	if query.Limit == 0 {
+		query.Limit = DefaultListLimit
+	}
+	if query.Sort == "" {
+		query.Sort = SortByISBN
+	}
+	if query.Sort != SortByISBN && query.Sort != SortByTitle {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListInvalidSort, query.Sort)
+	}
+	if query.Limit < 0 || query.Limit > MaxListLimit {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListLimitOutOfRange, MaxListLimit)
+	}
+	if query.Offset < 0 {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListOffsetNegative)
+	}
+	if query.MaxQuantityAvailable != nil && *query.MaxQuantityAvailable < 0 {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListMaxQuantityNegative)
+	}
+	if query.Status != "" && status.ToStatusEnum(query.Status).String() != query.Status {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListInvalidStatus, query.Status)
+	}
+	if query.CategoryId != "" {
+		categoryId, err := category.NewCategoryId(query.CategoryId)
+		if err != nil {
+			return nil, err
+		}
+		// クエリサービスでは正規化した値で比較する
+		query.CategoryId = categoryId.Value()
+	}
+
+	return s.queryService.ListBooks(ctx, query)
+}
@@ -0,0 +1,64 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/tracing"
+	"time"
+)
+
+// StockHistoryEntryDTO は在庫数の変更履歴1件のデータ転送オブジェクトです。
+type StockHistoryEntryDTO struct {
+	// Delta は変更量です。入庫は正、出庫は負の値です。
+	Delta int `json:"delta"`
+	// QuantityAvailable とStatusは変更後の在庫数と在庫ステータスです。
+	QuantityAvailable int       `json:"quantity_available"`
+	Status            string    `json:"status"`
+	OccurredAt        time.Time `json:"occurred_at"`
+}
+
+// StockHistoryQueryService は在庫数の変更履歴の参照系クエリを提供するインターフェースです。
+// 履歴は在庫数変更イベント（book.StockQuantityChanged）をもとにリポジトリが書籍の保存時に記録します。
+type StockHistoryQueryService interface {
+	// ListStockHistory は指定された書籍ごとに、新しい順に最大limit件の変更履歴をISBNをキーとしたマップで返します。
+	// 履歴のない書籍はマップに含まれません。
+	ListStockHistory(ctx context.Context, isbns []string, limit int) (map[string][]*StockHistoryEntryDTO, error)
+}
+
+// ListStockHistoryApplicationService は在庫数の変更履歴取得ユースケースを実装するアプリケーションサービスです。
+type ListStockHistoryApplicationService struct {
+	queryService StockHistoryQueryService
+}
+
+// NewListStockHistoryApplicationService は新しいListStockHistoryApplicationServiceを生成します。
+func NewListStockHistoryApplicationService(queryService StockHistoryQueryService) *ListStockHistoryApplicationService {
+	return &ListStockHistoryApplicationService{queryService: queryService}
+}
+
+// ExecuteBatch は指定された複数のISBNの書籍の変更履歴を新しい順に最大limit件ずつまとめて取得し、
+// 正規化したISBNをキーとしたマップで返します。履歴のない書籍はマップに含まれません。
+// limitが0の場合はDefaultListLimitを使用します。
+func (s *ListStockHistoryApplicationService) ExecuteBatch(ctx context.Context, isbns []string, limit int) (_ map[string][]*StockHistoryEntryDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ListStockHistoryApplicationService.ExecuteBatch", tracing.WithAttrs("count", len(isbns), "limit", limit))
+	defer tracing.End(span, &err)
+
+	if limit == 0 {
+		limit = DefaultListLimit
+	}
+	if limit < 0 || limit > MaxListLimit {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListLimitOutOfRange, MaxListLimit)
+	}
+
+	normalized := make([]string, 0, len(isbns))
+	for _, isbn := range isbns {
+		bookId, err := book.NewBookId(isbn)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, bookId.Value())
+	}
+
+	return s.queryService.ListStockHistory(ctx, normalized, limit)
+}
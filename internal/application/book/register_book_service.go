@@ -7,14 +7,41 @@ import (
 	"ddd-hands-on-go/internal/domain/repository"
 	"ddd-hands-on-go/internal/domain/service"
 	"ddd-hands-on-go/internal/domain/shared"
-	"fmt"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
 )
 
 // RegisterBookCommand は書籍登録に必要なパラーメータを保持する構造体です。
 type RegisterBookCommand struct {
-	ISBN        string
-	Title       string
+	ISBN  string
+	Title string
+	// Subtitle は副題です。空の場合は副題なしとします。
+	Subtitle    string
 	PriceAmount float64
+	// Metadata は書誌情報です。
+	Metadata MetadataInput
+}
+
+// MetadataInput は書誌情報の入力値です。空の項目（数値は0）は不明とします。
+type MetadataInput struct {
+	// AuthorIds は登録済みの著者のIDです。筆頭著者を先頭にします。
+	AuthorIds []string
+	// PublisherId は登録済みの出版社のIDです。
+	PublisherId string
+	// PublicationDate は出版日（YYYY-MM-DD）です。
+	PublicationDate string
+	// Language はISO 639の言語コード（ja、enなど）です。
+	Language  string
+	PageCount int
+	// Format は形態（HARDCOVER、PAPERBACK、EBOOK）です。
+	Format  string
+	Edition int
+}
+
+// toMetadata は入力値を検証し、書誌情報の値オブジェクトを生成します。
+func (in MetadataInput) toMetadata() (*book.Metadata, error) {
+	return book.BuildMetadata(in.AuthorIds, in.PublisherId, in.PublicationDate, in.Language, in.PageCount, in.Format, in.Edition)
 }
 
 // RegisterBookApplicationService は書籍登録ユースケースを実装するアプリケーションサービスです。
@@ -22,6 +49,8 @@ type RegisterBookApplicationService struct {
 	bookRepository          repository.BookRepository
 	transactionManager      shared.TransactionManager
 	duplicationCheckService *service.ISBNDuplicationCheckDomainService
+	referenceCheckService   *service.BookReferenceCheckDomainService
+	dtoAssembler            *BookDTOAssembler
 	eventPublisher          shared.DomainEventPublisher
 }
 
@@ -30,25 +59,33 @@ func NewRegisterBookApplicationService(
 	bookRepo repository.BookRepository,
 	txManager shared.TransactionManager,
 	dupCheck *service.ISBNDuplicationCheckDomainService,
+	refCheck *service.BookReferenceCheckDomainService,
+	dtoAssembler *BookDTOAssembler,
 	eventPublisher shared.DomainEventPublisher,
 ) *RegisterBookApplicationService {
 	return &RegisterBookApplicationService{
 		bookRepository:          bookRepo,
 		transactionManager:      txManager,
 		duplicationCheckService: dupCheck,
+		referenceCheckService:   refCheck,
+		dtoAssembler:            dtoAssembler,
 		eventPublisher:          eventPublisher,
 	}
 }
 
-// Execute は書籍登録処理を実行します。
-func (s *RegisterBookApplicationService) Execute(ctx context.Context, cmd RegisterBookCommand) error {
-	return s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+// Execute は書籍登録処理を実行し、登録した書籍を返します。
+func (s *RegisterBookApplicationService) Execute(ctx context.Context, cmd RegisterBookCommand) (_ *BookDTO, err error) {
+	ctx, span := tracing.Start(ctx, "RegisterBookApplicationService.Execute", tracing.WithAttrs("isbn", cmd.ISBN))
+	defer tracing.End(span, &err)
+
+	var dto *BookDTO
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
 		// 1. Value Objectの生成と検証
 		isbn, err := book.NewBookId(cmd.ISBN)
 		if err != nil {
 			return err
 		}
-		title, err := book.NewTitle(cmd.Title)
+		title, err := book.NewTitleWithSubtitle(cmd.Title, cmd.Subtitle)
 		if err != nil {
 			return err
 		}
@@ -57,6 +94,10 @@ func (s *RegisterBookApplicationService) Execute(ctx context.Context, cmd Regist
 		if err != nil {
 			return err
 		}
+		metadata, err := cmd.Metadata.toMetadata()
+		if err != nil {
+			return err
+		}
 
 		// 2. ISBN重複チェック
 		isDuplicate, err := s.duplicationCheckService.Execute(ctx, isbn)
@@ -64,28 +105,35 @@ func (s *RegisterBookApplicationService) Execute(ctx context.Context, cmd Regist
 			return err
 		}
 		if isDuplicate {
-			return fmt.Errorf("このISBNの書籍は既に存在します: %s", cmd.ISBN)
+			return shared.NewDomainError(shared.KindConflict, i18n.MsgBookAlreadyExists, cmd.ISBN)
+		}
+
+		// 3. 参照する著者・出版社の存在チェック
+		if err := s.referenceCheckService.Execute(ctx, metadata); err != nil {
+			return err
 		}
 
-		// 3. Bookエンティティの生成
-		newBook, err := book.NewBook(isbn, title, price)
+		// 4. Bookエンティティの生成
+		newBook, err := book.NewBook(isbn, title, price, metadata)
 		if err != nil {
 			return err
 		}
 
-		// 4. リポジトリへの保存
+		// 5. リポジトリへの保存
 		if err := s.bookRepository.Save(ctx, newBook); err != nil {
 			return err
 		}
 
-		// 5. ドメインイベントの発行
-		// トランザクションコミット前に発行するか後に発行するかは要件次第だが、
-		// ここではシンプルに処理成功の一部として発行する
-		// (厳密にはTxコミット後に発行したいケースも多い)
-		for _, event := range newBook.PullEvents() {
-			s.eventPublisher.Publish(event)
-		}
+		// 6. ドメインイベントの発行 (コミット後)
+		publishAfterCommit(ctx, s.transactionManager, s.eventPublisher, newBook.PullEvents())
 
-		return nil
+		dto, err = s.dtoAssembler.Assemble(ctx, newBook)
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("書籍を登録しました", "isbn", cmd.ISBN)
+	return dto, nil
 }
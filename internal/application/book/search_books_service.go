@@ -0,0 +1,138 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/tracing"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxSearchQueryLength は検索語全体の最大文字数です。
+	MaxSearchQueryLength = 100
+	// MaxSearchTerms は空白で区切った検索語の最大数です。
+	MaxSearchTerms = 10
+)
+
+// SearchBooksQuery は書籍の全文検索の条件です。
+type SearchBooksQuery struct {
+	// Q は検索語です。空白で区切った全ての語を、タイトル（副題を含む）・著者名・出版社名のいずれかに含む書籍を返します。
+	Q      string
+	Limit  int
+	Offset int
+}
+
+// BookSearchResult は検索インデックスが返す書籍と、検索語との関連度です。
+type BookSearchResult struct {
+	Book *BookDTO
+	// Score は関連度で、大きいほど検索語に関連します。尺度はSearchIndexの実装ごとに異なります。
+	Score float64
+}
+
+// SearchIndex は書籍の全文検索を提供するインターフェースです。
+// 検索の方式（全文検索エンジン、データベースの機能など）は実装ごとに選べます。
+type SearchIndex interface {
+	// Search はtermsの全てを、タイトル（副題を含む）・著者名・出版社名のいずれかに部分文字列として含む書籍を、
+	// 関連度の高い順（同じ場合はISBN順）にlimit件、offset件目から返します。
+	// termsはNormalizeSearchTextで正規化済みで、空白を含まず、重複しません。
+	Search(ctx context.Context, terms []string, limit, offset int) ([]*BookSearchResult, error)
+}
+
+// BookSearchHitDTO は書籍の検索結果の1件です。
+type BookSearchHitDTO struct {
+	Book       *BookDTO               `json:"book"`
+	Score      float64                `json:"score"`
+	Highlights BookSearchHighlightDTO `json:"highlights"`
+}
+
+// BookSearchHighlightDTO は検索語に一致した部分を<mark>で囲んだ項目の値です。値はHTMLエスケープ済みで、一致しなかった項目は省略します。
+type BookSearchHighlightDTO struct {
+	Title    string `json:"title,omitempty"`
+	Subtitle string `json:"subtitle,omitempty"`
+	// Authors は検索語に一致した著者名のみを、書籍の著者の記載順に保持します。
+	Authors   []string `json:"authors,omitempty"`
+	Publisher string   `json:"publisher,omitempty"`
+}
+
+// SearchBooksApplicationService は書籍の全文検索ユースケースを実装するアプリケーションサービスです。
+type SearchBooksApplicationService struct {
+	searchIndex SearchIndex
+}
+
+// NewSearchBooksApplicationService は新しいSearchBooksApplicationServiceを生成します。
+func NewSearchBooksApplicationService(searchIndex SearchIndex) *SearchBooksApplicationService {
+	return &SearchBooksApplicationService{searchIndex: searchIndex}
+}
+
+// Execute は検索語を検証・正規化して書籍を検索し、一致した部分を強調した検索結果を関連度の高い順に返します。
+// Limitが0の場合はDefaultListLimitを使用します。
+func (s *SearchBooksApplicationService) Execute(ctx context.Context, query SearchBooksQuery) (_ []*BookSearchHitDTO, err error) {
+	ctx, span := tracing.Start(ctx, "SearchBooksApplicationService.Execute")
+	defer tracing.End(span, &err)
+
+	if query.Limit == 0 {
+		query.Limit = DefaultListLimit
+	}
+	if query.Limit < 0 || query.Limit > MaxListLimit {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListLimitOutOfRange, MaxListLimit)
+	}
+	if query.Offset < 0 {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListOffsetNegative)
+	}
+	terms, err := searchTerms(query.Q)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.searchIndex.Search(ctx, terms, query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*BookSearchHitDTO, len(results))
+	for i, r := range results {
+		hits[i] = &BookSearchHitDTO{Book: r.Book, Score: r.Score, Highlights: highlightBook(r.Book, terms)}
+	}
+	return hits, nil
+}
+
+// searchTerms は検索語を正規化し、空白で区切った重複のない語にします。
+func searchTerms(q string) ([]string, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgSearchQueryRequired)
+	}
+	if utf8.RuneCountInString(q) > MaxSearchQueryLength {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgSearchQueryTooLong, MaxSearchQueryLength)
+	}
+
+	var terms []string
+	for _, term := range strings.Fields(NormalizeSearchText(q)) {
+		if !slices.Contains(terms, term) {
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) > MaxSearchTerms {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgSearchTooManyTerms, MaxSearchTerms)
+	}
+	return terms, nil
+}
+
+// highlightBook は書籍の検索対象の項目のうち、検索語に一致した部分を強調します。
+func highlightBook(b *BookDTO, terms []string) BookSearchHighlightDTO {
+	var h BookSearchHighlightDTO
+	h.Title, _ = highlight(b.Title, terms)
+	h.Subtitle, _ = highlight(b.Subtitle, terms)
+	for _, a := range b.Authors {
+		if marked, ok := highlight(a.Name, terms); ok {
+			h.Authors = append(h.Authors, marked)
+		}
+	}
+	if b.Publisher != nil {
+		h.Publisher, _ = highlight(b.Publisher.Name, terms)
+	}
+	return h
+}
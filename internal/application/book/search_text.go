@@ -0,0 +1,82 @@
+package book
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeSearchText は検索語と検索対象の文字列を部分一致で比較できるよう正規化します。
+// タイトルの照合キー（book.Title.CollationKey）と同じく、全角・半角、ひらがな・カタカナ、大文字・小文字を区別せず、
+// 連続する空白を1つの空白にします。照合キーと異なり、先頭の英語の冠詞は取り除きません。
+// PostgreSQLでは同じ規則の関数 search_normalize（0007_add_book_search.sql）で正規化するため、規則を変更する場合はマイグレーションも追加してください。
+func NormalizeSearchText(s string) string {
+	return strings.Join(strings.Fields(normalizeSearchChars(s)), " ")
+}
+
+// normalizeSearchChars は空白を除くNormalizeSearchTextの正規化を行います。
+func normalizeSearchChars(s string) string {
+	return strings.Map(katakanaToHiragana, strings.ToLower(norm.NFKC.String(s)))
+}
+
+// katakanaToHiragana はカタカナ（ァ〜ヶ）を対応するひらがなに変換します。
+func katakanaToHiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - ('ァ' - 'ぁ')
+	}
+	return r
+}
+
+// highlight はtextのうち、いずれかの検索語（正規化済み）に一致する部分を<mark>で囲み、HTMLエスケープした文字列を返します。
+// 一致する部分がない場合はfalseを返します。
+func highlight(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+
+	// 1文字ずつ正規化し、正規化後の文字列のバイト位置から元の文字の位置を引けるようにする
+	var normalized strings.Builder
+	origin := make([]int, 0, len(text))
+	for i, r := range runes {
+		n := normalizeSearchChars(string(r))
+		normalized.WriteString(n)
+		for range len(n) {
+			origin = append(origin, i)
+		}
+	}
+	target := normalized.String()
+
+	marked := make([]bool, len(runes))
+	found := false
+	for _, term := range terms {
+		for start := 0; start < len(target); {
+			i := strings.Index(target[start:], term)
+			if i < 0 {
+				break
+			}
+			from := start + i
+			for b := from; b < from+len(term); b++ {
+				marked[origin[b]] = true
+			}
+			found = true
+			// 重なる一致も探すため、一致した位置の次の文字から探す
+			_, size := utf8.DecodeRuneInString(target[from:])
+			start = from + size
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for i, r := range runes {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			sb.WriteString("<mark>")
+		}
+		sb.WriteString(html.EscapeString(string(r)))
+		if marked[i] && (i == len(runes)-1 || !marked[i+1]) {
+			sb.WriteString("</mark>")
+		}
+	}
+	return sb.String(), true
+}
@@ -0,0 +1,93 @@
+package book
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/book/price"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// UpdateBookCommand は書籍情報の更新に必要なパラメータを保持する構造体です。
+type UpdateBookCommand struct {
+	ISBN  string
+	Title string
+	// Subtitle は副題です。空の場合は副題を削除します。
+	Subtitle    string
+	PriceAmount float64
+}
+
+// UpdateBookApplicationService は書籍情報（タイトル・価格）更新ユースケースを実装するアプリケーションサービスです。
+type UpdateBookApplicationService struct {
+	bookRepository     repository.BookRepository
+	transactionManager shared.TransactionManager
+	dtoAssembler       *BookDTOAssembler
+	eventPublisher     shared.DomainEventPublisher
+}
+
+// NewUpdateBookApplicationService は新しいUpdateBookApplicationServiceを生成します。
+func NewUpdateBookApplicationService(
+	bookRepo repository.BookRepository,
+	txManager shared.TransactionManager,
+	dtoAssembler *BookDTOAssembler,
+	eventPublisher shared.DomainEventPublisher,
+) *UpdateBookApplicationService {
+	return &UpdateBookApplicationService{
+		bookRepository:     bookRepo,
+		transactionManager: txManager,
+		dtoAssembler:       dtoAssembler,
+		eventPublisher:     eventPublisher,
+	}
+}
+
+// Execute は書籍情報の更新処理を実行し、更新後の書籍情報を返します。
+func (s *UpdateBookApplicationService) Execute(ctx context.Context, cmd UpdateBookCommand) (_ *BookDTO, err error) {
+	ctx, span := tracing.Start(ctx, "UpdateBookApplicationService.Execute", tracing.WithAttrs("isbn", cmd.ISBN))
+	defer tracing.End(span, &err)
+
+	var dto *BookDTO
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		bookId, err := book.NewBookId(cmd.ISBN)
+		if err != nil {
+			return err
+		}
+		title, err := book.NewTitleWithSubtitle(cmd.Title, cmd.Subtitle)
+		if err != nil {
+			return err
+		}
+		// 通貨は現在JPY固定
+		price, err := price.NewPrice(cmd.PriceAmount, price.JPY)
+		if err != nil {
+			return err
+		}
+
+		// 保存時に在庫も書き戻すため、同時の在庫調整を上書きしないようコミットまで在庫をロックして読み取る
+		foundBook, err := s.bookRepository.FindForUpdate(ctx, bookId)
+		if err != nil {
+			return err
+		}
+		if foundBook == nil {
+			return newBookNotFoundError(cmd.ISBN)
+		}
+
+		foundBook.ChangeTitle(title)
+		foundBook.ChangePrice(price)
+
+		if err := s.bookRepository.Save(ctx, foundBook); err != nil {
+			return err
+		}
+
+		publishAfterCommit(ctx, s.transactionManager, s.eventPublisher, foundBook.PullEvents())
+
+		dto, err = s.dtoAssembler.Assemble(ctx, foundBook)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("書籍を更新しました", "isbn", cmd.ISBN)
+	return dto, nil
+}
@@ -0,0 +1,57 @@
+package category
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// DeleteCategoryApplicationService はカテゴリ削除ユースケースを実装するアプリケーションサービスです。
+type DeleteCategoryApplicationService struct {
+	categoryRepository repository.CategoryRepository
+	transactionManager shared.TransactionManager
+}
+
+// NewDeleteCategoryApplicationService は新しいDeleteCategoryApplicationServiceを生成します。
+func NewDeleteCategoryApplicationService(
+	categoryRepo repository.CategoryRepository,
+	txManager shared.TransactionManager,
+) *DeleteCategoryApplicationService {
+	return &DeleteCategoryApplicationService{
+		categoryRepository: categoryRepo,
+		transactionManager: txManager,
+	}
+}
+
+// Execute は指定されたIDのカテゴリを削除します。
+// カテゴリが存在しない場合はKindNotFound、子カテゴリがある場合や書籍に割り当てられている場合はKindConflictのエラーを返します。
+func (s *DeleteCategoryApplicationService) Execute(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "DeleteCategoryApplicationService.Execute", tracing.WithAttrs("category_id", id))
+	defer tracing.End(span, &err)
+
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		categoryId, err := category.NewCategoryId(id)
+		if err != nil {
+			return err
+		}
+
+		foundCategory, err := s.categoryRepository.Find(ctx, categoryId)
+		if err != nil {
+			return err
+		}
+		if foundCategory == nil {
+			return newCategoryNotFoundError(id)
+		}
+
+		return s.categoryRepository.Delete(ctx, categoryId)
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("カテゴリを削除しました", "category_id", id)
+	return nil
+}
@@ -0,0 +1,20 @@
+package category
+
+import (
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// newCategoryNotFoundError はカテゴリが存在しないことを表すエラーを生成します。
+func newCategoryNotFoundError(id string) error {
+	return shared.NewDomainError(shared.KindNotFound, i18n.MsgCategoryNotFound, id)
+}
+
+// parseParentId は親カテゴリのIDを変換します。空の場合は最上位のカテゴリを表すnilを返します。
+func parseParentId(value string) (*category.CategoryId, error) {
+	if value == "" {
+		return nil, nil
+	}
+	return category.NewCategoryId(value)
+}
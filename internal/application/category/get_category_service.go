@@ -0,0 +1,56 @@
+package category
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// GetCategoryApplicationService はカテゴリ取得ユースケースを実装するアプリケーションサービスです。
+type GetCategoryApplicationService struct {
+	categoryRepository repository.CategoryRepository
+}
+
+// NewGetCategoryApplicationService は新しいGetCategoryApplicationServiceを生成します。
+func NewGetCategoryApplicationService(categoryRepository repository.CategoryRepository) *GetCategoryApplicationService {
+	return &GetCategoryApplicationService{categoryRepository: categoryRepository}
+}
+
+// CategoryDTO はカテゴリのデータ転送オブジェクトです。
+type CategoryDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// ParentID は親カテゴリのIDです。最上位のカテゴリの場合は省略します。
+	ParentID string `json:"parent_id,omitempty"`
+}
+
+// Execute は指定されたIDのカテゴリを取得します。カテゴリが存在しない場合はnilを返します。
+func (s *GetCategoryApplicationService) Execute(ctx context.Context, id string) (_ *CategoryDTO, err error) {
+	ctx, span := tracing.Start(ctx, "GetCategoryApplicationService.Execute", tracing.WithAttrs("category_id", id))
+	defer tracing.End(span, &err)
+
+	categoryId, err := category.NewCategoryId(id)
+	if err != nil {
+		return nil, err
+	}
+
+	foundCategory, err := s.categoryRepository.Find(ctx, categoryId)
+	if err != nil {
+		return nil, err
+	}
+	if foundCategory == nil {
+		return nil, nil
+	}
+
+	return newCategoryDTO(foundCategory), nil
+}
+
+// newCategoryDTO はCategoryエンティティからCategoryDTOを生成します。
+func newCategoryDTO(c *category.Category) *CategoryDTO {
+	dto := &CategoryDTO{ID: c.CategoryId().Value(), Name: c.Name().Value()}
+	if c.ParentId() != nil {
+		dto.ParentID = c.ParentId().Value()
+	}
+	return dto
+}
@@ -0,0 +1,56 @@
+package category
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// CategoryQueryService はカテゴリの参照系クエリを提供するインターフェースです。
+type CategoryQueryService interface {
+	// ListCategories は全てのカテゴリをカテゴリ名順（同じ名前の場合はID順）に返します。
+	ListCategories(ctx context.Context) ([]*CategoryDTO, error)
+}
+
+// CategoryTreeNodeDTO はカテゴリの木構造の1つのノードです。Childrenは子カテゴリをカテゴリ名順に保持します。
+type CategoryTreeNodeDTO struct {
+	CategoryDTO
+	Children []*CategoryTreeNodeDTO `json:"children"`
+}
+
+// ListCategoriesApplicationService はカテゴリの木構造の取得ユースケースを実装するアプリケーションサービスです。
+type ListCategoriesApplicationService struct {
+	queryService CategoryQueryService
+}
+
+// NewListCategoriesApplicationService は新しいListCategoriesApplicationServiceを生成します。
+func NewListCategoriesApplicationService(queryService CategoryQueryService) *ListCategoriesApplicationService {
+	return &ListCategoriesApplicationService{queryService: queryService}
+}
+
+// Execute は全てのカテゴリを木構造として取得し、最上位のカテゴリをカテゴリ名順に返します。
+// カテゴリの数は書籍に比べて少ないため、ページ分割せずに全てを返します。
+func (s *ListCategoriesApplicationService) Execute(ctx context.Context) (_ []*CategoryTreeNodeDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ListCategoriesApplicationService.Execute")
+	defer tracing.End(span, &err)
+
+	dtos, err := s.queryService.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*CategoryTreeNodeDTO, len(dtos))
+	for _, dto := range dtos {
+		nodes[dto.ID] = &CategoryTreeNodeDTO{CategoryDTO: *dto, Children: []*CategoryTreeNodeDTO{}}
+	}
+	// カテゴリ名順に子を追加するため、クエリの結果の順にたどる
+	roots := make([]*CategoryTreeNodeDTO, 0)
+	for _, dto := range dtos {
+		node := nodes[dto.ID]
+		if parent, ok := nodes[dto.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots, nil
+}
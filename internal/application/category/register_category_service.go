@@ -0,0 +1,77 @@
+package category
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// RegisterCategoryCommand はカテゴリ登録に必要なパラメータを保持する構造体です。
+type RegisterCategoryCommand struct {
+	Name string
+	// ParentID は親カテゴリのIDです。空の場合は最上位のカテゴリとして登録します。
+	ParentID string
+}
+
+// RegisterCategoryApplicationService はカテゴリ登録ユースケースを実装するアプリケーションサービスです。
+type RegisterCategoryApplicationService struct {
+	categoryRepository repository.CategoryRepository
+	transactionManager shared.TransactionManager
+	hierarchyService   *service.CategoryHierarchyDomainService
+}
+
+// NewRegisterCategoryApplicationService は新しいRegisterCategoryApplicationServiceを生成します。
+func NewRegisterCategoryApplicationService(
+	categoryRepo repository.CategoryRepository,
+	txManager shared.TransactionManager,
+	hierarchyService *service.CategoryHierarchyDomainService,
+) *RegisterCategoryApplicationService {
+	return &RegisterCategoryApplicationService{
+		categoryRepository: categoryRepo,
+		transactionManager: txManager,
+		hierarchyService:   hierarchyService,
+	}
+}
+
+// Execute はカテゴリ登録処理を実行し、登録したカテゴリを返します。カテゴリIDは新しく採番します。
+// 親カテゴリが存在しない場合はKindInvalidのエラーを返します。
+func (s *RegisterCategoryApplicationService) Execute(ctx context.Context, cmd RegisterCategoryCommand) (_ *CategoryDTO, err error) {
+	ctx, span := tracing.Start(ctx, "RegisterCategoryApplicationService.Execute")
+	defer tracing.End(span, &err)
+
+	var dto *CategoryDTO
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		name, err := category.NewName(cmd.Name)
+		if err != nil {
+			return err
+		}
+		parentId, err := parseParentId(cmd.ParentID)
+		if err != nil {
+			return err
+		}
+
+		newCategory, err := category.NewCategory(category.GenerateCategoryId(), name, parentId)
+		if err != nil {
+			return err
+		}
+		if err := s.hierarchyService.Execute(ctx, newCategory); err != nil {
+			return err
+		}
+		if err := s.categoryRepository.Save(ctx, newCategory); err != nil {
+			return err
+		}
+
+		dto = newCategoryDTO(newCategory)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("カテゴリを登録しました", "category_id", dto.ID, "parent_id", dto.ParentID)
+	return dto, nil
+}
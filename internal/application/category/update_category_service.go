@@ -0,0 +1,91 @@
+package category
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// UpdateCategoryCommand はカテゴリの更新に必要なパラメータを保持する構造体です。
+type UpdateCategoryCommand struct {
+	ID   string
+	Name string
+	// ParentID は変更後の親カテゴリのIDです。空の場合は最上位のカテゴリにします。
+	ParentID string
+}
+
+// UpdateCategoryApplicationService はカテゴリ名と親カテゴリの変更ユースケースを実装するアプリケーションサービスです。
+// 親カテゴリを変更すると、子孫のカテゴリもともに移動します。
+type UpdateCategoryApplicationService struct {
+	categoryRepository repository.CategoryRepository
+	transactionManager shared.TransactionManager
+	hierarchyService   *service.CategoryHierarchyDomainService
+}
+
+// NewUpdateCategoryApplicationService は新しいUpdateCategoryApplicationServiceを生成します。
+func NewUpdateCategoryApplicationService(
+	categoryRepo repository.CategoryRepository,
+	txManager shared.TransactionManager,
+	hierarchyService *service.CategoryHierarchyDomainService,
+) *UpdateCategoryApplicationService {
+	return &UpdateCategoryApplicationService{
+		categoryRepository: categoryRepo,
+		transactionManager: txManager,
+		hierarchyService:   hierarchyService,
+	}
+}
+
+// Execute はカテゴリ名と親カテゴリを変更し、変更後のカテゴリを返します。
+// カテゴリが存在しない場合はKindNotFound、親カテゴリが存在しない場合や親子関係が循環する場合はKindInvalidのエラーを返します。
+func (s *UpdateCategoryApplicationService) Execute(ctx context.Context, cmd UpdateCategoryCommand) (_ *CategoryDTO, err error) {
+	ctx, span := tracing.Start(ctx, "UpdateCategoryApplicationService.Execute", tracing.WithAttrs("category_id", cmd.ID))
+	defer tracing.End(span, &err)
+
+	var dto *CategoryDTO
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		categoryId, err := category.NewCategoryId(cmd.ID)
+		if err != nil {
+			return err
+		}
+		name, err := category.NewName(cmd.Name)
+		if err != nil {
+			return err
+		}
+		parentId, err := parseParentId(cmd.ParentID)
+		if err != nil {
+			return err
+		}
+
+		foundCategory, err := s.categoryRepository.Find(ctx, categoryId)
+		if err != nil {
+			return err
+		}
+		if foundCategory == nil {
+			return newCategoryNotFoundError(cmd.ID)
+		}
+
+		foundCategory.Rename(name)
+		if err := foundCategory.MoveTo(parentId); err != nil {
+			return err
+		}
+		if err := s.hierarchyService.Execute(ctx, foundCategory); err != nil {
+			return err
+		}
+		if err := s.categoryRepository.Save(ctx, foundCategory); err != nil {
+			return err
+		}
+
+		dto = newCategoryDTO(foundCategory)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("カテゴリを更新しました", "category_id", cmd.ID, "parent_id", dto.ParentID)
+	return dto, nil
+}
@@ -0,0 +1,176 @@
+// Package idempotency は冪等キー（Idempotency-Key）による更新系リクエストの重複実行の防止を実装します。
+//
+// 冪等キーの予約、処理の実行、レスポンスの保存を1つのトランザクションで行うため、
+// 処理が成功してレスポンスが保存されるか、全てがロールバックされるかのどちらかになります。
+// 同じキーで再送されたリクエストには、処理を再実行せずに保存したレスポンスを返します。
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrKeyReused は冪等キーが、内容の異なるリクエストで再利用されたことを表します。
+var ErrKeyReused = errors.New("冪等キーが異なるリクエストで使用されています")
+
+// Response は保存するレスポンスです。
+type Response struct {
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+}
+
+// Successful はレスポンスが成功（2xx）かどうかを返します。成功したレスポンスのみ保存します。
+func (r *Response) Successful() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Record は保存された冪等キーです。
+type Record struct {
+	// Actor はリクエストを送ったアクターのIDです。冪等キーはアクターごとに区別します（認証が無効な場合は空文字列）。
+	Actor string
+	Key   string
+	// Fingerprint はリクエストの内容（メソッド・パス・ボディ）のハッシュ値です。
+	Fingerprint string
+	// Response は保存したレスポンスです。予約したトランザクション内ではnilです。
+	Response  *Response
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Store は冪等キーの保存先です。
+// 全ての操作はコンテキストのトランザクション内で行い、ロールバックされた場合は予約も取り消されます。
+type Store interface {
+	// Reserve はキーを予約します。有効期限内の同じアクター・キーが既にある場合は予約せず、そのRecordを返します。
+	// 同じキーを予約中の別のトランザクションがある場合は、その完了を待ちます。
+	Reserve(ctx context.Context, r *Record) (*Record, error)
+	// Complete は予約したキーにレスポンスを保存します。
+	Complete(ctx context.Context, r *Record) error
+	// DeleteExpired は有効期限がnow以前のキーを削除し、削除した件数を返します。
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+// Request は冪等キーを指定したリクエストです。
+type Request struct {
+	Actor       string
+	Key         string
+	Fingerprint string
+}
+
+// Fingerprint はリクエストのメソッド・パス・ボディからFingerprintを計算します。
+func Fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", method, path)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Service は冪等キーを指定したリクエストを1回だけ実行します。
+type Service struct {
+	store     Store
+	txManager shared.TransactionManager
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// NewService は新しいServiceを生成します。ttlは冪等キーを保持する期間です。
+func NewService(store Store, txManager shared.TransactionManager, ttl time.Duration) *Service {
+	return &Service{store: store, txManager: txManager, ttl: ttl, now: time.Now}
+}
+
+// errNotStored は成功しなかったレスポンスを保存せず、トランザクションをロールバックするための内部エラーです。
+var errNotStored = errors.New("レスポンスを保存しません")
+
+// Execute は冪等キーを予約してfを実行し、成功したレスポンスをキーとともに保存します。
+// fはキーの予約と同じトランザクション内で実行されるため、fの中で開始したトランザクションはこれに参加します。
+//
+// 有効期限内の同じキーが既にある場合は、fを実行せずに保存されたレスポンスを返し、replayedをtrueにします。
+// その際、リクエストの内容が異なる場合はErrKeyReusedを返します。
+// fのレスポンスが成功（2xx）でない場合は、トランザクションをロールバックしてキーを保存せずにレスポンスを返すため、
+// 同じキーで再試行できます。
+func (s *Service) Execute(ctx context.Context, req Request, f func(ctx context.Context) (*Response, error)) (resp *Response, replayed bool, err error) {
+	ctx, span := tracing.Start(ctx, "IdempotencyService.Execute", tracing.WithAttrs("idempotency.key", req.Key))
+	defer tracing.End(span, &err)
+
+	err = s.txManager.Begin(ctx, func(ctx context.Context) error {
+		// 再試行で関数ごと再実行される場合に備えて初期化する
+		resp, replayed = nil, false
+
+		now := s.now()
+		record := &Record{
+			Actor:       req.Actor,
+			Key:         req.Key,
+			Fingerprint: req.Fingerprint,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(s.ttl),
+		}
+		existing, err := s.store.Reserve(ctx, record)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if existing.Fingerprint != req.Fingerprint {
+				return ErrKeyReused
+			}
+			if existing.Response == nil {
+				return fmt.Errorf("冪等キーにレスポンスが保存されていません: %s", req.Key)
+			}
+			resp, replayed = existing.Response, true
+			return nil
+		}
+
+		resp, err = f(ctx)
+		if err != nil {
+			return err
+		}
+		if !resp.Successful() {
+			return errNotStored
+		}
+		record.Response = resp
+		return s.store.Complete(ctx, record)
+	})
+	if errors.Is(err, errNotStored) {
+		return resp, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	span.SetAttributes(tracing.Attrs("idempotency.replayed", replayed)...)
+	if replayed {
+		logging.FromContext(ctx).Info("冪等キーのレスポンスを再送しました", "idempotency_key", req.Key)
+	}
+	return resp, replayed, nil
+}
+
+// PurgeExpired は有効期限を過ぎた冪等キーを削除し、削除した件数を返します。
+func (s *Service) PurgeExpired(ctx context.Context) (int, error) {
+	return s.store.DeleteExpired(ctx, s.now())
+}
+
+// RunPurger はctxが終了するまで、intervalごとに有効期限を過ぎた冪等キーを削除します。
+func (s *Service) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.PurgeExpired(ctx)
+			if err != nil {
+				logging.FromContext(ctx).Error("期限切れの冪等キーの削除に失敗しました", "error", err)
+				continue
+			}
+			if n > 0 {
+				logging.FromContext(ctx).Info("期限切れの冪等キーを削除しました", "count", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package publisher
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// DeletePublisherApplicationService は出版社削除ユースケースを実装するアプリケーションサービスです。
+type DeletePublisherApplicationService struct {
+	publisherRepository repository.PublisherRepository
+	transactionManager  shared.TransactionManager
+}
+
+// NewDeletePublisherApplicationService は新しいDeletePublisherApplicationServiceを生成します。
+func NewDeletePublisherApplicationService(
+	publisherRepo repository.PublisherRepository,
+	txManager shared.TransactionManager,
+) *DeletePublisherApplicationService {
+	return &DeletePublisherApplicationService{
+		publisherRepository: publisherRepo,
+		transactionManager:  txManager,
+	}
+}
+
+// Execute は指定されたIDの出版社を削除します。
+// 出版社が存在しない場合はKindNotFound、書籍から参照されている場合はKindConflictのエラーを返します。
+func (s *DeletePublisherApplicationService) Execute(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "DeletePublisherApplicationService.Execute", tracing.WithAttrs("publisher_id", id))
+	defer tracing.End(span, &err)
+
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		publisherId, err := publisher.NewPublisherId(id)
+		if err != nil {
+			return err
+		}
+
+		foundPublisher, err := s.publisherRepository.Find(ctx, publisherId)
+		if err != nil {
+			return err
+		}
+		if foundPublisher == nil {
+			return newPublisherNotFoundError(id)
+		}
+
+		return s.publisherRepository.Delete(ctx, publisherId)
+	})
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("出版社を削除しました", "publisher_id", id)
+	return nil
+}
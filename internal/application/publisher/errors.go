@@ -0,0 +1,11 @@
+package publisher
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// newPublisherNotFoundError は出版社が存在しないことを表すエラーを生成します。
+func newPublisherNotFoundError(id string) error {
+	return shared.NewDomainError(shared.KindNotFound, i18n.MsgPublisherNotFound, id)
+}
@@ -0,0 +1,50 @@
+package publisher
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// GetPublisherApplicationService は出版社取得ユースケースを実装するアプリケーションサービスです。
+type GetPublisherApplicationService struct {
+	publisherRepository repository.PublisherRepository
+}
+
+// NewGetPublisherApplicationService は新しいGetPublisherApplicationServiceを生成します。
+func NewGetPublisherApplicationService(publisherRepository repository.PublisherRepository) *GetPublisherApplicationService {
+	return &GetPublisherApplicationService{publisherRepository: publisherRepository}
+}
+
+// PublisherDTO は出版社のデータ転送オブジェクトです。
+type PublisherDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Execute は指定されたIDの出版社を取得します。出版社が存在しない場合はnilを返します。
+func (s *GetPublisherApplicationService) Execute(ctx context.Context, id string) (_ *PublisherDTO, err error) {
+	ctx, span := tracing.Start(ctx, "GetPublisherApplicationService.Execute", tracing.WithAttrs("publisher_id", id))
+	defer tracing.End(span, &err)
+
+	publisherId, err := publisher.NewPublisherId(id)
+	if err != nil {
+		return nil, err
+	}
+
+	foundPublisher, err := s.publisherRepository.Find(ctx, publisherId)
+	if err != nil {
+		return nil, err
+	}
+	if foundPublisher == nil {
+		return nil, nil
+	}
+
+	return newPublisherDTO(foundPublisher), nil
+}
+
+// newPublisherDTO はPublisherエンティティからPublisherDTOを生成します。
+func newPublisherDTO(p *publisher.Publisher) *PublisherDTO {
+	return &PublisherDTO{ID: p.PublisherId().Value(), Name: p.Name().Value()}
+}
@@ -0,0 +1,55 @@
+package publisher
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+const (
+	// DefaultListLimit は一覧取得件数の既定値です。
+	DefaultListLimit = 20
+	// MaxListLimit は一覧取得件数の上限です。
+	MaxListLimit = 100
+)
+
+// ListPublishersQuery は出版社一覧の検索条件です。
+type ListPublishersQuery struct {
+	Limit  int
+	Offset int
+}
+
+// PublisherQueryService は出版社の参照系クエリを提供するインターフェースです。
+type PublisherQueryService interface {
+	// ListPublishers は出版社を出版社名順（同じ名前の場合はID順）に返します。
+	ListPublishers(ctx context.Context, query ListPublishersQuery) ([]*PublisherDTO, error)
+}
+
+// ListPublishersApplicationService は出版社一覧取得ユースケースを実装するアプリケーションサービスです。
+type ListPublishersApplicationService struct {
+	queryService PublisherQueryService
+}
+
+// NewListPublishersApplicationService は新しいListPublishersApplicationServiceを生成します。
+func NewListPublishersApplicationService(queryService PublisherQueryService) *ListPublishersApplicationService {
+	return &ListPublishersApplicationService{queryService: queryService}
+}
+
+// Execute は検索条件を検証し、出版社一覧を取得します。Limitが0の場合はDefaultListLimitを使用します。
+func (s *ListPublishersApplicationService) Execute(ctx context.Context, query ListPublishersQuery) (_ []*PublisherDTO, err error) {
+	ctx, span := tracing.Start(ctx, "ListPublishersApplicationService.Execute")
+	defer tracing.End(span, &err)
+
+	if query.Limit == 0 {
+		query.Limit = DefaultListLimit
+	}
+	if query.Limit < 0 || query.Limit > MaxListLimit {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListLimitOutOfRange, MaxListLimit)
+	}
+	if query.Offset < 0 {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgListOffsetNegative)
+	}
+
+	return s.queryService.ListPublishers(ctx, query)
+}
@@ -0,0 +1,60 @@
+package publisher
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// RegisterPublisherCommand は出版社登録に必要なパラメータを保持する構造体です。
+type RegisterPublisherCommand struct {
+	Name string
+}
+
+// RegisterPublisherApplicationService は出版社登録ユースケースを実装するアプリケーションサービスです。
+type RegisterPublisherApplicationService struct {
+	publisherRepository repository.PublisherRepository
+	transactionManager  shared.TransactionManager
+}
+
+// NewRegisterPublisherApplicationService は新しいRegisterPublisherApplicationServiceを生成します。
+func NewRegisterPublisherApplicationService(
+	publisherRepo repository.PublisherRepository,
+	txManager shared.TransactionManager,
+) *RegisterPublisherApplicationService {
+	return &RegisterPublisherApplicationService{
+		publisherRepository: publisherRepo,
+		transactionManager:  txManager,
+	}
+}
+
+// Execute は出版社登録処理を実行し、登録した出版社を返します。出版社IDは新しく採番します。
+func (s *RegisterPublisherApplicationService) Execute(ctx context.Context, cmd RegisterPublisherCommand) (_ *PublisherDTO, err error) {
+	ctx, span := tracing.Start(ctx, "RegisterPublisherApplicationService.Execute")
+	defer tracing.End(span, &err)
+
+	var dto *PublisherDTO
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		name, err := publisher.NewName(cmd.Name)
+		if err != nil {
+			return err
+		}
+
+		newPublisher := publisher.NewPublisher(publisher.GeneratePublisherId(), name)
+		if err := s.publisherRepository.Save(ctx, newPublisher); err != nil {
+			return err
+		}
+
+		dto = newPublisherDTO(newPublisher)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("出版社を登録しました", "publisher_id", dto.ID)
+	return dto, nil
+}
@@ -0,0 +1,74 @@
+package publisher
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/tracing"
+)
+
+// UpdatePublisherCommand は出版社の更新に必要なパラメータを保持する構造体です。
+type UpdatePublisherCommand struct {
+	ID   string
+	Name string
+}
+
+// UpdatePublisherApplicationService は出版社名の変更ユースケースを実装するアプリケーションサービスです。
+// 書籍は出版社をIDで参照するため、変更後の出版社名は全ての書籍に反映されます。
+type UpdatePublisherApplicationService struct {
+	publisherRepository repository.PublisherRepository
+	transactionManager  shared.TransactionManager
+}
+
+// NewUpdatePublisherApplicationService は新しいUpdatePublisherApplicationServiceを生成します。
+func NewUpdatePublisherApplicationService(
+	publisherRepo repository.PublisherRepository,
+	txManager shared.TransactionManager,
+) *UpdatePublisherApplicationService {
+	return &UpdatePublisherApplicationService{
+		publisherRepository: publisherRepo,
+		transactionManager:  txManager,
+	}
+}
+
+// Execute は出版社名を変更し、変更後の出版社を返します。出版社が存在しない場合はKindNotFoundのエラーを返します。
+func (s *UpdatePublisherApplicationService) Execute(ctx context.Context, cmd UpdatePublisherCommand) (_ *PublisherDTO, err error) {
+	ctx, span := tracing.Start(ctx, "UpdatePublisherApplicationService.Execute", tracing.WithAttrs("publisher_id", cmd.ID))
+	defer tracing.End(span, &err)
+
+	var dto *PublisherDTO
+	err = s.transactionManager.Begin(ctx, func(ctx context.Context) error {
+		publisherId, err := publisher.NewPublisherId(cmd.ID)
+		if err != nil {
+			return err
+		}
+		name, err := publisher.NewName(cmd.Name)
+		if err != nil {
+			return err
+		}
+
+		foundPublisher, err := s.publisherRepository.Find(ctx, publisherId)
+		if err != nil {
+			return err
+		}
+		if foundPublisher == nil {
+			return newPublisherNotFoundError(cmd.ID)
+		}
+
+		foundPublisher.Rename(name)
+		if err := s.publisherRepository.Save(ctx, foundPublisher); err != nil {
+			return err
+		}
+
+		dto = newPublisherDTO(foundPublisher)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("出版社を更新しました", "publisher_id", cmd.ID)
+	return dto, nil
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+)
+
+// APIKeyAuthenticator は静的に設定されたAPIキーでアクターを認証します。
+type APIKeyAuthenticator struct {
+	keys []apiKey
+}
+
+// apiKey は登録されたAPIキーです。比較時間からキーを推測されないよう、ハッシュ値を固定時間で比較します。
+type apiKey struct {
+	hash      [sha256.Size]byte
+	principal *Principal
+}
+
+// NewAPIKeyAuthenticator はAPIキーの定義からAPIKeyAuthenticatorを生成します。
+// specは「<アクター>:<ロール>[|<ロール>...]:<キー>」をカンマで区切って並べたものです。
+//
+//	inventory-bot:warehouse:9f2c...,alice:editor|warehouse:3ab1...
+func NewAPIKeyAuthenticator(spec string) (*APIKeyAuthenticator, error) {
+	a := &APIKeyAuthenticator{}
+	seen := make(map[[sha256.Size]byte]string)
+	for i, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("APIキーの%d件目の形式が不正です (<アクター>:<ロール>:<キー>)", i+1)
+		}
+
+		var roles []Role
+		for _, s := range strings.Split(parts[1], "|") {
+			r, err := ParseRole(s)
+			if err != nil {
+				return nil, fmt.Errorf("APIキーの%d件目 (%s): %w", i+1, parts[0], err)
+			}
+			roles = append(roles, r)
+		}
+
+		hash := sha256.Sum256([]byte(parts[2]))
+		if other, ok := seen[hash]; ok {
+			return nil, fmt.Errorf("APIキーの%d件目 (%s) は %s と同じキーです", i+1, parts[0], other)
+		}
+		seen[hash] = parts[0]
+
+		a.keys = append(a.keys, apiKey{
+			hash:      hash,
+			principal: &Principal{ID: parts[0], Roles: roles, Method: MethodAPIKey},
+		})
+	}
+	return a, nil
+}
+
+// Authenticate はAPIキーが登録されていればそのアクターを返します。APIキーが指定されていない場合は (nil, nil) を返します。
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, c Credentials) (*Principal, error) {
+	if c.APIKey == "" {
+		return nil, nil
+	}
+	hash := sha256.Sum256([]byte(c.APIKey))
+	var found *Principal
+	for _, k := range a.keys {
+		// 一致した後も全てのキーと比較し、登録順による応答時間の差をなくす
+		if subtle.ConstantTimeCompare(hash[:], k.hash[:]) == 1 {
+			found = k.principal
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: APIキーが登録されていません", ErrUnauthenticated)
+	}
+	return found, nil
+}
@@ -0,0 +1,140 @@
+// Package auth はAPIの利用者（アクター）の認証と、ロールによる認可の判定を提供します。
+// 認証されたアクターはコンテキストに格納され、アプリケーションサービスやドメインイベントへ引き継がれます。
+// 認証はHTTPのミドルウェアとgRPCのインターセプターが行い、GraphQLのリゾルバーはロールを確認し、レート制限と冪等キーはアクターでクライアントを区別します。
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Role はアクターに与えられる権限の種類です。
+type Role string
+
+const (
+	// RoleViewer は書籍の参照のみができます。
+	RoleViewer Role = "viewer"
+	// RoleEditor は書籍の参照に加え、登録・更新・削除・一括取り込みができます。
+	RoleEditor Role = "editor"
+	// RoleWarehouse は書籍の参照に加え、在庫の調整ができます。
+	RoleWarehouse Role = "warehouse"
+	// RoleAdmin は全ての操作ができます。
+	RoleAdmin Role = "admin"
+)
+
+// ParseRole は文字列をRoleへ変換します。
+func ParseRole(s string) (Role, error) {
+	switch r := Role(strings.ToLower(strings.TrimSpace(s))); r {
+	case RoleViewer, RoleEditor, RoleWarehouse, RoleAdmin:
+		return r, nil
+	default:
+		return "", fmt.Errorf("不正なロールです: %q", s)
+	}
+}
+
+// 認証方式
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// Principal は認証されたアクターです。
+type Principal struct {
+	// ID はアクターの識別子です（APIキーの利用者名やJWTのsub）。
+	ID string
+	// Roles はアクターに与えられたロールです。
+	Roles []Role
+	// Method は認証方式（MethodAPIKeyまたはMethodJWT）です。
+	Method string
+}
+
+// Can はアクターがrequiredのロールを必要とする操作を実行できるかを判定します。
+// adminは全ての操作を、viewerの操作はいずれかのロールを持つアクターが実行できます。
+func (p *Principal) Can(required Role) bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range p.Roles {
+		if r == RoleAdmin || r == required || required == RoleViewer {
+			return true
+		}
+	}
+	return false
+}
+
+// Credentials はリクエストから取り出した認証情報です。指定されていない項目は空文字列です。
+type Credentials struct {
+	// APIKey はX-API-Keyヘッダー（gRPCではx-api-keyメタデータ）の値です。
+	APIKey string
+	// BearerToken はAuthorization: Bearer のトークンです。
+	BearerToken string
+}
+
+// Empty は認証情報が1つも指定されていないかどうかを返します。
+func (c Credentials) Empty() bool {
+	return c.APIKey == "" && c.BearerToken == ""
+}
+
+// BearerToken はAuthorizationヘッダー（gRPCではauthorizationメタデータ）の値からBearerトークンを取り出します。
+// Bearer以外の方式の場合は空文字列を返します。
+func BearerToken(authorization string) string {
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// Authenticator は認証情報を検証してアクターを返します。
+// 扱わない種類の認証情報しか含まれていない場合は (nil, nil) を返し、次のAuthenticatorに委ねます。
+// 認証情報が不正な場合はErrUnauthenticatedをラップしたエラーを返します。
+type Authenticator interface {
+	Authenticate(ctx context.Context, c Credentials) (*Principal, error)
+}
+
+// ErrUnauthenticated は認証情報が不正であることを表します。
+var ErrUnauthenticated = errors.New("認証に失敗しました")
+
+// Authenticate は認証情報をauthenticatorsで順に検証し、最初に認証できたアクターを返します。
+// 認証情報が指定されていない場合は (nil, nil) を返します。
+// 認証情報が指定されているのにいずれのAuthenticatorでも認証できなかった場合はErrUnauthenticatedを返します。
+func Authenticate(ctx context.Context, authenticators []Authenticator, c Credentials) (*Principal, error) {
+	if c.Empty() {
+		return nil, nil
+	}
+	for _, a := range authenticators {
+		p, err := a.Authenticate(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			return p, nil
+		}
+	}
+	return nil, ErrUnauthenticated
+}
+
+type key int
+
+const principalKey key = iota
+
+// WithPrincipal はアクターを格納したコンテキストを返します。
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// FromContext はコンテキストに格納されたアクターを返します。認証されていない場合はnilを返します。
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey).(*Principal)
+	return p
+}
+
+// Actor はコンテキストに格納されたアクターのIDを返します。認証されていない場合は空文字列を返します。
+func Actor(ctx context.Context) string {
+	if p := FromContext(ctx); p != nil {
+		return p.ID
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTConfig はJWTの検証設定です。HMACSecretとPublicKeyFileの少なくとも一方を指定します。
+type JWTConfig struct {
+	// HMACSecret はHS256/HS384/HS512の署名を検証する共有鍵です。
+	HMACSecret string
+	// PublicKeyFile はRS256・ES256・EdDSAなどの署名を検証する公開鍵（PEM形式）のパスです。
+	PublicKeyFile string
+	// Issuer が空でない場合は、issクレームが一致することを検証します。
+	Issuer string
+	// Audience が空でない場合は、audクレームに含まれることを検証します。
+	Audience string
+}
+
+// JWTAuthenticator はBearerトークンとして送られたJWTの署名と有効期限をローカルで検証し、アクターを認証します。
+// アクターのIDはsubクレーム、ロールはrolesクレーム（文字列の配列）から取り出します。
+type JWTAuthenticator struct {
+	hmacSecret []byte
+	publicKey  crypto.PublicKey
+	methods    []string
+	options    []jwt.ParserOption
+}
+
+// jwtClaims はJWTから取り出すクレームです。
+type jwtClaims struct {
+	Roles []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// NewJWTAuthenticator は新しいJWTAuthenticatorを生成します。
+func NewJWTAuthenticator(cfg JWTConfig) (*JWTAuthenticator, error) {
+	a := &JWTAuthenticator{}
+	if cfg.HMACSecret != "" {
+		a.hmacSecret = []byte(cfg.HMACSecret)
+		a.methods = append(a.methods, "HS256", "HS384", "HS512")
+	}
+	if cfg.PublicKeyFile != "" {
+		pem, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("JWTの公開鍵の読み込みに失敗しました: %w", err)
+		}
+		if a.publicKey, err = parsePublicKey(pem); err != nil {
+			return nil, fmt.Errorf("JWTの公開鍵の読み込みに失敗しました: %w", err)
+		}
+		a.methods = append(a.methods, "RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA")
+	}
+	if len(a.methods) == 0 {
+		return nil, errors.New("JWTの検証には共有鍵または公開鍵が必要です")
+	}
+
+	a.options = []jwt.ParserOption{
+		jwt.WithValidMethods(a.methods),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		a.options = append(a.options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		a.options = append(a.options, jwt.WithAudience(cfg.Audience))
+	}
+	return a, nil
+}
+
+// parsePublicKey はPEM形式の公開鍵（RSA・ECDSA・Ed25519）を読み込みます。
+func parsePublicKey(pem []byte) (crypto.PublicKey, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(pem); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(pem); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(pem); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("RSA・ECDSA・Ed25519のいずれの公開鍵でもありません")
+}
+
+// Authenticate はJWTを検証してアクターを返します。Bearerトークンが指定されていない場合は (nil, nil) を返します。
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, c Credentials) (*Principal, error) {
+	if c.BearerToken == "" {
+		return nil, nil
+	}
+
+	var claims jwtClaims
+	_, err := jwt.ParseWithClaims(c.BearerToken, &claims, a.key, a.options...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: JWTが不正です: %v", ErrUnauthenticated, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: JWTにsubクレームがありません", ErrUnauthenticated)
+	}
+
+	p := &Principal{ID: claims.Subject, Method: MethodJWT}
+	for _, s := range claims.Roles {
+		// 知らないロールは無視する（他のサービス向けのロールが含まれていてもよい）
+		if r, err := ParseRole(s); err == nil {
+			p.Roles = append(p.Roles, r)
+		}
+	}
+	return p, nil
+}
+
+// key は署名方式に応じて検証に使う鍵を返します。
+func (a *JWTAuthenticator) key(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if a.hmacSecret == nil {
+			return nil, errors.New("共有鍵が設定されていません")
+		}
+		return a.hmacSecret, nil
+	}
+	if a.publicKey == nil {
+		return nil, errors.New("公開鍵が設定されていません")
+	}
+	return a.publicKey, nil
+}
@@ -0,0 +1,365 @@
+// Package config はアプリケーションの設定を型付きで読み込みます。
+//
+// 設定値は次の順に適用され、後のものが優先されます。
+//
+//  1. 既定値 (Default)
+//  2. 設定ファイル (YAMLまたはTOML。-config フラグまたは環境変数 CONFIG_FILE で指定)
+//  3. 環境変数 (各フィールドの env タグ)
+//  4. コマンドラインフラグ (各フィールドの flag タグ)
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Config はアプリケーション全体の設定です。
+type Config struct {
+	Server      ServerConfig      `yaml:"server" toml:"server"`
+	Database    DatabaseConfig    `yaml:"database" toml:"database"`
+	Events      EventsConfig      `yaml:"events" toml:"events"`
+	Features    FeaturesConfig    `yaml:"features" toml:"features"`
+	Log         LogConfig         `yaml:"log" toml:"log"`
+	Tracing     TracingConfig     `yaml:"tracing" toml:"tracing"`
+	Auth        AuthConfig        `yaml:"auth" toml:"auth"`
+	Idempotency IdempotencyConfig `yaml:"idempotency" toml:"idempotency"`
+	Limits      LimitsConfig      `yaml:"limits" toml:"limits"`
+}
+
+// ServerConfig はHTTPサーバーとgRPCサーバーの設定です。
+type ServerConfig struct {
+	HTTPPort          int           `yaml:"http_port" toml:"http_port" env:"HTTP_PORT" flag:"http-port" usage:"HTTPサーバーのポート"`
+	GRPCPort          int           `yaml:"grpc_port" toml:"grpc_port" env:"GRPC_PORT" flag:"grpc-port" usage:"gRPCサーバーのポート"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" toml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT" flag:"http-read-header-timeout" usage:"リクエストヘッダーの読み込みのタイムアウト"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" toml:"read_timeout" env:"HTTP_READ_TIMEOUT" flag:"http-read-timeout" usage:"リクエスト全体の読み込みのタイムアウト"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" toml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" flag:"http-write-timeout" usage:"レスポンスの書き込みのタイムアウト"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" toml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" flag:"http-idle-timeout" usage:"Keep-Alive接続のアイドルタイムアウト"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" flag:"shutdown-timeout" usage:"停止シグナルを受けてから処理中のリクエストの完了を待つ上限"`
+}
+
+// DatabaseConfig はデータの保存先とPostgreSQLへの接続設定です。
+// DSNが指定されている場合は、Host〜SSLKeyの代わりにDSNをそのまま使用します。
+// Backendがmemoryの場合、接続設定は使用されません。
+type DatabaseConfig struct {
+	Backend      string `yaml:"backend" toml:"backend" env:"DB_BACKEND" flag:"db-backend" usage:"データの保存先 (postgres|memory)"`
+	DSN          string `yaml:"dsn" toml:"dsn" env:"DB_DSN" flag:"db-dsn" usage:"接続文字列 (指定した場合は個別の接続設定より優先)" secret:"dsn"`
+	Host         string `yaml:"host" toml:"host" env:"DB_HOST" flag:"db-host" usage:"データベースのホスト"`
+	Port         int    `yaml:"port" toml:"port" env:"DB_PORT" flag:"db-port" usage:"データベースのポート"`
+	User         string `yaml:"user" toml:"user" env:"DB_USER" flag:"db-user" usage:"データベースのユーザー"`
+	Password     string `yaml:"password" toml:"password" env:"DB_PASS" flag:"db-password" usage:"データベースのパスワード" secret:"true"`
+	Name         string `yaml:"name" toml:"name" env:"DB_NAME" flag:"db-name" usage:"データベース名"`
+	SSLMode      string `yaml:"sslmode" toml:"sslmode" env:"DB_SSLMODE" flag:"db-sslmode" usage:"TLSモード (disable|require|verify-ca|verify-full)"`
+	SSLRootCert  string `yaml:"sslrootcert" toml:"sslrootcert" env:"DB_SSLROOTCERT" flag:"db-sslrootcert" usage:"サーバー証明書を検証するCA証明書のパス"`
+	SSLCert      string `yaml:"sslcert" toml:"sslcert" env:"DB_SSLCERT" flag:"db-sslcert" usage:"クライアント証明書のパス"`
+	SSLKey       string `yaml:"sslkey" toml:"sslkey" env:"DB_SSLKEY" flag:"db-sslkey" usage:"クライアント証明書の秘密鍵のパス"`
+	MaxOpenConns int    `yaml:"max_open_conns" toml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" flag:"db-max-open-conns" usage:"接続プールの最大接続数 (0は無制限)"`
+	MaxIdleConns int    `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" flag:"db-max-idle-conns" usage:"接続プールに保持するアイドル接続数"`
+
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" flag:"db-conn-max-lifetime" usage:"接続を再利用する最大時間 (0は無制限)"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" flag:"db-conn-max-idle-time" usage:"アイドル接続を保持する最大時間 (0は無制限)"`
+
+	ConnectAttempts   int           `yaml:"connect_attempts" toml:"connect_attempts" env:"DB_CONNECT_ATTEMPTS" flag:"db-connect-attempts" usage:"起動時の接続確認の最大試行回数"`
+	ConnectBackoff    time.Duration `yaml:"connect_backoff" toml:"connect_backoff" env:"DB_CONNECT_BACKOFF" flag:"db-connect-backoff" usage:"起動時の接続確認の最初の再試行までの待機時間 (以降は倍々で増加)"`
+	ConnectMaxBackoff time.Duration `yaml:"connect_max_backoff" toml:"connect_max_backoff" env:"DB_CONNECT_MAX_BACKOFF" flag:"db-connect-max-backoff" usage:"起動時の接続確認の再試行の待機時間の上限"`
+
+	// MigrateOnStart は起動時に未適用のマイグレーションを適用するかどうかです。
+	// 無効の場合は `bookctl migrate` で事前に適用しておく必要があります（未適用の間はレディネスプローブが失敗します）。
+	MigrateOnStart bool `yaml:"migrate_on_start" toml:"migrate_on_start" env:"DB_MIGRATE_ON_START" flag:"db-migrate-on-start" usage:"起動時に未適用のマイグレーションを適用する"`
+}
+
+// EventsConfig はドメインイベントの配信設定です。
+type EventsConfig struct {
+	DispatchMode string `yaml:"dispatch_mode" toml:"dispatch_mode" env:"EVENT_DISPATCH_MODE" flag:"event-dispatch-mode" usage:"イベントの配信方式 (sync|async)"`
+}
+
+// FeaturesConfig は機能の有効・無効の切り替えです。
+type FeaturesConfig struct {
+	GraphQL            bool `yaml:"graphql" toml:"graphql" env:"FEATURE_GRAPHQL" flag:"feature-graphql" usage:"GraphQL APIを有効にする"`
+	GRPC               bool `yaml:"grpc" toml:"grpc" env:"FEATURE_GRPC" flag:"feature-grpc" usage:"gRPCサーバーを起動する"`
+	ResponseValidation bool `yaml:"response_validation" toml:"response_validation" env:"FEATURE_RESPONSE_VALIDATION" flag:"feature-response-validation" usage:"レスポンスをOpenAPI仕様で検証する (開発用)"`
+	Metrics            bool `yaml:"metrics" toml:"metrics" env:"FEATURE_METRICS" flag:"feature-metrics" usage:"Prometheus形式のメトリクスを /metrics で公開する"`
+}
+
+// LogConfig はログの出力設定です。
+type LogConfig struct {
+	Level  string `yaml:"level" toml:"level" env:"LOG_LEVEL" flag:"log-level" usage:"ログレベル (debug|info|warn|error)"`
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT" flag:"log-format" usage:"ログの出力形式 (json|text)"`
+}
+
+// TracingConfig はOpenTelemetryによるトレースの出力設定です。
+type TracingConfig struct {
+	Exporter     string `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER" flag:"tracing-exporter" usage:"トレースの出力先 (none|stdout|otlp)"`
+	OTLPEndpoint string `yaml:"otlp_endpoint" toml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT" flag:"tracing-otlp-endpoint" usage:"OTLP/gRPCでトレースを送信するコレクターのアドレス (host:port)"`
+	OTLPInsecure bool   `yaml:"otlp_insecure" toml:"otlp_insecure" env:"TRACING_OTLP_INSECURE" flag:"tracing-otlp-insecure" usage:"コレクターへの送信にTLSを使用しない (ローカルのコレクター用)"`
+	ServiceName  string `yaml:"service_name" toml:"service_name" env:"TRACING_SERVICE_NAME" flag:"tracing-service-name" usage:"トレースに記録するサービス名"`
+}
+
+// IdempotencyConfig は冪等キー（Idempotency-Keyヘッダー）の保持期間の設定です。
+type IdempotencyConfig struct {
+	TTL           time.Duration `yaml:"ttl" toml:"ttl" env:"IDEMPOTENCY_TTL" flag:"idempotency-ttl" usage:"冪等キーとレスポンスを保持する期間 (期間内の同じキーのリクエストには保存したレスポンスを返す)"`
+	PurgeInterval time.Duration `yaml:"purge_interval" toml:"purge_interval" env:"IDEMPOTENCY_PURGE_INTERVAL" flag:"idempotency-purge-interval" usage:"期限切れの冪等キーを削除する間隔"`
+}
+
+// LimitsConfig はHTTPのリクエストのレート制限とボディの大きさの上限の設定です。
+// レートは <回数>/<期間>（60/1m など）で指定し、クライアント（認証されたアクター、またはIPアドレス）ごとに適用します。
+type LimitsConfig struct {
+	RateLimit            string `yaml:"rate_limit" toml:"rate_limit" env:"RATE_LIMIT" flag:"rate-limit" usage:"全てのルートに適用するクライアントごとのレート (<回数>/<期間>、空の場合は制限しない)"`
+	RouteRateLimits      string `yaml:"route_rate_limits" toml:"route_rate_limits" env:"RATE_LIMIT_ROUTES" flag:"rate-limit-routes" usage:"ルートごとに追加で適用するレート (<メソッド> <パス>=<回数>/<期間> のカンマ区切り)"`
+	AuthFailureRateLimit string `yaml:"auth_failure_rate_limit" toml:"auth_failure_rate_limit" env:"RATE_LIMIT_AUTH_FAILURES" flag:"rate-limit-auth-failures" usage:"認証に失敗したリクエストに適用するIPアドレスごとのレート (<回数>/<期間>、空の場合は制限しない)"`
+	TrustForwardedFor    bool   `yaml:"trust_forwarded_for" toml:"trust_forwarded_for" env:"RATE_LIMIT_TRUST_FORWARDED_FOR" flag:"rate-limit-trust-forwarded-for" usage:"X-Forwarded-ForヘッダーのIPアドレスでクライアントを区別する (信頼できるリバースプロキシの背後でのみ有効にする)"`
+	MaxBodyBytes         int    `yaml:"max_body_bytes" toml:"max_body_bytes" env:"HTTP_MAX_BODY_BYTES" flag:"http-max-body-bytes" usage:"リクエストボディの上限 (バイト)"`
+	MaxImportBodyBytes   int    `yaml:"max_import_body_bytes" toml:"max_import_body_bytes" env:"HTTP_MAX_IMPORT_BODY_BYTES" flag:"http-max-import-body-bytes" usage:"一括取り込みのリクエストボディの上限 (バイト)"`
+}
+
+// AuthConfig はAPIの認証の設定です。
+// 有効な場合、APIキーとJWTのうち設定されたものでアクターを認証し、ロールで操作を認可します。
+// 既定では有効で、APIキーかJWTの検証方法を設定しないと起動しません。無効にするには明示的にEnabledをfalseにします（ローカルでの開発用）。
+type AuthConfig struct {
+	Enabled          bool   `yaml:"enabled" toml:"enabled" env:"AUTH_ENABLED" flag:"auth-enabled" usage:"APIの認証と認可を有効にする"`
+	APIKeys          string `yaml:"api_keys" toml:"api_keys" env:"AUTH_API_KEYS" flag:"auth-api-keys" usage:"APIキーの定義 (<アクター>:<ロール>[|<ロール>]:<キー> をカンマ区切り)" secret:"true"`
+	JWTSecret        string `yaml:"jwt_secret" toml:"jwt_secret" env:"AUTH_JWT_SECRET" flag:"auth-jwt-secret" usage:"JWT (HS256等) の署名を検証する共有鍵" secret:"true"`
+	JWTPublicKeyFile string `yaml:"jwt_public_key_file" toml:"jwt_public_key_file" env:"AUTH_JWT_PUBLIC_KEY_FILE" flag:"auth-jwt-public-key-file" usage:"JWT (RS256・ES256・EdDSA等) の署名を検証する公開鍵 (PEM) のパス"`
+	JWTIssuer        string `yaml:"jwt_issuer" toml:"jwt_issuer" env:"AUTH_JWT_ISSUER" flag:"auth-jwt-issuer" usage:"JWTのissクレームに求める値 (空の場合は検証しない)"`
+	JWTAudience      string `yaml:"jwt_audience" toml:"jwt_audience" env:"AUTH_JWT_AUDIENCE" flag:"auth-jwt-audience" usage:"JWTのaudクレームに求める値 (空の場合は検証しない)"`
+}
+
+// トレースの出力先
+const (
+	TracingNone   = "none"
+	TracingStdout = "stdout"
+	TracingOTLP   = "otlp"
+)
+
+// logLevels と logFormats はログの設定として指定できる値です。
+var (
+	logLevels  = []string{"debug", "info", "warn", "error"}
+	logFormats = []string{"json", "text"}
+)
+
+// イベントの配信方式
+const (
+	DispatchSync  = "sync"
+	DispatchAsync = "async"
+)
+
+// データの保存先
+const (
+	BackendPostgres = "postgres"
+	// BackendMemory はプロセス内のメモリに保存します。再起動するとデータは失われます。
+	BackendMemory = "memory"
+)
+
+// sslModes はlib/pqがサポートするsslmodeです。
+var sslModes = []string{"disable", "require", "verify-ca", "verify-full"}
+
+// Default は既定値の設定を返します。既定値はdocker-compose.ymlの開発環境に合わせています。
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			HTTPPort:          8080,
+			GRPCPort:          9090,
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Backend:      BackendPostgres,
+			Host:         "localhost",
+			Port:         5433,
+			User:         "ddd",
+			Password:     "ddd",
+			Name:         "ddd_hands_on",
+			SSLMode:      "disable",
+			MaxOpenConns: 20,
+			MaxIdleConns: 5,
+
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+
+			ConnectAttempts:   10,
+			ConnectBackoff:    500 * time.Millisecond,
+			ConnectMaxBackoff: 5 * time.Second,
+		},
+		Events: EventsConfig{
+			DispatchMode: DispatchSync,
+		},
+		Features: FeaturesConfig{
+			GraphQL: true,
+			GRPC:    true,
+			Metrics: true,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: TracingConfig{
+			Exporter:     TracingNone,
+			OTLPEndpoint: "localhost:4317",
+			OTLPInsecure: true,
+			ServiceName:  "ddd-hands-on-go",
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:           24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Limits: LimitsConfig{
+			RateLimit:            "600/1m",
+			RouteRateLimits:      "POST /books=60/1m,POST /books/import=5/1m",
+			AuthFailureRateLimit: "10/1m",
+			MaxBodyBytes:         1 << 20,
+			MaxImportBodyBytes:   64 << 20,
+		},
+		Auth: AuthConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// Profile は設定を読み込むプログラムの種類です。プログラムが使用しない設定項目は検証しません。
+type Profile int
+
+const (
+	// ProfileAPI はAPIサーバーです。全ての設定項目を検証します。
+	ProfileAPI Profile = iota
+	// ProfileCLI は管理用CLI（bookctl）です。APIを公開しないため、認証の設定（auth.*）を検証しません。
+	// 認証の設定は読み込んだ値のまま残るため、環境変数 AUTH_ENABLED などを設定していても上書きしません。
+	ProfileCLI
+)
+
+// Validate はAPIサーバーの設定として設定値を検証し、誤りがあれば全てまとめて返します。
+func (c *Config) Validate() error {
+	return c.ValidateFor(ProfileAPI)
+}
+
+// ValidateFor はprofileのプログラムが使用する設定値を検証し、誤りがあれば全てまとめて返します。
+func (c *Config) ValidateFor(profile Profile) error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(validPort(c.Server.HTTPPort), "server.http_port は1〜65535である必要があります: %d", c.Server.HTTPPort)
+	check(validPort(c.Server.GRPCPort), "server.grpc_port は1〜65535である必要があります: %d", c.Server.GRPCPort)
+	check(c.Server.HTTPPort != c.Server.GRPCPort || !c.Features.GRPC, "server.http_port と server.grpc_port は異なる必要があります")
+	check(c.Server.ReadHeaderTimeout > 0, "server.read_header_timeout は正の値である必要があります")
+	check(c.Server.ReadTimeout > 0, "server.read_timeout は正の値である必要があります")
+	check(c.Server.WriteTimeout > 0, "server.write_timeout は正の値である必要があります")
+	check(c.Server.IdleTimeout > 0, "server.idle_timeout は正の値である必要があります")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout は正の値である必要があります")
+
+	check(c.Database.Backend == BackendPostgres || c.Database.Backend == BackendMemory,
+		"database.backend は %s または %s である必要があります: %q", BackendPostgres, BackendMemory, c.Database.Backend)
+	if c.Database.Backend == BackendPostgres && c.Database.DSN == "" {
+		check(c.Database.Host != "", "database.host は必須です")
+		check(validPort(c.Database.Port), "database.port は1〜65535である必要があります: %d", c.Database.Port)
+		check(c.Database.User != "", "database.user は必須です")
+		check(c.Database.Name != "", "database.name は必須です")
+		check(contains(sslModes, c.Database.SSLMode), "database.sslmode は %s のいずれかである必要があります: %q",
+			strings.Join(sslModes, ", "), c.Database.SSLMode)
+		check((c.Database.SSLCert == "") == (c.Database.SSLKey == ""), "database.sslcert と database.sslkey は両方指定する必要があります")
+	}
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns は0以上である必要があります")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns は0以上である必要があります")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns は database.max_open_conns 以下である必要があります")
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime は0以上である必要があります")
+	check(c.Database.ConnMaxIdleTime >= 0, "database.conn_max_idle_time は0以上である必要があります")
+	check(c.Database.ConnectAttempts >= 1, "database.connect_attempts は1以上である必要があります")
+	check(c.Database.ConnectBackoff > 0, "database.connect_backoff は正の値である必要があります")
+	check(c.Database.ConnectMaxBackoff >= c.Database.ConnectBackoff,
+		"database.connect_max_backoff は database.connect_backoff 以上である必要があります")
+
+	check(c.Events.DispatchMode == DispatchSync || c.Events.DispatchMode == DispatchAsync,
+		"events.dispatch_mode は %s または %s である必要があります: %q", DispatchSync, DispatchAsync, c.Events.DispatchMode)
+	check(contains(logLevels, c.Log.Level), "log.level は %s のいずれかである必要があります: %q",
+		strings.Join(logLevels, ", "), c.Log.Level)
+	check(contains(logFormats, c.Log.Format), "log.format は %s のいずれかである必要があります: %q",
+		strings.Join(logFormats, ", "), c.Log.Format)
+	check(c.Tracing.Exporter == TracingNone || c.Tracing.Exporter == TracingStdout || c.Tracing.Exporter == TracingOTLP,
+		"tracing.exporter は %s, %s, %s のいずれかである必要があります: %q", TracingNone, TracingStdout, TracingOTLP, c.Tracing.Exporter)
+	check(c.Tracing.Exporter != TracingOTLP || c.Tracing.OTLPEndpoint != "", "tracing.otlp_endpoint は必須です")
+	check(c.Tracing.ServiceName != "", "tracing.service_name は必須です")
+	check(c.Idempotency.TTL > 0, "idempotency.ttl は正の値である必要があります")
+	check(c.Idempotency.PurgeInterval > 0, "idempotency.purge_interval は正の値である必要があります")
+	check(c.Limits.MaxBodyBytes > 0, "limits.max_body_bytes は正の値である必要があります")
+	check(c.Limits.MaxImportBodyBytes > 0, "limits.max_import_body_bytes は正の値である必要があります")
+
+	if c.Auth.Enabled && profile == ProfileAPI {
+		check(c.Auth.APIKeys != "" || c.Auth.JWTSecret != "" || c.Auth.JWTPublicKeyFile != "",
+			"auth.enabled の場合は auth.api_keys、auth.jwt_secret、auth.jwt_public_key_file のいずれかが必要です（ローカルでの開発で認証を無効にする場合は auth.enabled を false にしてください）")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("設定が不正です: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// ConnString はlib/pqに渡す接続文字列を返します。
+func (d DatabaseConfig) ConnString() string {
+	if d.DSN != "" {
+		return d.DSN
+	}
+
+	params := []struct{ key, value string }{
+		{"host", d.Host},
+		{"port", fmt.Sprint(d.Port)},
+		{"user", d.User},
+		{"password", d.Password},
+		{"dbname", d.Name},
+		{"sslmode", d.SSLMode},
+		{"sslrootcert", d.SSLRootCert},
+		{"sslcert", d.SSLCert},
+		{"sslkey", d.SSLKey},
+	}
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		if p.value == "" {
+			continue
+		}
+		parts = append(parts, p.key+"="+quoteConnValue(p.value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteConnValue は空白や引用符を含む値をkey=value形式の接続文字列用に引用符で囲みます。
+func quoteConnValue(v string) string {
+	if !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return "'" + r.Replace(v) + "'"
+}
+
+// redactDSN は接続文字列に含まれるパスワードを伏せ字にします。
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql") {
+		return u.Redacted()
+	}
+
+	return passwordParam.ReplaceAllString(dsn, "${1}"+redacted)
+}
+
+// passwordParam はkey=value形式の接続文字列のpassword（引用符で囲まれた値を含む）に一致します。
+var passwordParam = regexp.MustCompile(`(\bpassword\s*=\s*)('(?:[^'\\]|\\.)*'|\S*)`)
+
+func validPort(p int) bool {
+	return p > 0 && p <= 65535
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
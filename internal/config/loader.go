@@ -0,0 +1,262 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv は設定ファイルのパスを指定する環境変数です。
+const ConfigFileEnv = "CONFIG_FILE"
+
+// redacted は秘密情報を出力する際の伏せ字です。
+const redacted = "****"
+
+// Loader は設定ファイル、環境変数、コマンドラインフラグから設定を読み込みます。
+type Loader struct {
+	lookupEnv  func(string) (string, bool)
+	fs         *flag.FlagSet
+	configPath *string
+	flagValues map[string]*flagValue
+	profile    Profile
+}
+
+// NewLoader は新しいLoaderを生成します。lookupEnvには通常os.LookupEnvを渡します。
+func NewLoader(lookupEnv func(string) (string, bool)) *Loader {
+	return &Loader{lookupEnv: lookupEnv}
+}
+
+// WithProfile はLoadでprofileのプログラムとして設定を検証するようにします。既定はProfileAPIです。
+func (l *Loader) WithProfile(profile Profile) *Loader {
+	l.profile = profile
+	return l
+}
+
+// RegisterFlags は -config と各設定項目のフラグをfsに登録します。
+// Loadはfs.Parseの後に呼び出してください。明示的に指定されたフラグのみが設定を上書きします。
+func (l *Loader) RegisterFlags(fs *flag.FlagSet) {
+	l.fs = fs
+	l.configPath = fs.String("config", "", "設定ファイル (YAMLまたはTOML) のパス。環境変数 "+ConfigFileEnv+" でも指定できます")
+	l.flagValues = make(map[string]*flagValue)
+
+	defaults := Default()
+	for _, f := range fields(defaults) {
+		if f.flag == "" {
+			continue
+		}
+		fv := &flagValue{isBool: f.value.Kind() == reflect.Bool}
+		if f.secret == "" {
+			fv.value = formatValue(f.value)
+		}
+		l.flagValues[f.flag] = fv
+		fs.Var(fv, f.flag, fmt.Sprintf("%s (環境変数 %s)", f.usage, f.env))
+	}
+}
+
+// Load は既定値、設定ファイル、環境変数、フラグの順に設定を適用し、WithProfileで指定したプログラムの設定として検証した結果を返します。
+func (l *Loader) Load() (*Config, error) {
+	cfg := Default()
+
+	path := ""
+	if l.configPath != nil {
+		path = *l.configPath
+	}
+	if path == "" {
+		path, _ = l.lookupEnv(ConfigFileEnv)
+	}
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range fields(cfg) {
+		if f.env == "" {
+			continue
+		}
+		v, ok := l.lookupEnv(f.env)
+		if !ok {
+			continue
+		}
+		if err := setValue(f.value, v); err != nil {
+			return nil, fmt.Errorf("環境変数 %s の値が不正です: %w", f.env, err)
+		}
+	}
+
+	if l.fs != nil {
+		var errs []error
+		set := make(map[string]bool)
+		l.fs.Visit(func(fl *flag.Flag) { set[fl.Name] = true })
+		for _, f := range fields(cfg) {
+			if !set[f.flag] {
+				continue
+			}
+			if err := setValue(f.value, l.flagValues[f.flag].value); err != nil {
+				errs = append(errs, fmt.Errorf("フラグ -%s の値が不正です: %w", f.flag, err))
+			}
+		}
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+	}
+
+	if err := cfg.ValidateFor(l.profile); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile は拡張子に応じてYAMLまたはTOMLの設定ファイルを読み込みます。
+// 未知のキーは設定の誤りとしてエラーにします。
+func loadFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("設定ファイルを開けませんでした: %w", err)
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("設定ファイル %s の読み込みに失敗しました: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.NewDecoder(f).Decode(cfg)
+		if err != nil {
+			return fmt.Errorf("設定ファイル %s の読み込みに失敗しました: %w", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("設定ファイル %s に未知のキーがあります: %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("サポートされていない設定ファイルの形式です (.yaml, .yml, .toml のいずれか): %s", path)
+	}
+	return nil
+}
+
+// Redacted はパスワードなどの秘密情報を伏せ字にした設定のコピーを返します。
+func (c *Config) Redacted() *Config {
+	cp := *c
+	for _, f := range fields(&cp) {
+		if f.secret == "" || f.value.String() == "" {
+			continue
+		}
+		if f.secret == "dsn" {
+			// 接続文字列はパスワード以外（ホストなど）を確認できるよう、パスワードのみ伏せ字にする
+			f.value.SetString(redactDSN(f.value.String()))
+			continue
+		}
+		f.value.SetString(redacted)
+	}
+	return &cp
+}
+
+// Write は秘密情報を伏せ字にした有効な設定をYAMLでwに書き出します。
+func (c *Config) Write(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return fmt.Errorf("設定の書き出しに失敗しました: %w", err)
+	}
+	return enc.Close()
+}
+
+// field は設定項目1つ分の情報です。
+type field struct {
+	env   string
+	flag  string
+	usage string
+	// secret は出力時の伏せ方です。"true" は値全体を、"dsn" は接続文字列中のパスワードのみを伏せ字にします。
+	secret string
+	value  reflect.Value
+}
+
+// fields はcfgの各セクションの設定項目を、値を書き換え可能な状態で返します。
+func fields(cfg *Config) []field {
+	var out []field
+	root := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < root.NumField(); i++ {
+		section := root.Field(i)
+		for j := 0; j < section.NumField(); j++ {
+			tag := section.Type().Field(j).Tag
+			out = append(out, field{
+				env:    tag.Get("env"),
+				flag:   tag.Get("flag"),
+				usage:  tag.Get("usage"),
+				secret: tag.Get("secret"),
+				value:  section.Field(j),
+			})
+		}
+	}
+	return out
+}
+
+// flagValue は設定項目のフラグの値です。型の変換はLoadでまとめて行います。
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (v *flagValue) String() string { return v.value }
+
+func (v *flagValue) Set(s string) error {
+	v.value = s
+	return nil
+}
+
+// IsBoolFlag はbool型の項目を値なし（-feature-grpc など）で指定できるようにします。
+func (v *flagValue) IsBoolFlag() bool { return v.isBool }
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue は文字列をフィールドの型に変換して設定します。
+func setValue(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("サポートされていない型です: %s", v.Type())
+	}
+	return nil
+}
+
+// formatValue はフィールドの値をフラグの既定値として表示する文字列に変換します。
+func formatValue(v reflect.Value) string {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,28 @@
+package author
+
+// Author は著者を表す集約ルートです。
+// 書籍はAuthorIdで著者を参照するため、同じ著者の表記の揺れを防ぎ、著者名の変更を全ての書籍へ反映できます。
+type Author struct {
+	authorId *AuthorId
+	name     *Name
+}
+
+// NewAuthor は新しいAuthorを生成します。DBなどから復元する際にも使用します。
+func NewAuthor(authorId *AuthorId, name *Name) *Author {
+	return &Author{authorId: authorId, name: name}
+}
+
+// Rename は著者名を変更します。
+func (a *Author) Rename(newName *Name) {
+	a.name = newName
+}
+
+// AuthorId は著者IDを返します。
+func (a *Author) AuthorId() *AuthorId {
+	return a.authorId
+}
+
+// Name は著者名を返します。
+func (a *Author) Name() *Name {
+	return a.name
+}
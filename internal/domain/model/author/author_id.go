@@ -0,0 +1,40 @@
+package author
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+
+	"github.com/google/uuid"
+)
+
+// AuthorId は著者のIDを表す値オブジェクトです。UUIDを小文字のハイフン区切りの形式で保持します。
+type AuthorId struct {
+	value string
+}
+
+// NewAuthorId は文字列から新しいAuthorIdを生成します。
+// 値がUUIDとして不正な場合はエラーを返します。
+func NewAuthorId(value string) (*AuthorId, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgAuthorIdInvalid, value)
+	}
+	return &AuthorId{value: id.String()}, nil
+}
+
+// GenerateAuthorId はランダムな（UUIDv4の）新しいAuthorIdを生成します。
+func GenerateAuthorId() *AuthorId {
+	return &AuthorId{value: uuid.NewString()}
+}
+
+// Value はAuthorIdの値を返します。
+func (id *AuthorId) Value() string {
+	return id.value
+}
+
+func (id *AuthorId) Equals(other *AuthorId) bool {
+	if other == nil {
+		return false
+	}
+	return id.value == other.value
+}
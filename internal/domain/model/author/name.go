@@ -0,0 +1,37 @@
+package author
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"unicode/utf8"
+)
+
+// MaxNameLength は著者名の最大文字数（ルーン数）です。
+const MaxNameLength = 100
+
+// Name は著者名を表す値オブジェクトです。
+// 名前は前後の空白を取り除き、NFCに正規化して保持します。
+type Name struct {
+	value string
+}
+
+// NewName は新しいNameを生成します。
+// 名前が空の場合、MaxNameLengthを超える場合、制御文字を含む場合はエラーを返します。
+func NewName(value string) (*Name, error) {
+	value = shared.NormalizeText(value)
+	if value == "" {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgAuthorRequired)
+	}
+	if utf8.RuneCountInString(value) > MaxNameLength {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgAuthorTooLong, MaxNameLength)
+	}
+	if shared.ContainsControl(value) {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgAuthorControlCharacter)
+	}
+	return &Name{value: value}, nil
+}
+
+// Value は著者名を返します。
+func (n *Name) Value() string {
+	return n.value
+}
@@ -3,23 +3,31 @@ package book
 import (
 	"ddd-hands-on-go/internal/domain/model/book/price"
 	"ddd-hands-on-go/internal/domain/model/book/stock"
+	"ddd-hands-on-go/internal/domain/model/category"
 	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
 	"fmt"
 	"time"
 )
 
+// MaxCategories は1冊の書籍に割り当てられるカテゴリの上限です。
+const MaxCategories = 20
+
 // Book は書籍を表す集約ルートです。
 type Book struct {
-	bookId *BookId
-	title  *Title
-	price  *price.Price
-	stock  *stock.Stock
-	events []shared.DomainEvent // ドメインイベントを保持
+	bookId   *BookId
+	title    *Title
+	price    *price.Price
+	metadata *Metadata
+	// categoryIds は割り当てられたカテゴリのIDです。順序に意味はありません。
+	categoryIds []*category.CategoryId
+	stock       *stock.Stock
+	events      []shared.DomainEvent // ドメインイベントを保持
 }
 
 // NewBook は新しいBookを生成します。
-// 書籍生成時に、初期在庫（0、在庫切れ）も同時に生成されます。
-func NewBook(bookId *BookId, title *Title, price *price.Price) (*Book, error) {
+// 書籍生成時に、初期在庫（0、在庫切れ）も同時に生成されます。metadataがnilの場合、書誌情報は全て不明とします。
+func NewBook(bookId *BookId, title *Title, price *price.Price, metadata *Metadata) (*Book, error) {
 	// 新しい書籍を作成する際、在庫は初期状態で空/作成されます
 	// 簡素化のため、StockIdにはBookIdと同じ値を使用します (1:1の関係)
 	newStock, err := stock.Create(bookId.Value())
@@ -28,11 +36,12 @@ func NewBook(bookId *BookId, title *Title, price *price.Price) (*Book, error) {
 	}
 
 	book := &Book{
-		bookId: bookId,
-		title:  title,
-		price:  price,
-		stock:  newStock,
-		events: make([]shared.DomainEvent, 0),
+		bookId:   bookId,
+		title:    title,
+		price:    price,
+		metadata: orEmptyMetadata(metadata),
+		stock:    newStock,
+		events:   make([]shared.DomainEvent, 0),
 	}
 
 	// BookCreatedイベントを記録
@@ -46,34 +55,115 @@ func NewBook(bookId *BookId, title *Title, price *price.Price) (*Book, error) {
 }
 
 // ReconstructBook はDBなどから復元する際に使用するファクトリ関数です。
-func ReconstructBook(bookId *BookId, title *Title, price *price.Price, stock *stock.Stock) *Book {
+func ReconstructBook(
+	bookId *BookId,
+	title *Title,
+	price *price.Price,
+	metadata *Metadata,
+	categoryIds []*category.CategoryId,
+	stock *stock.Stock,
+) *Book {
 	return &Book{
-		bookId: bookId,
-		title:  title,
-		price:  price,
-		stock:  stock,
-		events: make([]shared.DomainEvent, 0),
+		bookId:      bookId,
+		title:       title,
+		price:       price,
+		metadata:    orEmptyMetadata(metadata),
+		categoryIds: categoryIds,
+		stock:       stock,
+		events:      make([]shared.DomainEvent, 0),
 	}
 }
 
+func orEmptyMetadata(m *Metadata) *Metadata {
+	if m == nil {
+		return &Metadata{}
+	}
+	return m
+}
+
 // ChangeTitle は書籍のタイトルを変更します。
 func (b *Book) ChangeTitle(newTitle *Title) {
 	b.title = newTitle
+	b.AddEvent(&BookTitleChanged{
+		BookId:     b.bookId.Value(),
+		Title:      newTitle.Value(),
+		OccurredAt: time.Now(),
+	})
 }
 
 // ChangePrice は書籍の価格を変更します。
 func (b *Book) ChangePrice(newPrice *price.Price) {
 	b.price = newPrice
+	b.AddEvent(&BookPriceChanged{
+		BookId:      b.bookId.Value(),
+		PriceAmount: newPrice.Amount(),
+		OccurredAt:  time.Now(),
+	})
+}
+
+// AssignCategories は書籍に割り当てるカテゴリを、categoryIdsで置き換えます。空の場合は全ての割り当てを解除します。
+// カテゴリがMaxCategoriesを超える場合や、同じカテゴリが重複する場合はエラーを返します。
+// 参照先のカテゴリが存在するかどうかは検証しません（service.BookReferenceCheckDomainServiceで検証します）。
+func (b *Book) AssignCategories(categoryIds []*category.CategoryId) error {
+	if len(categoryIds) > MaxCategories {
+		return shared.NewDomainError(shared.KindInvalid, i18n.MsgCategoriesTooMany, MaxCategories)
+	}
+	seen := make(map[string]bool, len(categoryIds))
+	for _, id := range categoryIds {
+		if seen[id.Value()] {
+			return shared.NewDomainError(shared.KindInvalid, i18n.MsgCategoriesDuplicate, id.Value())
+		}
+		seen[id.Value()] = true
+	}
+
+	b.categoryIds = append([]*category.CategoryId(nil), categoryIds...)
+	ids := make([]string, len(categoryIds))
+	for i, id := range categoryIds {
+		ids[i] = id.Value()
+	}
+	b.AddEvent(&BookCategoriesChanged{
+		BookId:      b.bookId.Value(),
+		CategoryIds: ids,
+		OccurredAt:  time.Now(),
+	})
+	return nil
 }
 
 // IncreaseStock は在庫数を増加させます。
 func (b *Book) IncreaseStock(amount int) error {
-	return b.stock.IncreaseQuantity(amount)
+	if err := b.stock.IncreaseQuantity(amount); err != nil {
+		return err
+	}
+	b.addStockQuantityChanged(amount)
+	return nil
 }
 
 // DecreaseStock は在庫数を減少させます。
 func (b *Book) DecreaseStock(amount int) error {
-	return b.stock.DecreaseQuantity(amount)
+	if err := b.stock.DecreaseQuantity(amount); err != nil {
+		return err
+	}
+	b.addStockQuantityChanged(-amount)
+	return nil
+}
+
+// Delete は書籍を削除対象としてマークし、BookDeletedイベントを記録します。
+// 実際の削除はリポジトリで行います。
+func (b *Book) Delete() {
+	b.AddEvent(&BookDeleted{
+		BookId:     b.bookId.Value(),
+		OccurredAt: time.Now(),
+	})
+}
+
+func (b *Book) addStockQuantityChanged(delta int) {
+	b.AddEvent(&StockQuantityChanged{
+		BookId:            b.bookId.Value(),
+		Delta:             delta,
+		QuantityAvailable: b.stock.QuantityAvailable().Value(),
+		Status:            b.stock.Status().Value().String(),
+		OccurredAt:        time.Now(),
+	})
 }
 
 // IsSaleable は書籍が販売可能かどうかを判定します。
@@ -97,6 +187,16 @@ func (b *Book) Price() *price.Price {
 	return b.price
 }
 
+// Metadata は書誌情報を返します。nilになることはありません。
+func (b *Book) Metadata() *Metadata {
+	return b.metadata
+}
+
+// CategoryIds は割り当てられたカテゴリのIDを返します。カテゴリが割り当てられていない場合は空です。
+func (b *Book) CategoryIds() []*category.CategoryId {
+	return b.categoryIds
+}
+
 // Stock は在庫エンティティを返します。
 func (b *Book) Stock() *stock.Stock {
 	return b.stock
@@ -107,6 +207,11 @@ func (b *Book) AddEvent(event shared.DomainEvent) {
 	b.events = append(b.events, event)
 }
 
+// Events はまだ取り出されていないドメインイベントを返します。PullEventsと異なり内部リストはクリアしません。
+func (b *Book) Events() []shared.DomainEvent {
+	return b.events
+}
+
 // PullEvents は記録された全てのドメインイベントを取得し、内部リストをクリアします。
 func (b *Book) PullEvents() []shared.DomainEvent {
 	events := b.events
@@ -116,6 +221,7 @@ func (b *Book) PullEvents() []shared.DomainEvent {
 
 // BookCreated は書籍作成イベントです。
 type BookCreated struct {
+	shared.EventMetadata
 	BookId     string
 	Title      string
 	OccurredAt time.Time
@@ -128,3 +234,85 @@ func (e *BookCreated) EventName() string {
 func (e *BookCreated) OccurredOn() time.Time {
 	return e.OccurredAt
 }
+
+// BookTitleChanged は書籍タイトル変更イベントです。
+type BookTitleChanged struct {
+	shared.EventMetadata
+	BookId     string
+	Title      string
+	OccurredAt time.Time
+}
+
+func (e *BookTitleChanged) EventName() string {
+	return "BookTitleChanged"
+}
+
+func (e *BookTitleChanged) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// BookPriceChanged は書籍価格変更イベントです。
+type BookPriceChanged struct {
+	shared.EventMetadata
+	BookId      string
+	PriceAmount float64
+	OccurredAt  time.Time
+}
+
+func (e *BookPriceChanged) EventName() string {
+	return "BookPriceChanged"
+}
+
+func (e *BookPriceChanged) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// BookCategoriesChanged は書籍のカテゴリの割り当て変更イベントです。CategoryIdsは変更後の全てのカテゴリです。
+type BookCategoriesChanged struct {
+	shared.EventMetadata
+	BookId      string
+	CategoryIds []string
+	OccurredAt  time.Time
+}
+
+func (e *BookCategoriesChanged) EventName() string {
+	return "BookCategoriesChanged"
+}
+
+func (e *BookCategoriesChanged) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// StockQuantityChanged は在庫数変更イベントです。
+// Deltaは変更量で、入庫は正、出庫は負の値です。
+type StockQuantityChanged struct {
+	shared.EventMetadata
+	BookId            string
+	Delta             int
+	QuantityAvailable int
+	Status            string
+	OccurredAt        time.Time
+}
+
+func (e *StockQuantityChanged) EventName() string {
+	return "StockQuantityChanged"
+}
+
+func (e *StockQuantityChanged) OccurredOn() time.Time {
+	return e.OccurredAt
+}
+
+// BookDeleted は書籍削除イベントです。
+type BookDeleted struct {
+	shared.EventMetadata
+	BookId     string
+	OccurredAt time.Time
+}
+
+func (e *BookDeleted) EventName() string {
+	return "BookDeleted"
+}
+
+func (e *BookDeleted) OccurredOn() time.Time {
+	return e.OccurredAt
+}
@@ -1,6 +1,9 @@
 package book
 
-import "fmt"
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
 
 // BookId は書籍のIDを表す値オブジェクトです。
 type BookId struct {
@@ -11,7 +14,7 @@ type BookId struct {
 // 値が空の場合はエラーを返します。
 func NewBookId(value string) (*BookId, error) {
 	if value == "" {
-		return nil, fmt.Errorf("BookIdの生成に失敗しました: ISBNは必須です")
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgBookIdRequired)
 	}
 	// TODO: 必要であればISBNのフォーマット検証を追加する
 	return &BookId{value: value}, nil
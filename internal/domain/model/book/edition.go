@@ -0,0 +1,28 @@
+package book
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// MaxEdition は版の上限です。
+const MaxEdition = 999
+
+// Edition は書籍の版（初版は1）を表す値オブジェクトです。
+type Edition struct {
+	value int
+}
+
+// NewEdition は新しいEditionを生成します。
+// 値が1未満、またはMaxEditionを超える場合はエラーを返します。
+func NewEdition(value int) (*Edition, error) {
+	if value < 1 || value > MaxEdition {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgEditionOutOfRange, MaxEdition)
+	}
+	return &Edition{value: value}, nil
+}
+
+// Value は版を返します。
+func (e *Edition) Value() int {
+	return e.value
+}
@@ -0,0 +1,34 @@
+package book
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// Format は書籍の形態を表す型です。
+type Format string
+
+const (
+	// Hardcover はハードカバー（上製本）を表します。
+	Hardcover Format = "HARDCOVER"
+	// Paperback はペーパーバック（並製本、文庫・新書を含む）を表します。
+	Paperback Format = "PAPERBACK"
+	// Ebook は電子書籍を表します。
+	Ebook Format = "EBOOK"
+)
+
+// NewFormat は文字列からFormatを生成します。
+// HARDCOVER、PAPERBACK、EBOOKのいずれでもない場合はエラーを返します。
+func NewFormat(value string) (Format, error) {
+	switch f := Format(value); f {
+	case Hardcover, Paperback, Ebook:
+		return f, nil
+	default:
+		return "", shared.NewDomainError(shared.KindInvalid, i18n.MsgFormatInvalid, value)
+	}
+}
+
+// String はFormatの文字列表現を返します。
+func (f Format) String() string {
+	return string(f)
+}
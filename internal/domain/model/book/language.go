@@ -0,0 +1,34 @@
+package book
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+
+	"golang.org/x/text/language"
+)
+
+// Language は書籍の本文の言語を表す値オブジェクトです。
+// ISO 639の言語コードを、2文字のコードがある言語は2文字（jpn→ja）に正規化して保持します。
+type Language struct {
+	base language.Base
+}
+
+// NewLanguage はISO 639-1（2文字）またはISO 639-2/3（3文字）の言語コードから新しいLanguageを生成します。
+// 大文字・小文字は区別しません。未知の言語コードや「und」（未確定）の場合はエラーを返します。
+func NewLanguage(code string) (*Language, error) {
+	base, err := language.ParseBase(code)
+	if err != nil || base.String() == "und" {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgLanguageInvalid, code)
+	}
+	return &Language{base: base}, nil
+}
+
+// Code は正規化した言語コード（ja、enなど）を返します。
+func (l *Language) Code() string {
+	return l.base.String()
+}
+
+// ISO3 はISO 639-3の3文字の言語コード（jpn、engなど）を返します。
+func (l *Language) ISO3() string {
+	return l.base.ISO3()
+}
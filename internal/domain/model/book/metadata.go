@@ -0,0 +1,140 @@
+package book
+
+import (
+	"ddd-hands-on-go/internal/domain/model/author"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// MaxAuthors は1冊の書籍に記録できる著者の上限です。
+const MaxAuthors = 50
+
+// Metadata は書籍の書誌情報（著者、出版社、出版日、言語、ページ数、形態、版）を表す値オブジェクトです。
+// いずれの項目も省略でき、省略した項目は不明として扱います。著者と出版社は別の集約のため、IDで参照します。
+type Metadata struct {
+	authorIds       []*author.AuthorId
+	publisherId     *publisher.PublisherId
+	publicationDate *PublicationDate
+	language        *Language
+	pageCount       *PageCount
+	format          Format
+	edition         *Edition
+}
+
+// NewMetadata は新しいMetadataを生成します。不明な項目にはnil（formatは空文字列）を指定します。
+// 著者はauthorIdsの順（筆頭著者が先頭）に保持します。著者がMaxAuthorsを超える場合や、同じ著者が重複する場合はエラーを返します。
+// 参照先の著者・出版社が存在するかどうかは検証しません（service.BookReferenceCheckDomainServiceで検証します）。
+func NewMetadata(
+	authorIds []*author.AuthorId,
+	publisherId *publisher.PublisherId,
+	publicationDate *PublicationDate,
+	language *Language,
+	pageCount *PageCount,
+	format Format,
+	edition *Edition,
+) (*Metadata, error) {
+	if len(authorIds) > MaxAuthors {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgAuthorsTooMany, MaxAuthors)
+	}
+	seen := make(map[string]bool, len(authorIds))
+	for _, id := range authorIds {
+		if seen[id.Value()] {
+			return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgAuthorsDuplicate, id.Value())
+		}
+		seen[id.Value()] = true
+	}
+
+	return &Metadata{
+		authorIds:       append([]*author.AuthorId(nil), authorIds...),
+		publisherId:     publisherId,
+		publicationDate: publicationDate,
+		language:        language,
+		pageCount:       pageCount,
+		format:          format,
+		edition:         edition,
+	}, nil
+}
+
+// AuthorIds は著者のIDを記載順に返します。著者が不明な場合は空です。
+func (m *Metadata) AuthorIds() []*author.AuthorId {
+	return append([]*author.AuthorId(nil), m.authorIds...)
+}
+
+// PublisherId は出版社のIDを返します。不明な場合はnilです。
+func (m *Metadata) PublisherId() *publisher.PublisherId {
+	return m.publisherId
+}
+
+// PublicationDate は出版日を返します。不明な場合はnilです。
+func (m *Metadata) PublicationDate() *PublicationDate {
+	return m.publicationDate
+}
+
+// Language は言語を返します。不明な場合はnilです。
+func (m *Metadata) Language() *Language {
+	return m.language
+}
+
+// PageCount はページ数を返します。不明な場合はnilです。
+func (m *Metadata) PageCount() *PageCount {
+	return m.pageCount
+}
+
+// Format は形態を返します。不明な場合は空文字列です。
+func (m *Metadata) Format() Format {
+	return m.format
+}
+
+// Edition は版を返します。不明な場合はnilです。
+func (m *Metadata) Edition() *Edition {
+	return m.edition
+}
+
+// BuildMetadata は各項目の値から値オブジェクトを生成し、Metadataを生成します。
+// 空の項目（数値は0）は不明とします。いずれかの値が不正な場合はその値オブジェクトのエラーを返します。
+func BuildMetadata(authorIds []string, publisherId, publicationDate, language string, pageCount int, format string, edition int) (*Metadata, error) {
+	m := &Metadata{}
+	var err error
+
+	ids := make([]*author.AuthorId, 0, len(authorIds))
+	for _, value := range authorIds {
+		id, err := author.NewAuthorId(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if publisherId != "" {
+		if m.publisherId, err = publisher.NewPublisherId(publisherId); err != nil {
+			return nil, err
+		}
+	}
+	if publicationDate != "" {
+		if m.publicationDate, err = NewPublicationDate(publicationDate); err != nil {
+			return nil, err
+		}
+	}
+	if language != "" {
+		if m.language, err = NewLanguage(language); err != nil {
+			return nil, err
+		}
+	}
+	if pageCount != 0 {
+		if m.pageCount, err = NewPageCount(pageCount); err != nil {
+			return nil, err
+		}
+	}
+	if format != "" {
+		if m.format, err = NewFormat(format); err != nil {
+			return nil, err
+		}
+	}
+	if edition != 0 {
+		if m.edition, err = NewEdition(edition); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewMetadata(ids, m.publisherId, m.publicationDate, m.language, m.pageCount, m.format, m.edition)
+}
@@ -0,0 +1,28 @@
+package book
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// MaxPageCount はページ数の上限です。
+const MaxPageCount = 100000
+
+// PageCount は書籍のページ数を表す値オブジェクトです。
+type PageCount struct {
+	value int
+}
+
+// NewPageCount は新しいPageCountを生成します。
+// 値が1未満、またはMaxPageCountを超える場合はエラーを返します。
+func NewPageCount(value int) (*PageCount, error) {
+	if value < 1 || value > MaxPageCount {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgPageCountOutOfRange, MaxPageCount)
+	}
+	return &PageCount{value: value}, nil
+}
+
+// Value はページ数を返します。
+func (p *PageCount) Value() int {
+	return p.value
+}
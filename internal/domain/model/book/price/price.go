@@ -1,6 +1,9 @@
 package price
 
-import "fmt"
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
 
 // Currency は通貨を表す型です。
 type Currency string
@@ -20,10 +23,10 @@ type Price struct {
 // 金額が負の値の場合、または通貨がJPY以外の場合はエラーを返します。
 func NewPrice(amount float64, currency Currency) (*Price, error) {
 	if amount < 0 {
-		return nil, fmt.Errorf("Priceの生成に失敗しました: 金額は0以上である必要があります")
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgPriceNegative)
 	}
 	if currency != JPY {
-		return nil, fmt.Errorf("Priceの生成に失敗しました: 通貨はJPYのみサポートされています")
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgPriceUnsupportedCurrency)
 	}
 	return &Price{amount: amount, currency: currency}, nil
 }
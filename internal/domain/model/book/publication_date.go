@@ -0,0 +1,35 @@
+package book
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"time"
+)
+
+// PublicationDateLayout は出版日の文字列表現（YYYY-MM-DD）のレイアウトです。
+const PublicationDateLayout = "2006-01-02"
+
+// PublicationDate は書籍の出版日（発売日）を表す値オブジェクトです。予約販売のため、未来の日付も許容します。
+type PublicationDate struct {
+	value time.Time
+}
+
+// NewPublicationDate はYYYY-MM-DD形式の文字列から新しいPublicationDateを生成します。
+// 形式が不正な場合や、存在しない日付の場合はエラーを返します。
+func NewPublicationDate(value string) (*PublicationDate, error) {
+	t, err := time.Parse(PublicationDateLayout, value)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgPublicationDateInvalid, value)
+	}
+	return &PublicationDate{value: t}, nil
+}
+
+// Value は出版日をUTCの0時として返します。
+func (d *PublicationDate) Value() time.Time {
+	return d.value
+}
+
+// String は出版日をYYYY-MM-DD形式で返します。
+func (d *PublicationDate) String() string {
+	return d.value.Format(PublicationDateLayout)
+}
@@ -1,6 +1,9 @@
 package quantity_available
 
-import "fmt"
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
 
 // QuantityAvailable は在庫数を表す値オブジェクトです。
 type QuantityAvailable struct {
@@ -11,7 +14,7 @@ type QuantityAvailable struct {
 // 数値が負の場合はエラーを返します。
 func NewQuantityAvailable(value int) (*QuantityAvailable, error) {
 	if value < 0 {
-		return nil, fmt.Errorf("QuantityAvailableの生成に失敗しました: 数値は0以上である必要があります")
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgQuantityNegative)
 	}
 	return &QuantityAvailable{value: value}, nil
 }
@@ -24,7 +27,7 @@ func (q *QuantityAvailable) Value() int {
 // Increment は在庫数を増加させた新しいQuantityAvailableを返します。
 func (q *QuantityAvailable) Increment(amount int) (*QuantityAvailable, error) {
 	if amount < 0 {
-		return nil, fmt.Errorf("負の値で増加させることはできません")
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgQuantityNegativeIncrease)
 	}
 	return NewQuantityAvailable(q.value + amount)
 }
@@ -33,10 +36,10 @@ func (q *QuantityAvailable) Increment(amount int) (*QuantityAvailable, error) {
 // 在庫不足になる場合はエラーを返します。
 func (q *QuantityAvailable) Decrement(amount int) (*QuantityAvailable, error) {
 	if amount < 0 {
-		return nil, fmt.Errorf("負の値で減少させることはできません")
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgQuantityNegativeDecrease)
 	}
 	if q.value < amount {
-		return nil, fmt.Errorf("在庫が不足しています")
+		return nil, shared.NewDomainError(shared.KindConflict, i18n.MsgStockInsufficient)
 	}
 	return NewQuantityAvailable(q.value - amount)
 }
@@ -1,6 +1,9 @@
 package stock_id
 
-import "fmt"
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
 
 // StockId は在庫IDを表す値オブジェクトです。
 type StockId struct {
@@ -11,7 +14,7 @@ type StockId struct {
 // 値が空の場合はエラーを返します。
 func NewStockId(value string) (*StockId, error) {
 	if value == "" {
-		return nil, fmt.Errorf("StockIdの生成に失敗しました: IDは必須です")
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgStockIdRequired)
 	}
 	return &StockId{value: value}, nil
 }
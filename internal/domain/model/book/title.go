@@ -1,22 +1,104 @@
 package book
 
-import "fmt"
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"strings"
+	"unicode/utf8"
 
-// Title は書籍のタイトルを表す値オブジェクトです。
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MaxTitleLength はタイトル（主題）の最大文字数（ルーン数）です。
+	MaxTitleLength = 255
+	// MaxSubtitleLength はサブタイトル（副題）の最大文字数（ルーン数）です。
+	MaxSubtitleLength = 255
+)
+
+// Title は書籍のタイトルを表す値オブジェクトです。主題と、省略可能な副題からなります。
+// 値は前後の空白を取り除き、Unicode正規化形式C（NFC）に正規化して保持します。
 type Title struct {
-	value string
+	value    string
+	subtitle string
 }
 
-// NewTitle は新しいTitleを生成します。
-// 値が空の場合はエラーを返します。
+// NewTitle は副題のない新しいTitleを生成します。
+// 値が空の場合、MaxTitleLengthを超える場合、制御文字を含む場合はエラーを返します。
 func NewTitle(value string) (*Title, error) {
+	return NewTitleWithSubtitle(value, "")
+}
+
+// NewTitleWithSubtitle は主題と副題からなる新しいTitleを生成します。副題は空でも構いません。
+func NewTitleWithSubtitle(value, subtitle string) (*Title, error) {
+	value = shared.NormalizeText(value)
 	if value == "" {
-		return nil, fmt.Errorf("Titleの生成に失敗しました: タイトルは必須です")
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgTitleRequired)
+	}
+	if utf8.RuneCountInString(value) > MaxTitleLength {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgTitleTooLong, MaxTitleLength)
+	}
+	if shared.ContainsControl(value) {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgTitleControlCharacter)
 	}
-	return &Title{value: value}, nil
+
+	subtitle = shared.NormalizeText(subtitle)
+	if utf8.RuneCountInString(subtitle) > MaxSubtitleLength {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgSubtitleTooLong, MaxSubtitleLength)
+	}
+	if shared.ContainsControl(subtitle) {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgSubtitleControlCharacter)
+	}
+
+	return &Title{value: value, subtitle: subtitle}, nil
 }
 
-// Value はTitleの値を返します。
+// Value はタイトル（主題）を返します。
 func (t *Title) Value() string {
 	return t.value
 }
+
+// Subtitle は副題を返します。副題がない場合は空文字列です。
+func (t *Title) Subtitle() string {
+	return t.subtitle
+}
+
+// CollationKey はタイトル順に並べるための照合キーを返します。キーをバイト順に比較すると、次の規則でタイトル順になります。
+//   - 全角英数字と半角英数字、全角カナと半角カナ、ひらがなとカタカナ、大文字と小文字を区別しない
+//   - 連続する空白は1つの空白とみなす
+//   - 英語の先頭の冠詞（The、A、An）を無視する
+//   - 主題が同じ場合は副題の順とし、副題のないものを先にする
+func (t *Title) CollationKey() string {
+	key := collationKey(t.value)
+	if t.subtitle != "" {
+		// 区切りには主題の照合キーに現れず、どの文字よりも前に並ぶ文字を使用する
+		key += "\x01" + collationKey(t.subtitle)
+	}
+	return key
+}
+
+// leadingArticles は照合キーで無視する先頭の冠詞です（小文字にした後の値）。
+var leadingArticles = []string{"the ", "an ", "a "}
+
+// collationKey は文字列の照合キーを生成します。
+// データベースに保存済みの照合キーはマイグレーションで同じ規則により生成しているため、規則を変更する場合はマイグレーションも追加してください。
+func collationKey(s string) string {
+	// NFKCで全角英数字を半角に、半角カナを全角に揃える
+	s = strings.ToLower(norm.NFKC.String(s))
+	s = strings.Map(katakanaToHiragana, s)
+	s = strings.Join(strings.Fields(s), " ")
+	for _, article := range leadingArticles {
+		if rest, ok := strings.CutPrefix(s, article); ok && rest != "" {
+			return rest
+		}
+	}
+	return s
+}
+
+// katakanaToHiragana はカタカナ（ァ〜ヶ）を対応するひらがなに変換します。
+func katakanaToHiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - ('ァ' - 'ぁ')
+	}
+	return r
+}
@@ -0,0 +1,55 @@
+package category
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// Category は書籍の分類（ジャンル）を表す集約ルートです。
+// カテゴリは親カテゴリのIDを持つ木構造で、親がないカテゴリは最上位のカテゴリです。
+// 親カテゴリが存在すること、親子関係が循環しないことはservice.CategoryHierarchyDomainServiceで検証します。
+type Category struct {
+	categoryId *CategoryId
+	name       *Name
+	parentId   *CategoryId
+}
+
+// NewCategory は新しいCategoryを生成します。parentIdがnilの場合は最上位のカテゴリです。DBなどから復元する際にも使用します。
+// 自身を親に指定した場合はエラーを返します。
+func NewCategory(categoryId *CategoryId, name *Name, parentId *CategoryId) (*Category, error) {
+	c := &Category{categoryId: categoryId, name: name}
+	if err := c.MoveTo(parentId); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Rename はカテゴリ名を変更します。
+func (c *Category) Rename(newName *Name) {
+	c.name = newName
+}
+
+// MoveTo は親カテゴリを変更します。parentIdがnilの場合は最上位のカテゴリにします。
+// 子孫のカテゴリは、このカテゴリとともに移動します。自身を親に指定した場合はエラーを返します。
+func (c *Category) MoveTo(parentId *CategoryId) error {
+	if c.categoryId.Equals(parentId) {
+		return shared.NewDomainError(shared.KindInvalid, i18n.MsgCategoryCyclicParent, c.categoryId.Value())
+	}
+	c.parentId = parentId
+	return nil
+}
+
+// CategoryId はカテゴリIDを返します。
+func (c *Category) CategoryId() *CategoryId {
+	return c.categoryId
+}
+
+// Name はカテゴリ名を返します。
+func (c *Category) Name() *Name {
+	return c.name
+}
+
+// ParentId は親カテゴリのIDを返します。最上位のカテゴリの場合はnilです。
+func (c *Category) ParentId() *CategoryId {
+	return c.parentId
+}
@@ -0,0 +1,40 @@
+package category
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+
+	"github.com/google/uuid"
+)
+
+// CategoryId はカテゴリのIDを表す値オブジェクトです。UUIDを小文字のハイフン区切りの形式で保持します。
+type CategoryId struct {
+	value string
+}
+
+// NewCategoryId は文字列から新しいCategoryIdを生成します。
+// 値がUUIDとして不正な場合はエラーを返します。
+func NewCategoryId(value string) (*CategoryId, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgCategoryIdInvalid, value)
+	}
+	return &CategoryId{value: id.String()}, nil
+}
+
+// GenerateCategoryId はランダムな（UUIDv4の）新しいCategoryIdを生成します。
+func GenerateCategoryId() *CategoryId {
+	return &CategoryId{value: uuid.NewString()}
+}
+
+// Value はCategoryIdの値を返します。
+func (id *CategoryId) Value() string {
+	return id.value
+}
+
+func (id *CategoryId) Equals(other *CategoryId) bool {
+	if other == nil {
+		return false
+	}
+	return id.value == other.value
+}
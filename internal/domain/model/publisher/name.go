@@ -0,0 +1,37 @@
+package publisher
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"unicode/utf8"
+)
+
+// MaxNameLength は出版社名の最大文字数（ルーン数）です。
+const MaxNameLength = 255
+
+// Name は出版社名を表す値オブジェクトです。
+// 名前は前後の空白を取り除き、NFCに正規化して保持します。
+type Name struct {
+	value string
+}
+
+// NewName は新しいNameを生成します。
+// 名前が空の場合、MaxNameLengthを超える場合、制御文字を含む場合はエラーを返します。
+func NewName(value string) (*Name, error) {
+	value = shared.NormalizeText(value)
+	if value == "" {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgPublisherRequired)
+	}
+	if utf8.RuneCountInString(value) > MaxNameLength {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgPublisherTooLong, MaxNameLength)
+	}
+	if shared.ContainsControl(value) {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgPublisherControlCharacter)
+	}
+	return &Name{value: value}, nil
+}
+
+// Value は出版社名を返します。
+func (n *Name) Value() string {
+	return n.value
+}
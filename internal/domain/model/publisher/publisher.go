@@ -0,0 +1,28 @@
+package publisher
+
+// Publisher は出版社を表す集約ルートです。
+// 書籍はPublisherIdで出版社を参照するため、同じ出版社の表記の揺れを防ぎ、出版社名の変更を全ての書籍へ反映できます。
+type Publisher struct {
+	publisherId *PublisherId
+	name        *Name
+}
+
+// NewPublisher は新しいPublisherを生成します。DBなどから復元する際にも使用します。
+func NewPublisher(publisherId *PublisherId, name *Name) *Publisher {
+	return &Publisher{publisherId: publisherId, name: name}
+}
+
+// Rename は出版社名を変更します。
+func (p *Publisher) Rename(newName *Name) {
+	p.name = newName
+}
+
+// PublisherId は出版社IDを返します。
+func (p *Publisher) PublisherId() *PublisherId {
+	return p.publisherId
+}
+
+// Name は出版社名を返します。
+func (p *Publisher) Name() *Name {
+	return p.name
+}
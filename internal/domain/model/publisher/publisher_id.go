@@ -0,0 +1,40 @@
+package publisher
+
+import (
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+
+	"github.com/google/uuid"
+)
+
+// PublisherId は出版社のIDを表す値オブジェクトです。UUIDを小文字のハイフン区切りの形式で保持します。
+type PublisherId struct {
+	value string
+}
+
+// NewPublisherId は文字列から新しいPublisherIdを生成します。
+// 値がUUIDとして不正な場合はエラーを返します。
+func NewPublisherId(value string) (*PublisherId, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgPublisherIdInvalid, value)
+	}
+	return &PublisherId{value: id.String()}, nil
+}
+
+// GeneratePublisherId はランダムな（UUIDv4の）新しいPublisherIdを生成します。
+func GeneratePublisherId() *PublisherId {
+	return &PublisherId{value: uuid.NewString()}
+}
+
+// Value はPublisherIdの値を返します。
+func (id *PublisherId) Value() string {
+	return id.value
+}
+
+func (id *PublisherId) Equals(other *PublisherId) bool {
+	if other == nil {
+		return false
+	}
+	return id.value == other.value
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/author"
+)
+
+// AuthorRepository は著者エンティティの永続化を担当するリポジトリインターフェースです。
+type AuthorRepository interface {
+	// Save は著者を保存します。
+	Save(ctx context.Context, author *author.Author) error
+	// Find は指定されたIDの著者を検索して返します。見つからない場合はnilを返します。
+	Find(ctx context.Context, authorId *author.AuthorId) (*author.Author, error)
+	// FindMany は指定された複数のIDの著者をまとめて検索して返します。
+	// 見つからないIDは結果に含まれず、結果の順序は保証されません。
+	FindMany(ctx context.Context, authorIds []*author.AuthorId) ([]*author.Author, error)
+	// Delete は指定されたIDの著者を削除します。
+	// 著者を参照している書籍がある場合は削除せず、KindConflictのエラーを返します。
+	Delete(ctx context.Context, authorId *author.AuthorId) error
+}
@@ -11,6 +11,12 @@ type BookRepository interface {
 	Save(ctx context.Context, book *book.Book) error
 	// Find は指定されたIDの書籍を検索して返します。
 	Find(ctx context.Context, bookId *book.BookId) (*book.Book, error)
+	// FindForUpdate は指定されたIDの書籍を検索して返し、トランザクションの終了まで同じ書籍の在庫を更新する他のトランザクションを待機させます。
+	// 在庫数のように、読み取った値をもとに計算した値を保存する場合に、同時の更新が失われないようトランザクション内で使用します。
+	FindForUpdate(ctx context.Context, bookId *book.BookId) (*book.Book, error)
+	// FindMany は指定された複数のIDの書籍をまとめて検索して返します。
+	// 見つからないIDは結果に含まれず、結果の順序は保証されません。
+	FindMany(ctx context.Context, bookIds []*book.BookId) ([]*book.Book, error)
 	// Delete は指定されたIDの書籍を削除します。
 	Delete(ctx context.Context, bookId *book.BookId) error
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/category"
+)
+
+// CategoryRepository はカテゴリエンティティの永続化を担当するリポジトリインターフェースです。
+type CategoryRepository interface {
+	// Save はカテゴリを保存します。
+	Save(ctx context.Context, category *category.Category) error
+	// Find は指定されたIDのカテゴリを検索して返します。見つからない場合はnilを返します。
+	Find(ctx context.Context, categoryId *category.CategoryId) (*category.Category, error)
+	// FindMany は指定された複数のIDのカテゴリをまとめて検索して返します。
+	// 見つからないIDは結果に含まれず、結果の順序は保証されません。
+	FindMany(ctx context.Context, categoryIds []*category.CategoryId) ([]*category.Category, error)
+	// Delete は指定されたIDのカテゴリを削除します。
+	// 子カテゴリがある場合、またはカテゴリが書籍に割り当てられている場合は削除せず、KindConflictのエラーを返します。
+	Delete(ctx context.Context, categoryId *category.CategoryId) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+)
+
+// PublisherRepository は出版社エンティティの永続化を担当するリポジトリインターフェースです。
+type PublisherRepository interface {
+	// Save は出版社を保存します。
+	Save(ctx context.Context, publisher *publisher.Publisher) error
+	// Find は指定されたIDの出版社を検索して返します。見つからない場合はnilを返します。
+	Find(ctx context.Context, publisherId *publisher.PublisherId) (*publisher.Publisher, error)
+	// FindMany は指定された複数のIDの出版社をまとめて検索して返します。
+	// 見つからないIDは結果に含まれず、結果の順序は保証されません。
+	FindMany(ctx context.Context, publisherIds []*publisher.PublisherId) ([]*publisher.Publisher, error)
+	// Delete は指定されたIDの出版社を削除します。
+	// 出版社を参照している書籍がある場合は削除せず、KindConflictのエラーを返します。
+	Delete(ctx context.Context, publisherId *publisher.PublisherId) error
+}
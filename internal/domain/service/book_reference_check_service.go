@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// BookReferenceCheckDomainService は書籍が参照する著者・出版社・カテゴリが存在するかどうかを検証するドメインサービスです。
+type BookReferenceCheckDomainService struct {
+	authorRepository    repository.AuthorRepository
+	publisherRepository repository.PublisherRepository
+	categoryRepository  repository.CategoryRepository
+}
+
+// NewBookReferenceCheckDomainService は新しいBookReferenceCheckDomainServiceを生成します。
+func NewBookReferenceCheckDomainService(
+	authorRepository repository.AuthorRepository,
+	publisherRepository repository.PublisherRepository,
+	categoryRepository repository.CategoryRepository,
+) *BookReferenceCheckDomainService {
+	return &BookReferenceCheckDomainService{
+		authorRepository:    authorRepository,
+		publisherRepository: publisherRepository,
+		categoryRepository:  categoryRepository,
+	}
+}
+
+// Execute は書誌情報が参照する全ての著者と出版社が存在することを検証します。
+// 存在しない著者・出版社がある場合は、最初に見つかったものについてKindInvalidのエラーを返します。
+func (s *BookReferenceCheckDomainService) Execute(ctx context.Context, metadata *book.Metadata) error {
+	authorIds := metadata.AuthorIds()
+	if len(authorIds) > 0 {
+		// 著者の問い合わせは1回で行う
+		found, err := s.authorRepository.FindMany(ctx, authorIds)
+		if err != nil {
+			return err
+		}
+		exists := make(map[string]bool, len(found))
+		for _, a := range found {
+			exists[a.AuthorId().Value()] = true
+		}
+		for _, id := range authorIds {
+			if !exists[id.Value()] {
+				return shared.NewDomainError(shared.KindInvalid, i18n.MsgAuthorNotFound, id.Value())
+			}
+		}
+	}
+
+	if publisherId := metadata.PublisherId(); publisherId != nil {
+		found, err := s.publisherRepository.Find(ctx, publisherId)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return shared.NewDomainError(shared.KindInvalid, i18n.MsgPublisherNotFound, publisherId.Value())
+		}
+	}
+	return nil
+}
+
+// CheckCategories は書籍に割り当てる全てのカテゴリが存在することを検証します。
+// 存在しないカテゴリがある場合は、最初に見つかったものについてKindInvalidのエラーを返します。
+func (s *BookReferenceCheckDomainService) CheckCategories(ctx context.Context, categoryIds []*category.CategoryId) error {
+	if len(categoryIds) == 0 {
+		return nil
+	}
+	found, err := s.categoryRepository.FindMany(ctx, categoryIds)
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(found))
+	for _, c := range found {
+		exists[c.CategoryId().Value()] = true
+	}
+	for _, id := range categoryIds {
+		if !exists[id.Value()] {
+			return shared.NewDomainError(shared.KindInvalid, i18n.MsgCategoryNotFound, id.Value())
+		}
+	}
+	return nil
+}
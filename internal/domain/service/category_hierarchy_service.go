@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/repository"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// CategoryHierarchyDomainService はカテゴリの親子関係（木構造）が正しいかどうかを検証するドメインサービスです。
+type CategoryHierarchyDomainService struct {
+	categoryRepository repository.CategoryRepository
+}
+
+// NewCategoryHierarchyDomainService は新しいCategoryHierarchyDomainServiceを生成します。
+func NewCategoryHierarchyDomainService(categoryRepository repository.CategoryRepository) *CategoryHierarchyDomainService {
+	return &CategoryHierarchyDomainService{categoryRepository: categoryRepository}
+}
+
+// Execute はカテゴリcの親カテゴリが存在し、親子関係が循環しないこと（親カテゴリがc自身やその子孫でないこと）を検証します。
+// 親カテゴリが存在しない場合、循環する場合はKindInvalidのエラーを返します。cが最上位のカテゴリの場合は検証しません。
+func (s *CategoryHierarchyDomainService) Execute(ctx context.Context, c *category.Category) error {
+	parentId := c.ParentId()
+	if parentId == nil {
+		return nil
+	}
+	ancestor, err := s.categoryRepository.Find(ctx, parentId)
+	if err != nil {
+		return err
+	}
+	if ancestor == nil {
+		return shared.NewDomainError(shared.KindInvalid, i18n.MsgCategoryParentNotFound, parentId.Value())
+	}
+
+	// 親から最上位のカテゴリまで祖先をたどり、c自身が現れないことを確認する
+	for {
+		if ancestor.CategoryId().Equals(c.CategoryId()) {
+			return shared.NewDomainError(shared.KindInvalid, i18n.MsgCategoryCyclicParent, c.CategoryId().Value())
+		}
+		nextId := ancestor.ParentId()
+		if nextId == nil {
+			return nil
+		}
+		if ancestor, err = s.categoryRepository.Find(ctx, nextId); err != nil {
+			return err
+		}
+		if ancestor == nil {
+			// 保存済みのカテゴリの親は必ず存在するため、途中で途切れる場合はデータの不整合
+			return shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "parentId")
+		}
+	}
+}
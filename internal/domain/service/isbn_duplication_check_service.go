@@ -27,3 +27,18 @@ func (s *ISBNDuplicationCheckDomainService) Execute(ctx context.Context, isbn *b
 	}
 	return foundBook != nil, nil
 }
+
+// ExecuteBatch は複数のISBNについて既に使用されているかどうかをまとめてチェックし、
+// 使用済みのISBNの集合を返します。リポジトリへの問い合わせは1回で行います。
+func (s *ISBNDuplicationCheckDomainService) ExecuteBatch(ctx context.Context, isbns []*book.BookId) (map[string]bool, error) {
+	foundBooks, err := s.bookRepository.FindMany(ctx, isbns)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := make(map[string]bool, len(foundBooks))
+	for _, b := range foundBooks {
+		duplicates[b.BookId().Value()] = true
+	}
+	return duplicates, nil
+}
@@ -9,6 +9,56 @@ type DomainEvent interface {
 	OccurredOn() time.Time
 }
 
+// EventMetadata はドメインイベントに付与する、ドメインの関心事ではない付帯情報です。
+// 各イベントに埋め込むことでCorrelatedEventを実装します。
+type EventMetadata struct {
+	correlationID string
+	traceContext  map[string]string
+	actor         string
+}
+
+// CorrelationID はイベントの発生元のリクエストを識別する相関IDを返します。
+func (m *EventMetadata) CorrelationID() string {
+	return m.correlationID
+}
+
+// SetCorrelationID は相関IDを設定します。
+func (m *EventMetadata) SetCorrelationID(id string) {
+	m.correlationID = id
+}
+
+// TraceContext はイベントの発生元のトレースコンテキスト（W3C Trace Contextのtraceparentなど）を返します。
+func (m *EventMetadata) TraceContext() map[string]string {
+	return m.traceContext
+}
+
+// SetTraceContext はトレースコンテキストを設定します。
+func (m *EventMetadata) SetTraceContext(carrier map[string]string) {
+	m.traceContext = carrier
+}
+
+// Actor はイベントを発生させた操作を行ったアクター（認証された利用者）のIDを返します。認証されていない場合は空文字列です。
+func (m *EventMetadata) Actor() string {
+	return m.actor
+}
+
+// SetActor はアクターのIDを設定します。
+func (m *EventMetadata) SetActor(actor string) {
+	m.actor = actor
+}
+
+// CorrelatedEvent は相関ID・トレースコンテキスト・アクターを保持できるドメインイベントです。
+// サブスクライバーのログやスパンを発生元のリクエストと結び付け、誰の操作かを記録するために使用します。
+type CorrelatedEvent interface {
+	DomainEvent
+	CorrelationID() string
+	SetCorrelationID(id string)
+	TraceContext() map[string]string
+	SetTraceContext(carrier map[string]string)
+	Actor() string
+	SetActor(actor string)
+}
+
 // DomainEventPublisher はドメインイベントを発行するためのインターフェースです。
 type DomainEventPublisher interface {
 	Publish(event DomainEvent)
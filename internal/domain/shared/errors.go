@@ -0,0 +1,86 @@
+package shared
+
+import (
+	"ddd-hands-on-go/internal/i18n"
+	"errors"
+)
+
+// ErrorKind はドメインエラーの種類を表す型です。
+type ErrorKind int
+
+const (
+	// KindUnknown は分類されていないエラーを表します。
+	KindUnknown ErrorKind = iota
+	// KindInvalid は入力値がドメインのルールに違反していることを表します。
+	KindInvalid
+	// KindNotFound は対象が存在しないことを表します。
+	KindNotFound
+	// KindConflict は現在の状態と矛盾する操作（重複登録、在庫不足など）を表します。
+	KindConflict
+)
+
+// DomainError は種類とコードを伴うドメインエラーです。
+// メッセージはコード（メッセージカタログのキー）と引数から生成するため、
+// プレゼンテーション層でリクエストの言語に合わせて表示できます（i18n.LocalizedErrorを実装します）。
+type DomainError struct {
+	kind  ErrorKind
+	code  i18n.Key
+	args  []interface{}
+	cause error
+}
+
+// NewDomainError は新しいDomainErrorを生成します。argsはコードのメッセージの書式を埋める値です。
+func NewDomainError(kind ErrorKind, code i18n.Key, args ...interface{}) *DomainError {
+	return &DomainError{kind: kind, code: code, args: args}
+}
+
+// Wrap は原因のエラーを保持したDomainErrorを返します。メッセージは原因のエラーのメッセージを続けたものになります。
+func (e *DomainError) Wrap(cause error) *DomainError {
+	wrapped := *e
+	wrapped.cause = cause
+	return &wrapped
+}
+
+// Error は既定の言語のエラーメッセージを返します。ログへの出力に使用します。
+func (e *DomainError) Error() string {
+	return e.Localize(i18n.Default)
+}
+
+// Localize は指定された言語のエラーメッセージを返します。
+func (e *DomainError) Localize(l i18n.Locale) string {
+	message := i18n.Message(l, e.code, e.args...)
+	if e.cause != nil {
+		message += ": " + i18n.ErrorMessage(l, e.cause)
+	}
+	return message
+}
+
+// Kind はエラーの種類を返します。
+func (e *DomainError) Kind() ErrorKind {
+	return e.kind
+}
+
+// Code はエラーを識別する安定したコードを返します。
+func (e *DomainError) Code() i18n.Key {
+	return e.code
+}
+
+// Args はメッセージの書式を埋める値を返します。
+func (e *DomainError) Args() []interface{} {
+	return e.args
+}
+
+// Unwrap は原因のエラーを返します。
+func (e *DomainError) Unwrap() error {
+	return e.cause
+}
+
+// KindOf はエラーチェーンに含まれるDomainErrorの種類を返します。
+// DomainErrorを含まない場合はKindUnknownを返します。
+func KindOf(err error) ErrorKind {
+	var de *DomainError
+	if errors.As(err, &de) {
+		return de.kind
+	}
+	return KindUnknown
+}
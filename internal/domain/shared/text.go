@@ -0,0 +1,19 @@
+package shared
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeText は前後の空白を取り除き、Unicode正規化形式C（NFC）に正規化します。
+// タイトルや著者名など、利用者が入力する名前の値オブジェクトで共通の正規化です。
+func NormalizeText(s string) string {
+	return norm.NFC.String(strings.TrimSpace(s))
+}
+
+// ContainsControl は文字列が改行やタブなどの制御文字を含むかどうかを返します。
+func ContainsControl(s string) bool {
+	return strings.IndexFunc(s, unicode.IsControl) >= 0
+}
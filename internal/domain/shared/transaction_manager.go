@@ -5,5 +5,78 @@ import "context"
 // TransactionManager はトランザクション制御を行うインターフェースです。
 type TransactionManager interface {
 	// Begin はトランザクションを開始し、関数内で実行される処理をアトミックに実行します。
-	Begin(ctx context.Context, f func(ctx context.Context) error) error
+	// コンテキストに既存のトランザクションがある場合は、Propagationの指定に従って
+	// そのトランザクションへ参加するか、セーブポイントを作成します。
+	Begin(ctx context.Context, f func(ctx context.Context) error, opts ...TxOption) error
+	// AfterCommit はコンテキストのトランザクションがコミットされた後に実行する関数を登録します。
+	// トランザクション外で呼び出された場合は即座に実行します。
+	AfterCommit(ctx context.Context, f func())
+	// AfterRollback はコンテキストのトランザクションがロールバックされた後に実行する関数を登録します。
+	// トランザクション外で呼び出された場合は何もしません。
+	AfterRollback(ctx context.Context, f func())
+}
+
+// IsolationLevel はトランザクション分離レベルを表す型です。
+type IsolationLevel int
+
+const (
+	// IsolationDefault はデータベースの既定の分離レベルを使用します。
+	IsolationDefault IsolationLevel = iota
+	// IsolationReadCommitted はREAD COMMITTEDを表します。
+	IsolationReadCommitted
+	// IsolationRepeatableRead はREPEATABLE READを表します。
+	IsolationRepeatableRead
+	// IsolationSerializable はSERIALIZABLEを表します。
+	IsolationSerializable
+)
+
+// Propagation は既存のトランザクションがある場合の振る舞いを表す型です。
+type Propagation int
+
+const (
+	// PropagationRequired は既存のトランザクションに参加します（なければ新規に開始します）。
+	PropagationRequired Propagation = iota
+	// PropagationNested は既存のトランザクション内にセーブポイントを作成し、
+	// 関数がエラーを返した場合はセーブポイントまでロールバックします。
+	PropagationNested
+)
+
+// TxOptions はトランザクション開始時のオプションです。
+type TxOptions struct {
+	Isolation   IsolationLevel
+	ReadOnly    bool
+	Propagation Propagation
+}
+
+// TxOption はTxOptionsを変更する関数です。
+type TxOption func(*TxOptions)
+
+// WithIsolation は分離レベルを指定します。
+func WithIsolation(level IsolationLevel) TxOption {
+	return func(o *TxOptions) {
+		o.Isolation = level
+	}
+}
+
+// WithReadOnly は読み取り専用トランザクションとして開始します。
+func WithReadOnly() TxOption {
+	return func(o *TxOptions) {
+		o.ReadOnly = true
+	}
+}
+
+// WithPropagation は既存のトランザクションがある場合の振る舞いを指定します。
+func WithPropagation(p Propagation) TxOption {
+	return func(o *TxOptions) {
+		o.Propagation = p
+	}
+}
+
+// NewTxOptions はオプションを適用したTxOptionsを生成します。
+func NewTxOptions(opts ...TxOption) TxOptions {
+	var o TxOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
 }
@@ -0,0 +1,157 @@
+// Package i18n はメッセージの多言語化（日本語・英語）を提供します。
+// メッセージはキーで識別し、リクエストの言語（HTTPではAccept-Language）に応じてプレゼンテーション層で文字列にします。
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale はメッセージの言語です。
+type Locale string
+
+const (
+	Japanese Locale = "ja"
+	English  Locale = "en"
+)
+
+// Default は言語が指定されていないか、サポートしていない言語だけが指定された場合に使用する言語です。
+const Default = Japanese
+
+// Supported はサポートする言語です。
+var Supported = []Locale{Japanese, English}
+
+// ParseLocale は言語タグ（ja、en-US など）の主言語がサポートする言語であれば、その言語を返します。
+func ParseLocale(tag string) (Locale, bool) {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	for _, l := range Supported {
+		if string(l) == primary {
+			return l, true
+		}
+	}
+	return "", false
+}
+
+// LocaleFromEnv はPOSIXのロケールの環境変数（LC_ALL、LC_MESSAGES、LANGの順）から言語を返します。
+// en_US.UTF-8 のような値の主言語がサポートする言語でない場合（C、POSIXを含む）はDefaultを返します。
+func LocaleFromEnv(lookupEnv func(string) (string, bool)) Locale {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value, ok := lookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+		// 最初に設定されている変数が優先されるため、サポートしない言語でも以降の変数は参照しない
+		tag, _, _ := strings.Cut(value, ".")
+		tag, _, _ = strings.Cut(tag, "@")
+		if l, ok := ParseLocale(strings.ReplaceAll(tag, "_", "-")); ok {
+			return l
+		}
+		return Default
+	}
+	return Default
+}
+
+// ParseAcceptLanguage はAccept-Languageヘッダーから、品質値（q）の最も高いサポートする言語を返します。
+// サポートする言語がない場合はDefaultを返します。「*」はDefaultとして扱います。
+func ParseAcceptLanguage(header string) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+		order  int
+	}
+	var candidates []candidate
+	for i, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			q = v
+		}
+		if q <= 0 {
+			continue
+		}
+		if strings.TrimSpace(tag) == "*" {
+			candidates = append(candidates, candidate{Default, q, i})
+			continue
+		}
+		if l, ok := ParseLocale(tag); ok {
+			candidates = append(candidates, candidate{l, q, i})
+		}
+	}
+	if len(candidates) == 0 {
+		return Default
+	}
+	// 品質値が同じ場合はヘッダーでの順序を優先する
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale
+}
+
+type localeKey struct{}
+
+// WithLocale はコンテキストにメッセージの言語を格納します。
+func WithLocale(ctx context.Context, l Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, l)
+}
+
+// FromContext はコンテキストに格納された言語を返します。格納されていない場合はDefaultを返します。
+func FromContext(ctx context.Context) Locale {
+	if l, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return l
+	}
+	return Default
+}
+
+// Key はメッセージカタログのメッセージを識別するキーです。
+type Key string
+
+// Message はキーのメッセージを指定された言語で返します。メッセージはfmt.Sprintfの書式で、argsで埋めます。
+// 指定された言語のメッセージがない場合はDefaultの言語のメッセージを、キーがない場合はキーをそのまま使用します。
+func Message(l Locale, key Key, args ...interface{}) string {
+	format, ok := catalog[key][l]
+	if !ok {
+		format, ok = catalog[key][Default]
+	}
+	if !ok {
+		format = string(key)
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// LocalizedError はメッセージカタログのキーをコードとして持ち、指定された言語でメッセージを返せるエラーです。
+// ドメインエラー（shared.DomainError）が実装し、プレゼンテーション層でリクエストの言語のメッセージにします。
+type LocalizedError interface {
+	error
+	// Code はエラーを識別する安定したコード（メッセージカタログのキー）を返します。
+	Code() Key
+	// Localize はエラーのメッセージを指定された言語で返します。
+	Localize(l Locale) string
+}
+
+// ErrorMessage はerrのメッセージを指定された言語で返します。
+// エラーチェーンにLocalizedErrorを含む場合はそのメッセージを、含まない場合はerr.Error()を返します。
+func ErrorMessage(l Locale, err error) string {
+	var le LocalizedError
+	if errors.As(err, &le) {
+		return le.Localize(l)
+	}
+	return err.Error()
+}
+
+// ErrorCode はエラーチェーンに含まれるLocalizedErrorのコードを返します。含まない場合は空文字列を返します。
+func ErrorCode(err error) Key {
+	var le LocalizedError
+	if errors.As(err, &le) {
+		return le.Code()
+	}
+	return ""
+}
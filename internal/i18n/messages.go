@@ -0,0 +1,581 @@
+package i18n
+
+// HTTP API・GraphQL・gRPCのメッセージのキー
+const (
+	MsgMethodNotAllowed         Key = "method_not_allowed"
+	MsgRouteNotFound            Key = "route_not_found"
+	MsgNotAcceptable            Key = "not_acceptable"
+	MsgInvalidRequestBody       Key = "invalid_request_body"
+	MsgQueryParameterNotInteger Key = "query_parameter_not_integer"
+	MsgRequestSpecViolation     Key = "request_spec_violation"
+	MsgResponseSpecViolation    Key = "response_spec_violation"
+	MsgResponseEncodeFailed     Key = "response_encode_failed"
+	MsgPayloadTooLarge          Key = "payload_too_large"
+	MsgTooManyRequests          Key = "too_many_requests"
+	MsgAuthenticationRequired   Key = "authentication_required"
+	MsgInvalidCredentials       Key = "invalid_credentials"
+	MsgRoleRequired             Key = "role_required"
+	MsgInvalidIdempotencyKey    Key = "invalid_idempotency_key"
+	MsgIdempotencyKeyReused     Key = "idempotency_key_reused"
+	MsgIdempotencyFailed        Key = "idempotency_failed"
+	MsgISBNRequired             Key = "isbn_required"
+	MsgBookNotFound             Key = "book_not_found"
+	MsgRegisterBookFailed       Key = "register_book_failed"
+	MsgGetBookFailed            Key = "get_book_failed"
+	MsgListBooksFailed          Key = "list_books_failed"
+	MsgUpdateBookFailed         Key = "update_book_failed"
+	MsgDeleteBookFailed         Key = "delete_book_failed"
+	MsgAdjustStockFailed        Key = "adjust_stock_failed"
+	MsgUnsupportedImportType    Key = "unsupported_import_type"
+	MsgInvalidDryRun            Key = "invalid_dry_run"
+	MsgImportReadFailed         Key = "import_read_failed"
+	MsgImportBooksFailed        Key = "import_books_failed"
+	MsgUnsupportedExportFormat  Key = "unsupported_export_format"
+	MsgExportBooksFailed        Key = "export_books_failed"
+	MsgRegisterAuthorFailed     Key = "register_author_failed"
+	MsgGetAuthorFailed          Key = "get_author_failed"
+	MsgListAuthorsFailed        Key = "list_authors_failed"
+	MsgUpdateAuthorFailed       Key = "update_author_failed"
+	MsgDeleteAuthorFailed       Key = "delete_author_failed"
+	MsgRegisterPublisherFailed  Key = "register_publisher_failed"
+	MsgGetPublisherFailed       Key = "get_publisher_failed"
+	MsgListPublishersFailed     Key = "list_publishers_failed"
+	MsgUpdatePublisherFailed    Key = "update_publisher_failed"
+	MsgDeletePublisherFailed    Key = "delete_publisher_failed"
+	MsgRegisterCategoryFailed   Key = "register_category_failed"
+	MsgGetCategoryFailed        Key = "get_category_failed"
+	MsgListCategoriesFailed     Key = "list_categories_failed"
+	MsgUpdateCategoryFailed     Key = "update_category_failed"
+	MsgDeleteCategoryFailed     Key = "delete_category_failed"
+	MsgAssignCategoriesFailed   Key = "assign_categories_failed"
+	MsgSearchBooksFailed        Key = "search_books_failed"
+	MsgListStockHistoryFailed   Key = "list_stock_history_failed"
+	MsgServerShuttingDown       Key = "server_shutting_down"
+)
+
+// 管理用CLIのメッセージのキー
+const (
+	MsgCLIError Key = "cli.error"
+)
+
+// ドメインエラーのコード。APIのエラーレスポンスや取り込み結果にそのまま含めるため、一度公開したコードは変更しないでください。
+const (
+	MsgBookIdRequired            Key = "book_id.required"
+	MsgTitleRequired             Key = "title.required"
+	MsgTitleTooLong              Key = "title.too_long"
+	MsgTitleControlCharacter     Key = "title.control_character"
+	MsgSubtitleTooLong           Key = "subtitle.too_long"
+	MsgSubtitleControlCharacter  Key = "subtitle.control_character"
+	MsgAuthorRequired            Key = "author.required"
+	MsgAuthorTooLong             Key = "author.too_long"
+	MsgAuthorControlCharacter    Key = "author.control_character"
+	MsgAuthorsTooMany            Key = "authors.too_many"
+	MsgAuthorsDuplicate          Key = "authors.duplicate"
+	MsgAuthorIdInvalid           Key = "author_id.invalid"
+	MsgAuthorNotFound            Key = "author.not_found"
+	MsgAuthorInUse               Key = "author.in_use"
+	MsgPublisherRequired         Key = "publisher.required"
+	MsgPublisherTooLong          Key = "publisher.too_long"
+	MsgPublisherControlCharacter Key = "publisher.control_character"
+	MsgPublisherIdInvalid        Key = "publisher_id.invalid"
+	MsgPublisherNotFound         Key = "publisher.not_found"
+	MsgPublisherInUse            Key = "publisher.in_use"
+	MsgCategoryRequired          Key = "category.required"
+	MsgCategoryTooLong           Key = "category.too_long"
+	MsgCategoryControlCharacter  Key = "category.control_character"
+	MsgCategoryIdInvalid         Key = "category_id.invalid"
+	MsgCategoryNotFound          Key = "category.not_found"
+	MsgCategoryParentNotFound    Key = "category.parent_not_found"
+	MsgCategoryCyclicParent      Key = "category.cyclic_parent"
+	MsgCategoryHasChildren       Key = "category.has_children"
+	MsgCategoryInUse             Key = "category.in_use"
+	MsgCategoriesTooMany         Key = "categories.too_many"
+	MsgCategoriesDuplicate       Key = "categories.duplicate"
+	MsgPublicationDateInvalid    Key = "publication_date.invalid"
+	MsgLanguageInvalid           Key = "language.invalid"
+	MsgPageCountOutOfRange       Key = "page_count.out_of_range"
+	MsgFormatInvalid             Key = "format.invalid"
+	MsgEditionOutOfRange         Key = "edition.out_of_range"
+	MsgPriceNegative             Key = "price.negative"
+	MsgPriceUnsupportedCurrency  Key = "price.unsupported_currency"
+	MsgStockIdRequired           Key = "stock_id.required"
+	MsgQuantityNegative          Key = "quantity_available.negative"
+	MsgQuantityNegativeIncrease  Key = "quantity_available.negative_increase"
+	MsgQuantityNegativeDecrease  Key = "quantity_available.negative_decrease"
+	MsgStockInsufficient         Key = "stock.insufficient"
+	MsgBookAlreadyExists         Key = "book.already_exists"
+	MsgBookNotFoundByISBN        Key = "book.not_found"
+	MsgListLimitOutOfRange       Key = "list.limit_out_of_range"
+	MsgListOffsetNegative        Key = "list.offset_negative"
+	MsgListMaxQuantityNegative   Key = "list.max_quantity_negative"
+	MsgListInvalidStatus         Key = "list.invalid_status"
+	MsgListInvalidSort           Key = "list.invalid_sort"
+	MsgSearchQueryRequired       Key = "search.query_required"
+	MsgSearchQueryTooLong        Key = "search.query_too_long"
+	MsgSearchTooManyTerms        Key = "search.too_many_terms"
+	MsgImportInvalidMode         Key = "import.invalid_mode"
+	MsgImportDuplicateRow        Key = "import.duplicate_row"
+	MsgImportPriceNotNumber      Key = "import.price_not_number"
+	MsgImportInvalidCSV          Key = "import.invalid_csv"
+	MsgImportInvalidJSON         Key = "import.invalid_json"
+	MsgImportCSVHeaderUnreadable Key = "import.csv_header_unreadable"
+	MsgImportCSVMissingColumn    Key = "import.csv_missing_column"
+	MsgImportRowsFailed          Key = "import.rows_failed"
+	MsgRepositoryCorruptedValue  Key = "repository.corrupted_value"
+)
+
+// catalog はキーごとの各言語のメッセージです。メッセージを追加する場合は全てのSupportedの言語を記述してください。
+var catalog = map[Key]map[Locale]string{
+	MsgMethodNotAllowed: {
+		Japanese: "許可されていないメソッドです",
+		English:  "Method not allowed",
+	},
+	MsgRouteNotFound: {
+		Japanese: "指定されたパスは存在しません",
+		English:  "The requested path does not exist",
+	},
+	MsgNotAcceptable: {
+		Japanese: "Acceptヘッダーで受け付けられる形式のレスポンスを返せません（利用できる形式: %s）",
+		English:  "Cannot produce a response in a media type acceptable by the Accept header (available: %s)",
+	},
+	MsgInvalidRequestBody: {
+		Japanese: "リクエストボディの読み込みに失敗しました: %v",
+		English:  "Failed to read the request body: %v",
+	},
+	MsgQueryParameterNotInteger: {
+		Japanese: "クエリパラメータ %s は整数である必要があります",
+		English:  "Query parameter %s must be an integer",
+	},
+	MsgRequestSpecViolation: {
+		Japanese: "リクエストがAPI仕様に適合しません: %v",
+		English:  "The request does not conform to the API specification: %v",
+	},
+	MsgResponseSpecViolation: {
+		Japanese: "レスポンスがAPI仕様に適合しません: %v",
+		English:  "The response does not conform to the API specification: %v",
+	},
+	MsgResponseEncodeFailed: {
+		Japanese: "レスポンスのエンコードに失敗しました",
+		English:  "Failed to encode the response",
+	},
+	MsgPayloadTooLarge: {
+		Japanese: "リクエストボディが上限（%dバイト）を超えています",
+		English:  "The request body exceeds the limit of %d bytes",
+	},
+	MsgTooManyRequests: {
+		Japanese: "リクエストが多すぎます。%d秒後に再試行してください",
+		English:  "Too many requests. Retry after %d seconds",
+	},
+	MsgAuthenticationRequired: {
+		Japanese: "認証が必要です",
+		English:  "Authentication is required",
+	},
+	MsgInvalidCredentials: {
+		Japanese: "認証情報が不正です",
+		English:  "Invalid credentials",
+	},
+	MsgRoleRequired: {
+		Japanese: "この操作には %s ロールが必要です",
+		English:  "This operation requires the %s role",
+	},
+	MsgInvalidIdempotencyKey: {
+		Japanese: "%sは1〜%d文字の表示可能なASCII文字である必要があります",
+		English:  "%s must be 1 to %d printable ASCII characters",
+	},
+	MsgIdempotencyKeyReused: {
+		Japanese: "冪等キーが異なるリクエストで使用されています: %s",
+		English:  "The idempotency key has already been used for a different request: %s",
+	},
+	MsgIdempotencyFailed: {
+		Japanese: "冪等キーの処理に失敗しました",
+		English:  "Failed to process the idempotency key",
+	},
+	MsgISBNRequired: {
+		Japanese: "ISBNは必須です",
+		English:  "ISBN is required",
+	},
+	MsgBookNotFound: {
+		Japanese: "書籍が見つかりません",
+		English:  "Book not found",
+	},
+	MsgRegisterBookFailed: {
+		Japanese: "書籍の登録に失敗しました",
+		English:  "Failed to register the book",
+	},
+	MsgGetBookFailed: {
+		Japanese: "書籍の取得に失敗しました",
+		English:  "Failed to get the book",
+	},
+	MsgListBooksFailed: {
+		Japanese: "書籍一覧の取得に失敗しました",
+		English:  "Failed to list books",
+	},
+	MsgUpdateBookFailed: {
+		Japanese: "書籍の更新に失敗しました",
+		English:  "Failed to update the book",
+	},
+	MsgDeleteBookFailed: {
+		Japanese: "書籍の削除に失敗しました",
+		English:  "Failed to delete the book",
+	},
+	MsgAdjustStockFailed: {
+		Japanese: "在庫の調整に失敗しました",
+		English:  "Failed to adjust the stock",
+	},
+	MsgUnsupportedImportType: {
+		Japanese: "Content-Typeは text/csv または application/x-ndjson である必要があります",
+		English:  "Content-Type must be text/csv or application/x-ndjson",
+	},
+	MsgInvalidDryRun: {
+		Japanese: "dry_runは真偽値である必要があります",
+		English:  "dry_run must be a boolean",
+	},
+	MsgImportReadFailed: {
+		Japanese: "取り込みデータの読み込みに失敗しました",
+		English:  "Failed to read the import data",
+	},
+	MsgImportBooksFailed: {
+		Japanese: "書籍の一括取り込みに失敗しました",
+		English:  "Failed to import books",
+	},
+	MsgUnsupportedExportFormat: {
+		Japanese: "サポートされていない書き出し形式です: %s",
+		English:  "Unsupported export format: %s",
+	},
+	MsgExportBooksFailed: {
+		Japanese: "書籍の書き出しに失敗しました",
+		English:  "Failed to export books",
+	},
+	MsgRegisterAuthorFailed: {
+		Japanese: "著者の登録に失敗しました",
+		English:  "Failed to register the author",
+	},
+	MsgGetAuthorFailed: {
+		Japanese: "著者の取得に失敗しました",
+		English:  "Failed to get the author",
+	},
+	MsgListAuthorsFailed: {
+		Japanese: "著者一覧の取得に失敗しました",
+		English:  "Failed to list authors",
+	},
+	MsgUpdateAuthorFailed: {
+		Japanese: "著者の更新に失敗しました",
+		English:  "Failed to update the author",
+	},
+	MsgDeleteAuthorFailed: {
+		Japanese: "著者の削除に失敗しました",
+		English:  "Failed to delete the author",
+	},
+	MsgRegisterPublisherFailed: {
+		Japanese: "出版社の登録に失敗しました",
+		English:  "Failed to register the publisher",
+	},
+	MsgGetPublisherFailed: {
+		Japanese: "出版社の取得に失敗しました",
+		English:  "Failed to get the publisher",
+	},
+	MsgListPublishersFailed: {
+		Japanese: "出版社一覧の取得に失敗しました",
+		English:  "Failed to list publishers",
+	},
+	MsgUpdatePublisherFailed: {
+		Japanese: "出版社の更新に失敗しました",
+		English:  "Failed to update the publisher",
+	},
+	MsgDeletePublisherFailed: {
+		Japanese: "出版社の削除に失敗しました",
+		English:  "Failed to delete the publisher",
+	},
+	MsgRegisterCategoryFailed: {
+		Japanese: "カテゴリの登録に失敗しました",
+		English:  "Failed to register the category",
+	},
+	MsgGetCategoryFailed: {
+		Japanese: "カテゴリの取得に失敗しました",
+		English:  "Failed to get the category",
+	},
+	MsgListCategoriesFailed: {
+		Japanese: "カテゴリ一覧の取得に失敗しました",
+		English:  "Failed to list categories",
+	},
+	MsgUpdateCategoryFailed: {
+		Japanese: "カテゴリの更新に失敗しました",
+		English:  "Failed to update the category",
+	},
+	MsgDeleteCategoryFailed: {
+		Japanese: "カテゴリの削除に失敗しました",
+		English:  "Failed to delete the category",
+	},
+	MsgAssignCategoriesFailed: {
+		Japanese: "書籍のカテゴリの割り当てに失敗しました",
+		English:  "Failed to assign categories to the book",
+	},
+	MsgSearchBooksFailed: {
+		Japanese: "書籍の検索に失敗しました",
+		English:  "Failed to search books",
+	},
+	MsgListStockHistoryFailed: {
+		Japanese: "在庫の変更履歴の取得に失敗しました",
+		English:  "Failed to list the stock history",
+	},
+	MsgServerShuttingDown: {
+		Japanese: "サーバーを停止しています",
+		English:  "The server is shutting down",
+	},
+
+	// 管理用CLI
+	MsgCLIError: {
+		Japanese: "エラー",
+		English:  "Error",
+	},
+
+	// ドメインエラー
+	MsgBookIdRequired: {
+		Japanese: "BookIdの生成に失敗しました: ISBNは必須です",
+		English:  "Invalid book ID: ISBN is required",
+	},
+	MsgTitleRequired: {
+		Japanese: "Titleの生成に失敗しました: タイトルは必須です",
+		English:  "Invalid title: title is required",
+	},
+	MsgTitleTooLong: {
+		Japanese: "Titleの生成に失敗しました: タイトルは%d文字以内である必要があります",
+		English:  "Invalid title: title must be at most %d characters",
+	},
+	MsgTitleControlCharacter: {
+		Japanese: "Titleの生成に失敗しました: タイトルに制御文字は使用できません",
+		English:  "Invalid title: title must not contain control characters",
+	},
+	MsgSubtitleTooLong: {
+		Japanese: "Titleの生成に失敗しました: サブタイトルは%d文字以内である必要があります",
+		English:  "Invalid title: subtitle must be at most %d characters",
+	},
+	MsgSubtitleControlCharacter: {
+		Japanese: "Titleの生成に失敗しました: サブタイトルに制御文字は使用できません",
+		English:  "Invalid title: subtitle must not contain control characters",
+	},
+	MsgAuthorRequired: {
+		Japanese: "Authorの生成に失敗しました: 著者名は必須です",
+		English:  "Invalid author: name is required",
+	},
+	MsgAuthorTooLong: {
+		Japanese: "Authorの生成に失敗しました: 著者名は%d文字以内である必要があります",
+		English:  "Invalid author: name must be at most %d characters",
+	},
+	MsgAuthorControlCharacter: {
+		Japanese: "Authorの生成に失敗しました: 著者名に制御文字は使用できません",
+		English:  "Invalid author: name must not contain control characters",
+	},
+	MsgAuthorsTooMany: {
+		Japanese: "Metadataの生成に失敗しました: 著者は%d人以内である必要があります",
+		English:  "Invalid metadata: a book can have at most %d authors",
+	},
+	MsgAuthorsDuplicate: {
+		Japanese: "Metadataの生成に失敗しました: 同じ著者が重複しています: %s",
+		English:  "Invalid metadata: duplicate author: %s",
+	},
+	MsgAuthorIdInvalid: {
+		Japanese: "AuthorIdの生成に失敗しました: 著者IDはUUIDである必要があります: %q",
+		English:  "Invalid author ID: must be a UUID: %q",
+	},
+	MsgAuthorNotFound: {
+		Japanese: "著者が存在しません: %s",
+		English:  "Author not found: %s",
+	},
+	MsgAuthorInUse: {
+		Japanese: "書籍から参照されている著者は削除できません: %s",
+		English:  "The author is referenced by books and cannot be deleted: %s",
+	},
+	MsgPublisherRequired: {
+		Japanese: "Publisherの生成に失敗しました: 出版社名は必須です",
+		English:  "Invalid publisher: name is required",
+	},
+	MsgPublisherTooLong: {
+		Japanese: "Publisherの生成に失敗しました: 出版社名は%d文字以内である必要があります",
+		English:  "Invalid publisher: name must be at most %d characters",
+	},
+	MsgPublisherControlCharacter: {
+		Japanese: "Publisherの生成に失敗しました: 出版社名に制御文字は使用できません",
+		English:  "Invalid publisher: name must not contain control characters",
+	},
+	MsgPublisherIdInvalid: {
+		Japanese: "PublisherIdの生成に失敗しました: 出版社IDはUUIDである必要があります: %q",
+		English:  "Invalid publisher ID: must be a UUID: %q",
+	},
+	MsgPublisherNotFound: {
+		Japanese: "出版社が存在しません: %s",
+		English:  "Publisher not found: %s",
+	},
+	MsgPublisherInUse: {
+		Japanese: "書籍から参照されている出版社は削除できません: %s",
+		English:  "The publisher is referenced by books and cannot be deleted: %s",
+	},
+	MsgCategoryRequired: {
+		Japanese: "Categoryの生成に失敗しました: カテゴリ名は必須です",
+		English:  "Invalid category: name is required",
+	},
+	MsgCategoryTooLong: {
+		Japanese: "Categoryの生成に失敗しました: カテゴリ名は%d文字以内である必要があります",
+		English:  "Invalid category: name must be at most %d characters",
+	},
+	MsgCategoryControlCharacter: {
+		Japanese: "Categoryの生成に失敗しました: カテゴリ名に制御文字は使用できません",
+		English:  "Invalid category: name must not contain control characters",
+	},
+	MsgCategoryIdInvalid: {
+		Japanese: "CategoryIdの生成に失敗しました: カテゴリIDはUUIDである必要があります: %q",
+		English:  "Invalid category ID: must be a UUID: %q",
+	},
+	MsgCategoryNotFound: {
+		Japanese: "カテゴリが存在しません: %s",
+		English:  "Category not found: %s",
+	},
+	MsgCategoryParentNotFound: {
+		Japanese: "親カテゴリが存在しません: %s",
+		English:  "Parent category not found: %s",
+	},
+	MsgCategoryCyclicParent: {
+		Japanese: "カテゴリ自身またはその子孫を親カテゴリにすることはできません: %s",
+		English:  "A category cannot be moved under itself or its descendants: %s",
+	},
+	MsgCategoryHasChildren: {
+		Japanese: "子カテゴリがあるカテゴリは削除できません: %s",
+		English:  "The category has child categories and cannot be deleted: %s",
+	},
+	MsgCategoryInUse: {
+		Japanese: "書籍に割り当てられているカテゴリは削除できません: %s",
+		English:  "The category is assigned to books and cannot be deleted: %s",
+	},
+	MsgCategoriesTooMany: {
+		Japanese: "Bookのカテゴリの割り当てに失敗しました: カテゴリは%d個以内である必要があります",
+		English:  "Invalid categories: a book can have at most %d categories",
+	},
+	MsgCategoriesDuplicate: {
+		Japanese: "Bookのカテゴリの割り当てに失敗しました: 同じカテゴリが重複しています: %s",
+		English:  "Invalid categories: duplicate category: %s",
+	},
+	MsgPublicationDateInvalid: {
+		Japanese: "PublicationDateの生成に失敗しました: 出版日はYYYY-MM-DD形式の日付である必要があります: %q",
+		English:  "Invalid publication date: must be a date in YYYY-MM-DD format: %q",
+	},
+	MsgLanguageInvalid: {
+		Japanese: "Languageの生成に失敗しました: ISO 639の言語コードではありません: %q",
+		English:  "Invalid language: not an ISO 639 language code: %q",
+	},
+	MsgPageCountOutOfRange: {
+		Japanese: "PageCountの生成に失敗しました: ページ数は1以上%d以下である必要があります",
+		English:  "Invalid page count: must be between 1 and %d",
+	},
+	MsgFormatInvalid: {
+		Japanese: "Formatの生成に失敗しました: 不正な形態です: %q",
+		English:  "Invalid format: %q",
+	},
+	MsgEditionOutOfRange: {
+		Japanese: "Editionの生成に失敗しました: 版は1以上%d以下である必要があります",
+		English:  "Invalid edition: must be between 1 and %d",
+	},
+	MsgPriceNegative: {
+		Japanese: "Priceの生成に失敗しました: 金額は0以上である必要があります",
+		English:  "Invalid price: amount must be 0 or greater",
+	},
+	MsgPriceUnsupportedCurrency: {
+		Japanese: "Priceの生成に失敗しました: 通貨はJPYのみサポートされています",
+		English:  "Invalid price: only JPY is supported as the currency",
+	},
+	MsgStockIdRequired: {
+		Japanese: "StockIdの生成に失敗しました: IDは必須です",
+		English:  "Invalid stock ID: ID is required",
+	},
+	MsgQuantityNegative: {
+		Japanese: "QuantityAvailableの生成に失敗しました: 数値は0以上である必要があります",
+		English:  "Invalid quantity available: quantity must be 0 or greater",
+	},
+	MsgQuantityNegativeIncrease: {
+		Japanese: "負の値で増加させることはできません",
+		English:  "Cannot increase by a negative amount",
+	},
+	MsgQuantityNegativeDecrease: {
+		Japanese: "負の値で減少させることはできません",
+		English:  "Cannot decrease by a negative amount",
+	},
+	MsgStockInsufficient: {
+		Japanese: "在庫が不足しています",
+		English:  "Insufficient stock",
+	},
+	MsgBookAlreadyExists: {
+		Japanese: "このISBNの書籍は既に存在します: %s",
+		English:  "A book with this ISBN already exists: %s",
+	},
+	MsgBookNotFoundByISBN: {
+		Japanese: "書籍が見つかりません: %s",
+		English:  "Book not found: %s",
+	},
+	MsgListLimitOutOfRange: {
+		Japanese: "取得件数は1以上%d以下である必要があります",
+		English:  "Limit must be between 1 and %d",
+	},
+	MsgListOffsetNegative: {
+		Japanese: "取得開始位置は0以上である必要があります",
+		English:  "Offset must be 0 or greater",
+	},
+	MsgListMaxQuantityNegative: {
+		Japanese: "在庫数の上限は0以上である必要があります",
+		English:  "Maximum quantity must be 0 or greater",
+	},
+	MsgListInvalidStatus: {
+		Japanese: "不正な在庫ステータスです: %s",
+		English:  "Invalid stock status: %s",
+	},
+	MsgListInvalidSort: {
+		Japanese: "不正な並び順です: %s",
+		English:  "Invalid sort order: %s",
+	},
+	MsgSearchQueryRequired: {
+		Japanese: "検索語を指定してください",
+		English:  "Search query is required",
+	},
+	MsgSearchQueryTooLong: {
+		Japanese: "検索語は%d文字以内である必要があります",
+		English:  "Search query must be at most %d characters",
+	},
+	MsgSearchTooManyTerms: {
+		Japanese: "検索語は空白で区切って%d個まで指定できます",
+		English:  "Search query may contain at most %d space-separated terms",
+	},
+	MsgImportInvalidMode: {
+		Japanese: "不正な取り込みモードです: %s",
+		English:  "Invalid import mode: %s",
+	},
+	MsgImportDuplicateRow: {
+		Japanese: "%d行目と同じISBNです",
+		English:  "Same ISBN as line %d",
+	},
+	MsgImportPriceNotNumber: {
+		Japanese: "価格が数値ではありません: %q",
+		English:  "Price is not a number: %q",
+	},
+	MsgImportInvalidCSV: {
+		Japanese: "CSVとして不正です",
+		English:  "Invalid CSV",
+	},
+	MsgImportInvalidJSON: {
+		Japanese: "JSONとして不正です",
+		English:  "Invalid JSON",
+	},
+	MsgImportCSVHeaderUnreadable: {
+		Japanese: "CSVのヘッダー行の読み込みに失敗しました",
+		English:  "Failed to read the CSV header row",
+	},
+	MsgImportCSVMissingColumn: {
+		Japanese: "CSVのヘッダー行に %s 列がありません",
+		English:  "The CSV header row has no %s column",
+	},
+	MsgImportRowsFailed: {
+		Japanese: "%d行の取り込みに失敗しました",
+		English:  "Failed to import %d rows",
+	},
+	MsgRepositoryCorruptedValue: {
+		Japanese: "データ整合性エラー: 保存されている%sが不正です",
+		English:  "Data integrity error: the stored %s is invalid",
+	},
+}
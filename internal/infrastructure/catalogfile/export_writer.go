@@ -0,0 +1,133 @@
+package catalogfile
+
+import (
+	"bufio"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/model/book/price"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// NewExportWriter は指定された形式の書き出し用ライターを生成します。
+func NewExportWriter(format Format, w io.Writer) (book.BookExportWriter, error) {
+	switch format {
+	case CSV:
+		return NewCSVExportWriter(w), nil
+	case NDJSON:
+		return NewNDJSONExportWriter(w), nil
+	case ONIX:
+		return NewONIXExportWriter(w, DefaultONIXSender), nil
+	default:
+		return nil, fmt.Errorf("サポートされていない書き出し形式です: %s", format)
+	}
+}
+
+// exportCSVColumns は書き出すCSVの列です。先頭の3列は取り込みのCSVと同じため、そのまま取り込み直せます。
+var exportCSVColumns = []string{"isbn", "title", "price", "currency", "quantity_available", "status"}
+
+// CSVExportWriter は書籍をヘッダー行付きのCSVとして書き出します。
+type CSVExportWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+// NewCSVExportWriter は新しいCSVExportWriterを生成します。
+func NewCSVExportWriter(w io.Writer) *CSVExportWriter {
+	return &CSVExportWriter{w: csv.NewWriter(w)}
+}
+
+// Write は書籍を1行書き出します。
+func (c *CSVExportWriter) Write(b *book.BookDTO) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	return c.w.Write([]string{
+		b.ISBN,
+		b.Title,
+		formatPrice(b.PriceAmount),
+		// 通貨は現在JPY固定
+		string(price.JPY),
+		strconv.Itoa(b.QuantityAvailable),
+		b.Status,
+	})
+}
+
+// Close はバッファをフラッシュします。書籍が0件の場合もヘッダー行は書き出します。
+func (c *CSVExportWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *CSVExportWriter) writeHeader() error {
+	if c.wroteHeader {
+		return nil
+	}
+	c.wroteHeader = true
+	return c.w.Write(exportCSVColumns)
+}
+
+// NDJSONExportWriter は書籍を1行に1つのJSONオブジェクトとして書き出します。
+type NDJSONExportWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+// NewNDJSONExportWriter は新しいNDJSONExportWriterを生成します。
+func NewNDJSONExportWriter(w io.Writer) *NDJSONExportWriter {
+	bw := bufio.NewWriter(w)
+	return &NDJSONExportWriter{w: bw, enc: json.NewEncoder(bw)}
+}
+
+// ndjsonExportRow はNDJSONの1行です。
+type ndjsonExportRow struct {
+	ISBN              string  `json:"isbn"`
+	Title             string  `json:"title"`
+	Subtitle          string  `json:"subtitle,omitempty"`
+	Price             float64 `json:"price"`
+	Currency          string  `json:"currency"`
+	QuantityAvailable int     `json:"quantity_available"`
+	Status            string  `json:"status"`
+	// 書誌情報。不明な項目は省略します。
+	Authors         []book.BookAuthorDTO   `json:"authors,omitempty"`
+	Publisher       *book.BookPublisherDTO `json:"publisher,omitempty"`
+	PublicationDate string                 `json:"publication_date,omitempty"`
+	Language        string                 `json:"language,omitempty"`
+	PageCount       int                    `json:"page_count,omitempty"`
+	Format          string                 `json:"format,omitempty"`
+	Edition         int                    `json:"edition,omitempty"`
+}
+
+// Write は書籍を1行書き出します。
+func (n *NDJSONExportWriter) Write(b *book.BookDTO) error {
+	return n.enc.Encode(ndjsonExportRow{
+		ISBN:              b.ISBN,
+		Title:             b.Title,
+		Subtitle:          b.Subtitle,
+		Price:             b.PriceAmount,
+		Currency:          string(price.JPY),
+		QuantityAvailable: b.QuantityAvailable,
+		Status:            b.Status,
+		Authors:           b.Authors,
+		Publisher:         b.Publisher,
+		PublicationDate:   b.PublicationDate,
+		Language:          b.Language,
+		PageCount:         b.PageCount,
+		Format:            b.Format,
+		Edition:           b.Edition,
+	})
+}
+
+// Close はバッファをフラッシュします。
+func (n *NDJSONExportWriter) Close() error {
+	return n.w.Flush()
+}
+
+func formatPrice(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
@@ -0,0 +1,160 @@
+// Package catalogfile は書籍カタログのファイル形式（CSV、NDJSONなど）の読み書きを実装します。
+package catalogfile
+
+import (
+	"bufio"
+	"bytes"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format はカタログのファイル形式です。
+type Format string
+
+const (
+	// CSV はヘッダー行付きのCSVです。
+	CSV Format = "csv"
+	// NDJSON は1行に1つのJSONオブジェクトを記述する形式です。
+	NDJSON Format = "ndjson"
+	// ONIX は出版業界の書誌情報交換形式ONIX for Books 3.0です。書き出しのみに対応します。
+	ONIX Format = "onix"
+)
+
+// NewImportReader は指定された形式の取り込み行リーダーを生成します。
+func NewImportReader(format Format, r io.Reader) (book.ImportRowReader, error) {
+	switch format {
+	case CSV:
+		return NewCSVImportReader(r)
+	case NDJSON:
+		return NewNDJSONImportReader(r), nil
+	default:
+		return nil, fmt.Errorf("サポートされていない取り込み形式です: %s", format)
+	}
+}
+
+// csvColumns はCSVの必須列です。
+var csvColumns = []string{"isbn", "title", "price"}
+
+// CSVImportReader はCSVから取り込み行を読み込みます。
+// 1行目は列名（isbn, title, price と、省略可能な subtitle）のヘッダーで、列の順序は任意です。
+type CSVImportReader struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+// NewCSVImportReader はヘッダー行を読み込み、新しいCSVImportReaderを生成します。
+func NewCSVImportReader(r io.Reader) (*CSVImportReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgImportCSVHeaderUnreadable).Wrap(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// ExcelなどのBOM付きUTF-8に対応する
+		name = strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")
+		columns[strings.ToLower(name)] = i
+	}
+	for _, name := range csvColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, shared.NewDomainError(shared.KindInvalid, i18n.MsgImportCSVMissingColumn, name)
+		}
+	}
+
+	return &CSVImportReader{r: cr, columns: columns}, nil
+}
+
+// Read は次の行を返します。
+func (c *CSVImportReader) Read() (book.ImportRow, error) {
+	record, err := c.r.Read()
+	if errors.Is(err, io.EOF) {
+		return book.ImportRow{}, io.EOF
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return book.ImportRow{Line: parseErr.StartLine, Err: shared.NewDomainError(shared.KindInvalid, i18n.MsgImportInvalidCSV).Wrap(parseErr)}, nil
+	}
+	if err != nil {
+		return book.ImportRow{}, err
+	}
+	line, _ := c.r.FieldPos(0)
+
+	row := book.ImportRow{
+		Line:     line,
+		ISBN:     c.field(record, "isbn"),
+		Title:    c.field(record, "title"),
+		Subtitle: c.field(record, "subtitle"),
+	}
+	priceStr := c.field(record, "price")
+	row.PriceAmount, err = strconv.ParseFloat(priceStr, 64)
+	if err != nil {
+		row.Err = shared.NewDomainError(shared.KindInvalid, i18n.MsgImportPriceNotNumber, priceStr)
+	}
+	return row, nil
+}
+
+func (c *CSVImportReader) field(record []string, name string) string {
+	i, ok := c.columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// NDJSONImportReader はNDJSONから取り込み行を読み込みます。
+// 各行は {"isbn": "...", "title": "...", "price": 1500} の形式で、空行は無視します。
+type NDJSONImportReader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+// NewNDJSONImportReader は新しいNDJSONImportReaderを生成します。
+func NewNDJSONImportReader(r io.Reader) *NDJSONImportReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &NDJSONImportReader{s: s}
+}
+
+// ndjsonRow はNDJSONの1行です。
+type ndjsonRow struct {
+	ISBN     string  `json:"isbn"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle"`
+	Price    float64 `json:"price"`
+}
+
+// Read は次の行を返します。
+func (n *NDJSONImportReader) Read() (book.ImportRow, error) {
+	for n.s.Scan() {
+		n.line++
+		data := bytes.TrimSpace(n.s.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		var v ndjsonRow
+		if err := dec.Decode(&v); err != nil {
+			return book.ImportRow{Line: n.line, Err: shared.NewDomainError(shared.KindInvalid, i18n.MsgImportInvalidJSON).Wrap(err)}, nil
+		}
+		return book.ImportRow{Line: n.line, ISBN: v.ISBN, Title: v.Title, Subtitle: v.Subtitle, PriceAmount: v.Price}, nil
+	}
+	if err := n.s.Err(); err != nil {
+		return book.ImportRow{}, err
+	}
+	return book.ImportRow{}, io.EOF
+}
@@ -0,0 +1,296 @@
+package catalogfile
+
+import (
+	"bufio"
+	"ddd-hands-on-go/internal/application/book"
+	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/book/price"
+	"ddd-hands-on-go/internal/domain/model/book/stock/status"
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// DefaultONIXSender はONIXのヘッダーに記載する既定の送信者名です。
+const DefaultONIXSender = "ddd-hands-on-go"
+
+// onixNamespace はONIX for Books 3.0 (Reference tag) の名前空間です。
+const onixNamespace = "http://ns.editeur.org/onix/3.0/reference"
+
+// ONIXのコードリスト
+// https://www.editeur.org/14/Code-Lists/
+const (
+	onixNotificationConfirmed = "03"  // List 1: Notification confirmed on publication
+	onixProductIDISBN13       = "15"  // List 5: ISBN-13
+	onixProductIDProprietary  = "01"  // List 5: Proprietary
+	onixCompositionSingleItem = "00"  // List 2: Single-component retail product
+	onixProductFormBook       = "BA"  // List 150: Book
+	onixProductFormHardback   = "BB"  // List 150: Hardback
+	onixProductFormPaperback  = "BC"  // List 150: Paperback / softback
+	onixProductFormDownload   = "ED"  // List 150: Digital download
+	onixContributorRoleAuthor = "A01" // List 17: By (author)
+	onixExtentMainContent     = "00"  // List 23: Main content page count
+	onixExtentUnitPages       = "03"  // List 24: Pages
+	onixPublishingRolePub     = "01"  // List 45: Publisher
+	onixPublishingDatePub     = "01"  // List 163: Publication date
+	onixTitleTypeDistinctive  = "01"  // List 15: Distinctive title
+	onixTitleLevelProduct     = "01"  // List 149: Product
+	onixSupplierRolePublisher = "01"  // List 93: Publisher to retailers
+	onixAvailabilityInStock   = "21"  // List 65: In stock
+	onixAvailabilityOutStock  = "31"  // List 65: Out of stock
+	onixPriceTypeRRPIncTax    = "02"  // List 58: RRP including tax
+)
+
+// ONIXExportWriter は書籍をONIX for Books 3.0のProductレコードとして書き出します。
+// ヘッダーは最初の書き込み時に、ONIXMessageの終了タグはCloseで書き出します。
+type ONIXExportWriter struct {
+	w       *bufio.Writer
+	enc     *xml.Encoder
+	sender  string
+	sentAt  time.Time
+	started bool
+}
+
+// NewONIXExportWriter は新しいONIXExportWriterを生成します。senderはヘッダーのSenderNameに使用します。
+func NewONIXExportWriter(w io.Writer, sender string) *ONIXExportWriter {
+	bw := bufio.NewWriter(w)
+	enc := xml.NewEncoder(bw)
+	enc.Indent("", "  ")
+	return &ONIXExportWriter{w: bw, enc: enc, sender: sender, sentAt: time.Now().UTC()}
+}
+
+type onixHeader struct {
+	XMLName      xml.Name `xml:"Header"`
+	SenderName   string   `xml:"Sender>SenderName"`
+	SentDateTime string   `xml:"SentDateTime"`
+}
+
+type onixProduct struct {
+	XMLName           xml.Name              `xml:"Product"`
+	RecordReference   string                `xml:"RecordReference"`
+	NotificationType  string                `xml:"NotificationType"`
+	ProductIdentifier onixProductIdentifier `xml:"ProductIdentifier"`
+	DescriptiveDetail onixDescriptiveDetail `xml:"DescriptiveDetail"`
+	PublishingDetail  *onixPublishingDetail `xml:"PublishingDetail,omitempty"`
+	SupplyDetail      onixSupplyDetail      `xml:"ProductSupply>SupplyDetail"`
+}
+
+type onixProductIdentifier struct {
+	ProductIDType string `xml:"ProductIDType"`
+	IDValue       string `xml:"IDValue"`
+}
+
+type onixDescriptiveDetail struct {
+	ProductComposition string            `xml:"ProductComposition"`
+	ProductForm        string            `xml:"ProductForm"`
+	TitleDetail        onixTitleDetail   `xml:"TitleDetail"`
+	Contributors       []onixContributor `xml:"Contributor"`
+	EditionNumber      int               `xml:"EditionNumber,omitempty"`
+	Extent             *onixExtent       `xml:"Extent,omitempty"`
+}
+
+type onixContributor struct {
+	SequenceNumber  int    `xml:"SequenceNumber"`
+	ContributorRole string `xml:"ContributorRole"`
+	PersonName      string `xml:"PersonName"`
+}
+
+type onixExtent struct {
+	ExtentType  string `xml:"ExtentType"`
+	ExtentValue int    `xml:"ExtentValue"`
+	ExtentUnit  string `xml:"ExtentUnit"`
+}
+
+type onixPublishingDetail struct {
+	Publisher      *onixPublisher      `xml:"Publisher,omitempty"`
+	PublishingDate *onixPublishingDate `xml:"PublishingDate,omitempty"`
+}
+
+type onixPublisher struct {
+	PublishingRole string `xml:"PublishingRole"`
+	PublisherName  string `xml:"PublisherName"`
+}
+
+type onixPublishingDate struct {
+	PublishingDateRole string `xml:"PublishingDateRole"`
+	Date               string `xml:"Date"`
+}
+
+type onixTitleDetail struct {
+	TitleType         string `xml:"TitleType"`
+	TitleElementLevel string `xml:"TitleElement>TitleElementLevel"`
+	TitleText         string `xml:"TitleElement>TitleText"`
+	Subtitle          string `xml:"TitleElement>Subtitle,omitempty"`
+}
+
+type onixSupplyDetail struct {
+	SupplierRole        string    `xml:"Supplier>SupplierRole"`
+	SupplierName        string    `xml:"Supplier>SupplierName"`
+	ProductAvailability string    `xml:"ProductAvailability"`
+	Price               onixPrice `xml:"Price"`
+}
+
+type onixPrice struct {
+	PriceType    string `xml:"PriceType"`
+	PriceAmount  string `xml:"PriceAmount"`
+	CurrencyCode string `xml:"CurrencyCode"`
+}
+
+// Write は書籍を1件のProductとして書き出します。
+func (o *ONIXExportWriter) Write(b *book.BookDTO) error {
+	if err := o.start(); err != nil {
+		return err
+	}
+
+	idType, idValue := onixProductID(b.ISBN)
+	return o.enc.Encode(onixProduct{
+		RecordReference:   o.sender + ":" + b.ISBN,
+		NotificationType:  onixNotificationConfirmed,
+		ProductIdentifier: onixProductIdentifier{ProductIDType: idType, IDValue: idValue},
+		DescriptiveDetail: onixDescriptiveDetail{
+			ProductComposition: onixCompositionSingleItem,
+			ProductForm:        onixProductForm(domain_book.Format(b.Format)),
+			TitleDetail: onixTitleDetail{
+				TitleType:         onixTitleTypeDistinctive,
+				TitleElementLevel: onixTitleLevelProduct,
+				TitleText:         b.Title,
+				Subtitle:          b.Subtitle,
+			},
+			Contributors:  onixContributors(b.Authors),
+			EditionNumber: b.Edition,
+			Extent:        onixPageExtent(b.PageCount),
+		},
+		PublishingDetail: onixPublishing(b.Publisher, b.PublicationDate),
+		SupplyDetail: onixSupplyDetail{
+			SupplierRole:        onixSupplierRolePublisher,
+			SupplierName:        o.sender,
+			ProductAvailability: onixAvailability(status.ToStatusEnum(b.Status)),
+			Price: onixPrice{
+				// 国内の書籍価格は税込の定価として扱う
+				PriceType:   onixPriceTypeRRPIncTax,
+				PriceAmount: formatPrice(b.PriceAmount),
+				// 通貨は現在JPY固定
+				CurrencyCode: string(price.JPY),
+			},
+		},
+	})
+}
+
+// Close はONIXMessageの終了タグを書き出し、バッファをフラッシュします。
+func (o *ONIXExportWriter) Close() error {
+	if err := o.start(); err != nil {
+		return err
+	}
+	if err := o.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: "ONIXMessage"}}); err != nil {
+		return err
+	}
+	if err := o.enc.Flush(); err != nil {
+		return err
+	}
+	return o.w.Flush()
+}
+
+// start はXML宣言、ONIXMessageの開始タグとヘッダーを書き出します。
+func (o *ONIXExportWriter) start() error {
+	if o.started {
+		return nil
+	}
+	o.started = true
+
+	if _, err := o.w.WriteString(xml.Header); err != nil {
+		return err
+	}
+	if err := o.enc.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "ONIXMessage"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns"}, Value: onixNamespace},
+			{Name: xml.Name{Local: "release"}, Value: "3.0"},
+		},
+	}); err != nil {
+		return err
+	}
+	return o.enc.Encode(onixHeader{
+		SenderName:   o.sender,
+		SentDateTime: o.sentAt.Format("20060102T1504Z"),
+	})
+}
+
+// onixProductID はISBNからProductIDTypeとIDValueを返します。
+// ハイフンを除いて13桁の数字になる場合はISBN-13、それ以外は独自IDとして扱います。
+func onixProductID(isbn string) (string, string) {
+	compact := strings.ReplaceAll(isbn, "-", "")
+	if len(compact) != 13 {
+		return onixProductIDProprietary, isbn
+	}
+	for _, r := range compact {
+		if r < '0' || r > '9' {
+			return onixProductIDProprietary, isbn
+		}
+	}
+	return onixProductIDISBN13, compact
+}
+
+// onixProductForm は書籍の形態をONIXのProductForm (List 150) へ変換します。形態が不明な場合は書籍（BA）とします。
+func onixProductForm(f domain_book.Format) string {
+	switch f {
+	case domain_book.Hardcover:
+		return onixProductFormHardback
+	case domain_book.Paperback:
+		return onixProductFormPaperback
+	case domain_book.Ebook:
+		return onixProductFormDownload
+	default:
+		return onixProductFormBook
+	}
+}
+
+// onixContributors は著者を、記載順の連番を付けたContributorへ変換します。
+func onixContributors(authors []book.BookAuthorDTO) []onixContributor {
+	contributors := make([]onixContributor, 0, len(authors))
+	for i, a := range authors {
+		contributors = append(contributors, onixContributor{
+			SequenceNumber:  i + 1,
+			ContributorRole: onixContributorRoleAuthor,
+			PersonName:      a.Name,
+		})
+	}
+	return contributors
+}
+
+// onixPageExtent はページ数をExtentへ変換します。ページ数が不明な場合はnilです。
+func onixPageExtent(pageCount int) *onixExtent {
+	if pageCount == 0 {
+		return nil
+	}
+	return &onixExtent{ExtentType: onixExtentMainContent, ExtentValue: pageCount, ExtentUnit: onixExtentUnitPages}
+}
+
+// onixPublishing は出版社と出版日（YYYY-MM-DD）をPublishingDetailへ変換します。どちらも不明な場合はnilです。
+func onixPublishing(publisher *book.BookPublisherDTO, publicationDate string) *onixPublishingDetail {
+	if publisher == nil && publicationDate == "" {
+		return nil
+	}
+	d := &onixPublishingDetail{}
+	if publisher != nil {
+		d.Publisher = &onixPublisher{PublishingRole: onixPublishingRolePub, PublisherName: publisher.Name}
+	}
+	if publicationDate != "" {
+		// ONIXの日付の既定の形式はYYYYMMDD
+		d.PublishingDate = &onixPublishingDate{
+			PublishingDateRole: onixPublishingDatePub,
+			Date:               strings.ReplaceAll(publicationDate, "-", ""),
+		}
+	}
+	return d
+}
+
+// onixAvailability は在庫ステータスをONIXのProductAvailability (List 65) へ変換します。
+func onixAvailability(s status.StatusEnum) string {
+	switch s {
+	case status.InStock, status.LowStock:
+		return onixAvailabilityInStock
+	default:
+		return onixAvailabilityOutStock
+	}
+}
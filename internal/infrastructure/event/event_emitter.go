@@ -1,36 +1,156 @@
 package event
 
 import (
+	"context"
 	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/metrics"
+	"ddd-hands-on-go/internal/tracing"
+	"log/slog"
 	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DispatchMode はイベントをリスナーへ配信する方式です。
+type DispatchMode string
+
+const (
+	// DispatchSync はPublishの呼び出し元で全てのリスナーを実行します。
+	DispatchSync DispatchMode = "sync"
+	// DispatchAsync はイベントごとにゴルーチンを起動してリスナーを実行し、Publishは即座に戻ります。
+	// 同じイベントのリスナーは登録順に実行されますが、イベント間の実行順序は保証されません。
+	DispatchAsync DispatchMode = "async"
 )
 
 // EventEmitter はメモリ上でイベントの発行と購読を管理する構造体です。
 type EventEmitter struct {
 	subscribers map[string][]func(event shared.DomainEvent)
 	mu          sync.RWMutex
+	mode        DispatchMode
+	inFlight    sync.WaitGroup
+	metrics     *metrics.Metrics
+}
+
+// EmitterOption はEventEmitterの生成時のオプションです。
+type EmitterOption func(*EventEmitter)
+
+// WithDispatchMode はイベントの配信方式を指定します。既定はDispatchSyncです。
+func WithDispatchMode(mode DispatchMode) EmitterOption {
+	return func(e *EventEmitter) {
+		e.mode = mode
+	}
+}
+
+// WithMetrics は発行されたイベントとリスナーが失敗したイベントの件数をmに記録します。
+func WithMetrics(m *metrics.Metrics) EmitterOption {
+	return func(e *EventEmitter) {
+		e.metrics = m
+	}
 }
 
 // NewEventEmitter は新しいEventEmitterを生成します。
-func NewEventEmitter() *EventEmitter {
-	return &EventEmitter{
+func NewEventEmitter(opts ...EmitterOption) *EventEmitter {
+	e := &EventEmitter{
 		subscribers: make(map[string][]func(event shared.DomainEvent)),
+		mode:        DispatchSync,
+	}
+	for _, opt := range opts {
+		opt(e)
 	}
+	return e
 }
 
 // Publish はイベントを発行し、登録されたリスナー（コールバック）を実行します。
 func (e *EventEmitter) Publish(event shared.DomainEvent) {
 	e.mu.RLock()
-	defer e.mu.RUnlock()
+	callbacks := e.subscribers[event.EventName()]
+	e.mu.RUnlock()
 
-	callbacks, ok := e.subscribers[event.EventName()]
-	if !ok {
+	e.metrics.IncEventPublished(event.EventName())
+	if len(callbacks) == 0 {
 		return
 	}
 
-	for _, callback := range callbacks {
-		// 同期的に実行する（必要に応じてゴルーチンで非同期化も可能）
-		callback(event)
+	if e.mode != DispatchAsync {
+		defer func() {
+			// 同期配信ではパニックを呼び出し元へそのまま伝える
+			if r := recover(); r != nil {
+				e.metrics.IncEventFailed(event.EventName())
+				panic(r)
+			}
+		}()
+		for i, callback := range callbacks {
+			e.invoke(event, i, callback)
+		}
+		return
+	}
+
+	e.inFlight.Add(1)
+	go func() {
+		defer e.inFlight.Done()
+		defer func() {
+			// 非同期のリスナーのパニックでプロセス全体を停止させない
+			if r := recover(); r != nil {
+				e.metrics.IncEventFailed(event.EventName())
+				attrs := []any{"event", event.EventName(), "panic", r}
+				if e, ok := event.(shared.CorrelatedEvent); ok {
+					attrs = append(attrs, "correlation_id", e.CorrelationID())
+				}
+				slog.Error("イベントのリスナーでパニックが発生しました", attrs...)
+			}
+		}()
+		for i, callback := range callbacks {
+			e.invoke(event, i, callback)
+		}
+	}()
+}
+
+// invoke はリスナーを1つ実行し、その実行をスパンとして記録します。
+// スパンはイベントに付与された発生元のトレースコンテキストを親とします。パニックはスパンに記録した上で呼び出し元へ伝えます。
+func (e *EventEmitter) invoke(event shared.DomainEvent, index int, callback func(event shared.DomainEvent)) {
+	ctx := context.Background()
+	if ce, ok := event.(shared.CorrelatedEvent); ok {
+		ctx = tracing.Extract(ctx, ce.TraceContext())
+	}
+	_, span := tracing.Start(ctx, event.EventName()+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("event.name", event.EventName()),
+			attribute.Int("event.listener", index),
+			attribute.String("event.dispatch_mode", string(e.mode)),
+		),
+	)
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			tracing.RecordPanic(span, r)
+			panic(r)
+		}
+	}()
+
+	callback(event)
+}
+
+// Wait は非同期で実行中のリスナーが全て終了するまで待機します。同期配信の場合は即座に戻ります。
+func (e *EventEmitter) Wait() {
+	e.inFlight.Wait()
+}
+
+// Drain はWaitと同様に実行中のリスナーの終了を待機しますが、ctxが終了した場合はctxのエラーを返します。
+// サーバーの停止時に、データベースを閉じる前に呼び出します。
+func (e *EventEmitter) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		e.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"context"
+	appauthor "ddd-hands-on-go/internal/application/author"
+)
+
+// InMemoryAuthorQueryService はメモリ上の著者を参照するAuthorQueryServiceの実装です。
+type InMemoryAuthorQueryService struct {
+	store *Store
+}
+
+// NewInMemoryAuthorQueryService は新しいInMemoryAuthorQueryServiceを生成します。
+func NewInMemoryAuthorQueryService(store *Store) *InMemoryAuthorQueryService {
+	return &InMemoryAuthorQueryService{store: store}
+}
+
+// ListAuthors は著者を著者名順（同じ名前の場合はID順）に返します。
+func (s *InMemoryAuthorQueryService) ListAuthors(ctx context.Context, q appauthor.ListAuthorsQuery) ([]*appauthor.AuthorDTO, error) {
+	result := make([]*appauthor.AuthorDTO, 0, q.Limit)
+	s.store.read(ctx, func(t *tables) {
+		ids := t.authors.sorted()
+		for i := q.Offset; i < len(ids) && len(result) < q.Limit; i++ {
+			result = append(result, &appauthor.AuthorDTO{ID: ids[i], Name: t.authors[ids[i]]})
+		}
+	})
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package memory
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/author"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"slices"
+)
+
+// InMemoryAuthorRepository はメモリ上に保存するAuthorRepositoryの実装です。
+type InMemoryAuthorRepository struct {
+	store *Store
+}
+
+// NewInMemoryAuthorRepository は新しいInMemoryAuthorRepositoryを生成します。
+func NewInMemoryAuthorRepository(store *Store) *InMemoryAuthorRepository {
+	return &InMemoryAuthorRepository{store: store}
+}
+
+// Save は著者を保存(作成または更新)します。
+func (r *InMemoryAuthorRepository) Save(ctx context.Context, a *author.Author) error {
+	r.store.write(ctx, func(t *tables) {
+		t.authors[a.AuthorId().Value()] = a.Name().Value()
+	})
+	return nil
+}
+
+// Find は指定されたIDの著者を検索します。見つからない場合はnilを返します。
+func (r *InMemoryAuthorRepository) Find(ctx context.Context, authorId *author.AuthorId) (*author.Author, error) {
+	found, err := r.FindMany(ctx, []*author.AuthorId{authorId})
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	return found[0], nil
+}
+
+// FindMany は指定された複数のIDの著者をまとめて検索します。
+func (r *InMemoryAuthorRepository) FindMany(ctx context.Context, authorIds []*author.AuthorId) ([]*author.Author, error) {
+	stored := make(names)
+	r.store.read(ctx, func(t *tables) {
+		for _, id := range authorIds {
+			if name, ok := t.authors[id.Value()]; ok {
+				stored[id.Value()] = name
+			}
+		}
+	})
+
+	result := make([]*author.Author, 0, len(stored))
+	for id, value := range stored {
+		authorId, err := author.NewAuthorId(id)
+		if err != nil {
+			return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "authorId").Wrap(err)
+		}
+		name, err := author.NewName(value)
+		if err != nil {
+			return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "name").Wrap(err)
+		}
+		result = append(result, author.NewAuthor(authorId, name))
+	}
+	return result, nil
+}
+
+// Delete は著者を削除します。著者を参照している書籍がある場合はKindConflictのエラーを返します。
+func (r *InMemoryAuthorRepository) Delete(ctx context.Context, authorId *author.AuthorId) error {
+	var inUse bool
+	r.store.write(ctx, func(t *tables) {
+		for _, b := range t.books {
+			if slices.Contains(b.authorIds, authorId.Value()) {
+				inUse = true
+				return
+			}
+		}
+		delete(t.authors, authorId.Value())
+	})
+	if inUse {
+		return shared.NewDomainError(shared.KindConflict, i18n.MsgAuthorInUse, authorId.Value())
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"context"
+	appbook "ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/model/book/stock/status"
+	"slices"
+	"sort"
+)
+
+// InMemoryBookQueryService はメモリ上の書籍を参照するBookQueryServiceの実装です。
+type InMemoryBookQueryService struct {
+	store *Store
+}
+
+// NewInMemoryBookQueryService は新しいInMemoryBookQueryServiceを生成します。
+func NewInMemoryBookQueryService(store *Store) *InMemoryBookQueryService {
+	return &InMemoryBookQueryService{store: store}
+}
+
+// ListBooks は条件に一致する書籍をq.Sortの順に返します。
+func (s *InMemoryBookQueryService) ListBooks(ctx context.Context, q appbook.ListBooksQuery) ([]*appbook.BookDTO, error) {
+	result := make([]*appbook.BookDTO, 0, q.Limit)
+	s.store.read(ctx, func(t *tables) {
+		records := t.books.sorted()
+		if q.Sort == appbook.SortByTitle {
+			// ISBN順に並んだ書籍を安定ソートし、照合キーが同じ書籍はISBN順のままにする
+			sort.SliceStable(records, func(i, j int) bool { return records[i].titleCollationKey < records[j].titleCollationKey })
+		}
+
+		var inCategory map[string]bool
+		if q.CategoryId != "" {
+			inCategory = t.categories.descendants(q.CategoryId)
+		}
+
+		skipped := 0
+		for _, r := range records {
+			if len(result) >= q.Limit {
+				break
+			}
+			if q.Status != "" && r.status != q.Status {
+				continue
+			}
+			if q.MaxQuantityAvailable != nil && r.quantityAvailable > *q.MaxQuantityAvailable {
+				continue
+			}
+			if inCategory != nil && !slices.ContainsFunc(r.categoryIds, func(id string) bool { return inCategory[id] }) {
+				continue
+			}
+			if skipped < q.Offset {
+				skipped++
+				continue
+			}
+			result = append(result, r.toDTO(t))
+		}
+	})
+	return result, nil
+}
+
+// EachBook は全ての書籍をISBN順に1件ずつfへ渡します。
+// fの実行中に書籍が変更されても影響を受けないよう、呼び出し時点の複製を走査します。
+func (s *InMemoryBookQueryService) EachBook(ctx context.Context, f func(*appbook.BookDTO) error) error {
+	var dtos []*appbook.BookDTO
+	s.store.read(ctx, func(t *tables) {
+		for _, r := range t.books.sorted() {
+			dtos = append(dtos, r.toDTO(t))
+		}
+	})
+
+	for _, dto := range dtos {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := f(dto); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toDTO は保存された書籍をBookDTOへ変換します。著者名・出版社名・カテゴリ名はtから取得します。
+func (r bookRecord) toDTO(t *tables) *appbook.BookDTO {
+	dto := &appbook.BookDTO{
+		ISBN:              r.bookId,
+		Title:             r.title,
+		Subtitle:          r.subtitle,
+		PriceAmount:       r.priceAmount,
+		QuantityAvailable: r.quantityAvailable,
+		Status:            r.status,
+		PublicationDate:   r.publicationDate,
+		Language:          r.language,
+		PageCount:         r.pageCount,
+		Format:            r.format,
+		Edition:           r.edition,
+	}
+	for _, id := range r.authorIds {
+		dto.Authors = append(dto.Authors, appbook.BookAuthorDTO{ID: id, Name: t.authors[id]})
+	}
+	if r.publisherId != "" {
+		dto.Publisher = &appbook.BookPublisherDTO{ID: r.publisherId, Name: t.publishers[r.publisherId]}
+	}
+	for _, id := range r.categoryIds {
+		dto.Categories = append(dto.Categories, appbook.BookCategoryDTO{ID: id, Name: t.categories[id].name})
+	}
+	// BookDTOAssemblerと同じく、カテゴリ名順（同じ名前の場合はID順）に並べる
+	sort.Slice(dto.Categories, func(i, j int) bool {
+		if dto.Categories[i].Name != dto.Categories[j].Name {
+			return dto.Categories[i].Name < dto.Categories[j].Name
+		}
+		return dto.Categories[i].ID < dto.Categories[j].ID
+	})
+	return dto
+}
+
+// CountBooksByStatus は在庫ステータスごとの書籍数を返します。書籍のない在庫ステータスは0件として含めます。
+func (s *InMemoryBookQueryService) CountBooksByStatus(ctx context.Context) (map[string]int, error) {
+	counts := map[string]int{
+		status.InStock.String():    0,
+		status.LowStock.String():   0,
+		status.OutOfStock.String(): 0,
+	}
+	s.store.read(ctx, func(t *tables) {
+		for _, r := range t.books {
+			counts[r.status]++
+		}
+	})
+	return counts, nil
+}
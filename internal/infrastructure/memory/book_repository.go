@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/book"
+)
+
+// InMemoryBookRepository はメモリ上に保存するBookRepositoryの実装です。
+type InMemoryBookRepository struct {
+	store *Store
+}
+
+// NewInMemoryBookRepository は新しいInMemoryBookRepositoryを生成します。
+func NewInMemoryBookRepository(store *Store) *InMemoryBookRepository {
+	return &InMemoryBookRepository{store: store}
+}
+
+// Save は書籍情報を保存(作成または更新)します。
+func (r *InMemoryBookRepository) Save(ctx context.Context, b *book.Book) error {
+	record := toRecord(b)
+	// まだ取り出されていない在庫数変更イベントを変更履歴として追記する
+	var history []stockHistoryRecord
+	for _, event := range b.Events() {
+		if changed, ok := event.(*book.StockQuantityChanged); ok {
+			history = append(history, stockHistoryRecord{
+				delta:             changed.Delta,
+				quantityAvailable: changed.QuantityAvailable,
+				status:            changed.Status,
+				occurredAt:        changed.OccurredAt,
+			})
+		}
+	}
+	r.store.write(ctx, func(t *tables) {
+		t.books[record.bookId] = record
+		if len(history) > 0 {
+			t.stockHistory[record.bookId] = append(t.stockHistory[record.bookId], history...)
+		}
+	})
+	return nil
+}
+
+// Find は指定されたIDの書籍を検索します。見つからない場合はnilを返します。
+func (r *InMemoryBookRepository) Find(ctx context.Context, bookId *book.BookId) (*book.Book, error) {
+	var record bookRecord
+	var ok bool
+	r.store.read(ctx, func(t *tables) {
+		record, ok = t.books[bookId.Value()]
+	})
+	if !ok {
+		return nil, nil
+	}
+	return record.toBook()
+}
+
+// FindForUpdate は指定されたIDの書籍を検索します。
+// トランザクションは1つずつ実行されるため（InMemoryTransactionManagerを参照）、ロックせずにFindと同じく検索します。
+func (r *InMemoryBookRepository) FindForUpdate(ctx context.Context, bookId *book.BookId) (*book.Book, error) {
+	return r.Find(ctx, bookId)
+}
+
+// FindMany は指定された複数のIDの書籍をまとめて検索します。
+func (r *InMemoryBookRepository) FindMany(ctx context.Context, bookIds []*book.BookId) ([]*book.Book, error) {
+	var records []bookRecord
+	r.store.read(ctx, func(t *tables) {
+		for _, id := range bookIds {
+			if record, ok := t.books[id.Value()]; ok {
+				records = append(records, record)
+			}
+		}
+	})
+
+	result := make([]*book.Book, 0, len(records))
+	for _, record := range records {
+		b, err := record.toBook()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, b)
+	}
+	return result, nil
+}
+
+// Delete は書籍を削除します。
+func (r *InMemoryBookRepository) Delete(ctx context.Context, bookId *book.BookId) error {
+	r.store.write(ctx, func(t *tables) {
+		delete(t.books, bookId.Value())
+		delete(t.stockHistory, bookId.Value())
+	})
+	return nil
+}
@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"context"
+	appcategory "ddd-hands-on-go/internal/application/category"
+)
+
+// InMemoryCategoryQueryService はメモリ上のカテゴリを参照するCategoryQueryServiceの実装です。
+type InMemoryCategoryQueryService struct {
+	store *Store
+}
+
+// NewInMemoryCategoryQueryService は新しいInMemoryCategoryQueryServiceを生成します。
+func NewInMemoryCategoryQueryService(store *Store) *InMemoryCategoryQueryService {
+	return &InMemoryCategoryQueryService{store: store}
+}
+
+// ListCategories は全てのカテゴリをカテゴリ名順（同じ名前の場合はID順）に返します。
+func (s *InMemoryCategoryQueryService) ListCategories(ctx context.Context) ([]*appcategory.CategoryDTO, error) {
+	var result []*appcategory.CategoryDTO
+	s.store.read(ctx, func(t *tables) {
+		ids := t.categories.sorted()
+		result = make([]*appcategory.CategoryDTO, 0, len(ids))
+		for _, id := range ids {
+			result = append(result, &appcategory.CategoryDTO{ID: id, Name: t.categories[id].name, ParentID: t.categories[id].parentId})
+		}
+	})
+	return result, nil
+}
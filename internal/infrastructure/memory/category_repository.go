@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"slices"
+)
+
+// InMemoryCategoryRepository はメモリ上に保存するCategoryRepositoryの実装です。
+type InMemoryCategoryRepository struct {
+	store *Store
+}
+
+// NewInMemoryCategoryRepository は新しいInMemoryCategoryRepositoryを生成します。
+func NewInMemoryCategoryRepository(store *Store) *InMemoryCategoryRepository {
+	return &InMemoryCategoryRepository{store: store}
+}
+
+// Save はカテゴリを保存(作成または更新)します。
+func (r *InMemoryCategoryRepository) Save(ctx context.Context, c *category.Category) error {
+	record := categoryRecord{name: c.Name().Value()}
+	if c.ParentId() != nil {
+		record.parentId = c.ParentId().Value()
+	}
+	r.store.write(ctx, func(t *tables) {
+		t.categories[c.CategoryId().Value()] = record
+	})
+	return nil
+}
+
+// Find は指定されたIDのカテゴリを検索します。見つからない場合はnilを返します。
+func (r *InMemoryCategoryRepository) Find(ctx context.Context, categoryId *category.CategoryId) (*category.Category, error) {
+	found, err := r.FindMany(ctx, []*category.CategoryId{categoryId})
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	return found[0], nil
+}
+
+// FindMany は指定された複数のIDのカテゴリをまとめて検索します。
+func (r *InMemoryCategoryRepository) FindMany(ctx context.Context, categoryIds []*category.CategoryId) ([]*category.Category, error) {
+	stored := make(categories)
+	r.store.read(ctx, func(t *tables) {
+		for _, id := range categoryIds {
+			if record, ok := t.categories[id.Value()]; ok {
+				stored[id.Value()] = record
+			}
+		}
+	})
+
+	result := make([]*category.Category, 0, len(stored))
+	for id, record := range stored {
+		c, err := record.toCategory(id)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+// Delete はカテゴリを削除します。
+// 子カテゴリがある場合、またはカテゴリを割り当てられた書籍がある場合はKindConflictのエラーを返します。
+func (r *InMemoryCategoryRepository) Delete(ctx context.Context, categoryId *category.CategoryId) error {
+	var err error
+	r.store.write(ctx, func(t *tables) {
+		for _, c := range t.categories {
+			if c.parentId == categoryId.Value() {
+				err = shared.NewDomainError(shared.KindConflict, i18n.MsgCategoryHasChildren, categoryId.Value())
+				return
+			}
+		}
+		for _, b := range t.books {
+			if slices.Contains(b.categoryIds, categoryId.Value()) {
+				err = shared.NewDomainError(shared.KindConflict, i18n.MsgCategoryInUse, categoryId.Value())
+				return
+			}
+		}
+		delete(t.categories, categoryId.Value())
+	})
+	return err
+}
+
+// toCategory は保存された値からカテゴリを復元します。
+func (r categoryRecord) toCategory(id string) (*category.Category, error) {
+	categoryId, err := category.NewCategoryId(id)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "categoryId").Wrap(err)
+	}
+	name, err := category.NewName(r.name)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "name").Wrap(err)
+	}
+	var parentId *category.CategoryId
+	if r.parentId != "" {
+		if parentId, err = category.NewCategoryId(r.parentId); err != nil {
+			return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "parentId").Wrap(err)
+		}
+	}
+	c, err := category.NewCategory(categoryId, name, parentId)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "parentId").Wrap(err)
+	}
+	return c, nil
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/application/idempotency"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// idempotencyKey は冪等キーをアクターごとに区別するためのキーです。
+type idempotencyKey struct {
+	actor, key string
+}
+
+// InMemoryIdempotencyStore はメモリ上に冪等キーを保存するidempotency.Storeの実装です。
+// トランザクション内の変更は即座に反映し、ロールバックされた場合に元に戻します。
+// InMemoryTransactionManagerはトランザクションを1つずつ実行するため、他のトランザクションから途中の状態は見えません。
+type InMemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[idempotencyKey]idempotency.Record
+}
+
+// NewInMemoryIdempotencyStore は新しいInMemoryIdempotencyStoreを生成します。
+func NewInMemoryIdempotencyStore() *InMemoryIdempotencyStore {
+	return &InMemoryIdempotencyStore{records: make(map[idempotencyKey]idempotency.Record)}
+}
+
+// Reserve はキーを予約します。有効期限を過ぎた同じキーは新しい予約で置き換えます。
+func (s *InMemoryIdempotencyStore) Reserve(ctx context.Context, r *idempotency.Record) (*idempotency.Record, error) {
+	k := idempotencyKey{actor: r.Actor, key: r.Key}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[k]; ok && existing.ExpiresAt.After(r.CreatedAt) {
+		return &existing, nil
+	}
+	s.set(ctx, k, *r)
+	return nil, nil
+}
+
+// Complete は予約したキーにレスポンスを保存します。
+// 予約と同じトランザクション内で呼び出すため、ロールバック時には予約のフックが予約前の状態に戻します。
+func (s *InMemoryIdempotencyStore) Complete(ctx context.Context, r *idempotency.Record) error {
+	k := idempotencyKey{actor: r.Actor, key: r.Key}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.records[k]; !ok {
+		return fmt.Errorf("予約済みの冪等キーが見つかりません: %s", r.Key)
+	}
+	s.records[k] = *r
+	return nil
+}
+
+// DeleteExpired は有効期限がnow以前のキーを削除します。
+func (s *InMemoryIdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for k, r := range s.records {
+		if !r.ExpiresAt.After(now) {
+			s.delete(ctx, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// set はキーを保存し、トランザクションがロールバックされた場合に元に戻すフックを登録します。s.muを保持して呼び出します。
+func (s *InMemoryIdempotencyStore) set(ctx context.Context, k idempotencyKey, r idempotency.Record) {
+	s.onRollback(ctx, k)
+	s.records[k] = r
+}
+
+// delete はキーを削除し、トランザクションがロールバックされた場合に元に戻すフックを登録します。s.muを保持して呼び出します。
+func (s *InMemoryIdempotencyStore) delete(ctx context.Context, k idempotencyKey) {
+	s.onRollback(ctx, k)
+	delete(s.records, k)
+}
+
+// onRollback は現在のキーの値を、トランザクションのロールバック時に復元するよう登録します。
+func (s *InMemoryIdempotencyStore) onRollback(ctx context.Context, k idempotencyKey) {
+	state := getTxState(ctx)
+	if state == nil {
+		return
+	}
+	prev, existed := s.records[k]
+	state.afterRollback = append(state.afterRollback, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if existed {
+			s.records[k] = prev
+		} else {
+			delete(s.records, k)
+		}
+	})
+}
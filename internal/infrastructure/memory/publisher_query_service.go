@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"context"
+	apppublisher "ddd-hands-on-go/internal/application/publisher"
+)
+
+// InMemoryPublisherQueryService はメモリ上の出版社を参照するPublisherQueryServiceの実装です。
+type InMemoryPublisherQueryService struct {
+	store *Store
+}
+
+// NewInMemoryPublisherQueryService は新しいInMemoryPublisherQueryServiceを生成します。
+func NewInMemoryPublisherQueryService(store *Store) *InMemoryPublisherQueryService {
+	return &InMemoryPublisherQueryService{store: store}
+}
+
+// ListPublishers は出版社を出版社名順（同じ名前の場合はID順）に返します。
+func (s *InMemoryPublisherQueryService) ListPublishers(ctx context.Context, q apppublisher.ListPublishersQuery) ([]*apppublisher.PublisherDTO, error) {
+	result := make([]*apppublisher.PublisherDTO, 0, q.Limit)
+	s.store.read(ctx, func(t *tables) {
+		ids := t.publishers.sorted()
+		for i := q.Offset; i < len(ids) && len(result) < q.Limit; i++ {
+			result = append(result, &apppublisher.PublisherDTO{ID: ids[i], Name: t.publishers[ids[i]]})
+		}
+	})
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+)
+
+// InMemoryPublisherRepository はメモリ上に保存するPublisherRepositoryの実装です。
+type InMemoryPublisherRepository struct {
+	store *Store
+}
+
+// NewInMemoryPublisherRepository は新しいInMemoryPublisherRepositoryを生成します。
+func NewInMemoryPublisherRepository(store *Store) *InMemoryPublisherRepository {
+	return &InMemoryPublisherRepository{store: store}
+}
+
+// Save は出版社を保存(作成または更新)します。
+func (r *InMemoryPublisherRepository) Save(ctx context.Context, p *publisher.Publisher) error {
+	r.store.write(ctx, func(t *tables) {
+		t.publishers[p.PublisherId().Value()] = p.Name().Value()
+	})
+	return nil
+}
+
+// Find は指定されたIDの出版社を検索します。見つからない場合はnilを返します。
+func (r *InMemoryPublisherRepository) Find(ctx context.Context, publisherId *publisher.PublisherId) (*publisher.Publisher, error) {
+	found, err := r.FindMany(ctx, []*publisher.PublisherId{publisherId})
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	return found[0], nil
+}
+
+// FindMany は指定された複数のIDの出版社をまとめて検索します。
+func (r *InMemoryPublisherRepository) FindMany(ctx context.Context, publisherIds []*publisher.PublisherId) ([]*publisher.Publisher, error) {
+	stored := make(names)
+	r.store.read(ctx, func(t *tables) {
+		for _, id := range publisherIds {
+			if name, ok := t.publishers[id.Value()]; ok {
+				stored[id.Value()] = name
+			}
+		}
+	})
+
+	result := make([]*publisher.Publisher, 0, len(stored))
+	for id, value := range stored {
+		publisherId, err := publisher.NewPublisherId(id)
+		if err != nil {
+			return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "publisherId").Wrap(err)
+		}
+		name, err := publisher.NewName(value)
+		if err != nil {
+			return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "name").Wrap(err)
+		}
+		result = append(result, publisher.NewPublisher(publisherId, name))
+	}
+	return result, nil
+}
+
+// Delete は出版社を削除します。出版社を参照している書籍がある場合はKindConflictのエラーを返します。
+func (r *InMemoryPublisherRepository) Delete(ctx context.Context, publisherId *publisher.PublisherId) error {
+	var inUse bool
+	r.store.write(ctx, func(t *tables) {
+		for _, b := range t.books {
+			if b.publisherId == publisherId.Value() {
+				inUse = true
+				return
+			}
+		}
+		delete(t.publishers, publisherId.Value())
+	})
+	if inUse {
+		return shared.NewDomainError(shared.KindConflict, i18n.MsgPublisherInUse, publisherId.Value())
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	appbook "ddd-hands-on-go/internal/application/book"
+	"sort"
+	"strings"
+)
+
+// 検索語を含む項目ごとの、関連度への加算値です。PostgresSearchIndexの部分一致による加算と同じ値です。
+const (
+	searchWeightTitle     = 1.0
+	searchWeightAuthor    = 0.6
+	searchWeightPublisher = 0.3
+)
+
+// InMemorySearchIndex はメモリ上の書籍を走査して検索するSearchIndexの実装です。
+// 関連度は検索語を含む項目の重みの合計で、PostgresSearchIndexと異なり全文検索の順位は加算しません。
+type InMemorySearchIndex struct {
+	store *Store
+}
+
+// NewInMemorySearchIndex は新しいInMemorySearchIndexを生成します。
+func NewInMemorySearchIndex(store *Store) *InMemorySearchIndex {
+	return &InMemorySearchIndex{store: store}
+}
+
+// Search はtermsの全てを、タイトル（副題を含む）・著者名・出版社名のいずれかに含む書籍を関連度の高い順（同じ場合はISBN順）に返します。
+func (s *InMemorySearchIndex) Search(ctx context.Context, terms []string, limit, offset int) ([]*appbook.BookSearchResult, error) {
+	var results []*appbook.BookSearchResult
+	s.store.read(ctx, func(t *tables) {
+		for _, r := range t.books.sorted() {
+			dto := r.toDTO(t)
+			if score, ok := searchScore(dto, terms); ok {
+				results = append(results, &appbook.BookSearchResult{Book: dto, Score: score})
+			}
+		}
+	})
+
+	// ISBN順に並んだ結果を安定ソートし、関連度が同じ書籍はISBN順のままにする
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if offset >= len(results) {
+		return []*appbook.BookSearchResult{}, nil
+	}
+	return results[offset:min(offset+limit, len(results))], nil
+}
+
+// searchScore はdtoが全ての検索語を含む場合に、その関連度を返します。
+func searchScore(dto *appbook.BookDTO, terms []string) (float64, bool) {
+	title := appbook.NormalizeSearchText(dto.Title + " " + dto.Subtitle)
+	var authorNames []string
+	for _, a := range dto.Authors {
+		authorNames = append(authorNames, a.Name)
+	}
+	authors := appbook.NormalizeSearchText(strings.Join(authorNames, " "))
+	var publisher string
+	if dto.Publisher != nil {
+		publisher = appbook.NormalizeSearchText(dto.Publisher.Name)
+	}
+
+	score := 0.0
+	for _, term := range terms {
+		matched := false
+		for _, f := range []struct {
+			text   string
+			weight float64
+		}{{title, searchWeightTitle}, {authors, searchWeightAuthor}, {publisher, searchWeightPublisher}} {
+			if strings.Contains(f.text, term) {
+				score += f.weight
+				matched = true
+			}
+		}
+		if !matched {
+			return 0, false
+		}
+	}
+	return score, true
+}
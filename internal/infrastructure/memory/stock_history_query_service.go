@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	appbook "ddd-hands-on-go/internal/application/book"
+)
+
+// InMemoryStockHistoryQueryService はメモリ上の在庫数の変更履歴を参照するStockHistoryQueryServiceの実装です。
+type InMemoryStockHistoryQueryService struct {
+	store *Store
+}
+
+// NewInMemoryStockHistoryQueryService は新しいInMemoryStockHistoryQueryServiceを生成します。
+func NewInMemoryStockHistoryQueryService(store *Store) *InMemoryStockHistoryQueryService {
+	return &InMemoryStockHistoryQueryService{store: store}
+}
+
+// ListStockHistory は指定された書籍ごとに、新しい順に最大limit件の変更履歴を返します。
+func (s *InMemoryStockHistoryQueryService) ListStockHistory(ctx context.Context, isbns []string, limit int) (map[string][]*appbook.StockHistoryEntryDTO, error) {
+	result := make(map[string][]*appbook.StockHistoryEntryDTO)
+	s.store.read(ctx, func(t *tables) {
+		for _, isbn := range isbns {
+			records := t.stockHistory[isbn]
+			if len(records) == 0 {
+				continue
+			}
+			entries := make([]*appbook.StockHistoryEntryDTO, 0, min(len(records), limit))
+			for i := len(records) - 1; i >= 0 && len(entries) < limit; i-- {
+				r := records[i]
+				entries = append(entries, &appbook.StockHistoryEntryDTO{
+					Delta:             r.delta,
+					QuantityAvailable: r.quantityAvailable,
+					Status:            r.status,
+					OccurredAt:        r.occurredAt,
+				})
+			}
+			result[isbn] = entries
+		}
+	})
+	return result, nil
+}
@@ -0,0 +1,315 @@
+// Package memory はデータベースを使用しない、メモリ上のリポジトリ・クエリサービス・トランザクションマネージャーの実装です。
+// ローカルでの動作確認やデモなど、PostgreSQLを用意できない環境で使用します。プロセスを終了するとデータは失われます。
+package memory
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/book/price"
+	"ddd-hands-on-go/internal/domain/model/book/stock"
+	"ddd-hands-on-go/internal/domain/model/book/stock/quantity_available"
+	"ddd-hands-on-go/internal/domain/model/book/stock/status"
+	"ddd-hands-on-go/internal/domain/model/book/stock/stock_id"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"sort"
+	"sync"
+	"time"
+)
+
+// bookRecord は保存された書籍1件分の値です。
+// 集約のポインタを保持すると保存後の変更が反映されてしまうため、値として複製して保持します。
+type bookRecord struct {
+	bookId            string
+	title             string
+	subtitle          string
+	titleCollationKey string
+	priceAmount       float64
+	authorIds         []string
+	publisherId       string
+	publicationDate   string
+	language          string
+	pageCount         int
+	format            string
+	edition           int
+	categoryIds       []string
+	stockId           string
+	quantityAvailable int
+	status            string
+}
+
+// books はISBNをキーとした書籍の集合です。
+type books map[string]bookRecord
+
+func (b books) clone() books {
+	cp := make(books, len(b))
+	for k, v := range b {
+		cp[k] = v
+	}
+	return cp
+}
+
+// sorted はISBN順に並べた書籍を返します。
+func (b books) sorted() []bookRecord {
+	records := make([]bookRecord, 0, len(b))
+	for _, r := range b {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].bookId < records[j].bookId })
+	return records
+}
+
+// names はIDをキーとした名前の集合です。著者と出版社の保存に使用します。
+type names map[string]string
+
+func (n names) clone() names {
+	cp := make(names, len(n))
+	for k, v := range n {
+		cp[k] = v
+	}
+	return cp
+}
+
+// sorted は名前順（同じ名前の場合はID順）に並べたIDを返します。
+func (n names) sorted() []string {
+	ids := make([]string, 0, len(n))
+	for id := range n {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if n[ids[i]] != n[ids[j]] {
+			return n[ids[i]] < n[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// categoryRecord は保存されたカテゴリ1件分の値です。最上位のカテゴリのparentIdは空です。
+type categoryRecord struct {
+	name     string
+	parentId string
+}
+
+// categories はIDをキーとしたカテゴリの集合です。
+type categories map[string]categoryRecord
+
+func (c categories) clone() categories {
+	cp := make(categories, len(c))
+	for k, v := range c {
+		cp[k] = v
+	}
+	return cp
+}
+
+// sorted はカテゴリ名順（同じ名前の場合はID順）に並べたIDを返します。
+func (c categories) sorted() []string {
+	ids := make([]string, 0, len(c))
+	for id := range c {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if c[ids[i]].name != c[ids[j]].name {
+			return c[ids[i]].name < c[ids[j]].name
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// descendants はidのカテゴリと、その子孫の全てのカテゴリのIDの集合を返します。
+func (c categories) descendants(id string) map[string]bool {
+	result := map[string]bool{id: true}
+	// 親子関係は循環しないため、集合が増えなくなるまで子を追加する
+	for added := true; added; {
+		added = false
+		for childId, r := range c {
+			if result[r.parentId] && !result[childId] {
+				result[childId] = true
+				added = true
+			}
+		}
+	}
+	return result
+}
+
+// stockHistoryRecord は在庫数の変更履歴1件分の値です。
+type stockHistoryRecord struct {
+	delta             int
+	quantityAvailable int
+	status            string
+	occurredAt        time.Time
+}
+
+// stockHistory はISBNをキーとした在庫数の変更履歴です。書籍ごとに古い順に保持します。
+type stockHistory map[string][]stockHistoryRecord
+
+func (h stockHistory) clone() stockHistory {
+	cp := make(stockHistory, len(h))
+	for k, v := range h {
+		// 追記がトランザクション外のデータに影響しないよう容量を切り詰める
+		cp[k] = v[:len(v):len(v)]
+	}
+	return cp
+}
+
+// tables は保存するデータ全体です。トランザクションではこの単位で複製し、コミット時に置き換えます。
+type tables struct {
+	books      books
+	authors    names
+	publishers names
+	categories categories
+	// stockHistory は書籍の削除時に合わせて削除します。
+	stockHistory stockHistory
+}
+
+func newTables() *tables {
+	return &tables{
+		books:        make(books),
+		authors:      make(names),
+		publishers:   make(names),
+		categories:   make(categories),
+		stockHistory: make(stockHistory),
+	}
+}
+
+func (t *tables) clone() *tables {
+	return &tables{
+		books:        t.books.clone(),
+		authors:      t.authors.clone(),
+		publishers:   t.publishers.clone(),
+		categories:   t.categories.clone(),
+		stockHistory: t.stockHistory.clone(),
+	}
+}
+
+// Store はリポジトリ、クエリサービス、トランザクションマネージャーが共有するデータの保存先です。
+type Store struct {
+	mu     sync.RWMutex
+	tables *tables
+	// txMu はトランザクションを1つずつ実行するためのロックです（SERIALIZABLE相当）。
+	txMu sync.Mutex
+}
+
+// NewStore は空のStoreを生成します。
+func NewStore() *Store {
+	return &Store{tables: newTables()}
+}
+
+// read はコンテキストのトランザクション内のデータ、またはコミット済みのデータに対してfを実行します。
+func (s *Store) read(ctx context.Context, f func(*tables)) {
+	if state := getTxState(ctx); state != nil {
+		f(state.tables)
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f(s.tables)
+}
+
+// write はコンテキストのトランザクション内のデータ、またはコミット済みのデータに対してfを実行します。
+// トランザクション外の書き込みは、コミット時に上書きされないよう実行中のトランザクションの終了を待ちます。
+func (s *Store) write(ctx context.Context, f func(*tables)) {
+	if state := getTxState(ctx); state != nil {
+		f(state.tables)
+		return
+	}
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.tables)
+}
+
+// snapshot はコミット済みのデータの複製を返します。
+func (s *Store) snapshot() *tables {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tables.clone()
+}
+
+// commit はトランザクション内のデータをコミット済みのデータとして反映します。
+func (s *Store) commit(t *tables) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables = t
+}
+
+// toRecord は書籍集約を保存用の値へ変換します。
+func toRecord(b *book.Book) bookRecord {
+	r := bookRecord{
+		bookId:            b.BookId().Value(),
+		title:             b.Title().Value(),
+		subtitle:          b.Title().Subtitle(),
+		titleCollationKey: b.Title().CollationKey(),
+		priceAmount:       b.Price().Amount(),
+		stockId:           b.Stock().StockId().Value(),
+		quantityAvailable: b.Stock().QuantityAvailable().Value(),
+		status:            b.Stock().Status().Value().String(),
+		format:            b.Metadata().Format().String(),
+	}
+
+	m := b.Metadata()
+	for _, id := range m.AuthorIds() {
+		r.authorIds = append(r.authorIds, id.Value())
+	}
+	if m.PublisherId() != nil {
+		r.publisherId = m.PublisherId().Value()
+	}
+	if m.PublicationDate() != nil {
+		r.publicationDate = m.PublicationDate().String()
+	}
+	if m.Language() != nil {
+		r.language = m.Language().Code()
+	}
+	if m.PageCount() != nil {
+		r.pageCount = m.PageCount().Value()
+	}
+	if m.Edition() != nil {
+		r.edition = m.Edition().Value()
+	}
+	for _, id := range b.CategoryIds() {
+		r.categoryIds = append(r.categoryIds, id.Value())
+	}
+	return r
+}
+
+// toBook は保存された値から書籍集約を復元します。
+func (r bookRecord) toBook() (*book.Book, error) {
+	bookId, err := book.NewBookId(r.bookId)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "bookId").Wrap(err)
+	}
+	title, err := book.NewTitleWithSubtitle(r.title, r.subtitle)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "title").Wrap(err)
+	}
+	p, err := price.NewPrice(r.priceAmount, price.JPY)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "priceAmount").Wrap(err)
+	}
+	metadata, err := book.BuildMetadata(r.authorIds, r.publisherId, r.publicationDate, r.language, r.pageCount, r.format, r.edition)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "metadata").Wrap(err)
+	}
+	var categoryIds []*category.CategoryId
+	for _, v := range r.categoryIds {
+		id, err := category.NewCategoryId(v)
+		if err != nil {
+			return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "categoryId").Wrap(err)
+		}
+		categoryIds = append(categoryIds, id)
+	}
+	sId, err := stock_id.NewStockId(r.stockId)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "stockId").Wrap(err)
+	}
+	q, err := quantity_available.NewQuantityAvailable(r.quantityAvailable)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "quantityAvailable").Wrap(err)
+	}
+	st := status.NewStatus(status.ToStatusEnum(r.status))
+
+	return book.ReconstructBook(bookId, title, p, metadata, categoryIds, stock.Reconstruct(sId, q, st)), nil
+}
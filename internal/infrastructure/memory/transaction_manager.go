@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/tracing"
+	"fmt"
+)
+
+type key int
+
+const (
+	txKey key = iota
+)
+
+// txState はコンテキストに格納するトランザクションの状態です。
+type txState struct {
+	// tables はトランザクション内で変更中のデータです。コミット時にStoreへ反映します。
+	tables        *tables
+	opts          shared.TxOptions
+	afterCommit   []func()
+	afterRollback []func()
+}
+
+// runAfterRollback はfrom番目以降に登録されたロールバック後フックを実行します。
+func (s *txState) runAfterRollback(from int) {
+	for _, f := range s.afterRollback[from:] {
+		f()
+	}
+	s.afterRollback = s.afterRollback[:from]
+}
+
+func getTxState(ctx context.Context) *txState {
+	if state, ok := ctx.Value(txKey).(*txState); ok {
+		return state
+	}
+	return nil
+}
+
+// InMemoryTransactionManager はStoreの複製に対して変更を行い、コミット時に反映するトランザクションマネージャーです。
+// トランザクションは1つずつ実行されるため、分離レベルの指定に関わらずSERIALIZABLE相当の振る舞いになります。
+type InMemoryTransactionManager struct {
+	store *Store
+}
+
+// NewInMemoryTransactionManager は新しいInMemoryTransactionManagerを生成します。
+func NewInMemoryTransactionManager(store *Store) *InMemoryTransactionManager {
+	return &InMemoryTransactionManager{store: store}
+}
+
+// Begin はトランザクションを開始します。
+// コンテキストに既存のトランザクションがある場合は新たに開始せず、
+// PropagationRequiredであれば参加し、PropagationNestedであればセーブポイント相当の複製を作成します。
+func (tm *InMemoryTransactionManager) Begin(ctx context.Context, f func(ctx context.Context) error, opts ...shared.TxOption) (err error) {
+	o := shared.NewTxOptions(opts...)
+	current := getTxState(ctx)
+
+	ctx, span := tracing.Start(ctx, "InMemoryTransactionManager.Begin", tracing.WithAttrs(
+		"tx.in_tx", current != nil,
+		"tx.nested", current != nil && o.Propagation == shared.PropagationNested,
+		"tx.read_only", o.ReadOnly,
+	))
+	defer tracing.End(span, &err)
+
+	if current != nil {
+		return tm.beginNested(ctx, current, o, f)
+	}
+
+	tm.store.txMu.Lock()
+	defer tm.store.txMu.Unlock()
+
+	state := &txState{tables: tm.store.snapshot(), opts: o}
+	if err := f(context.WithValue(ctx, txKey, state)); err != nil {
+		state.runAfterRollback(0)
+		return err
+	}
+
+	if !o.ReadOnly {
+		tm.store.commit(state.tables)
+	}
+	for _, f := range state.afterCommit {
+		f()
+	}
+	return nil
+}
+
+// AfterCommit はコンテキストのトランザクションがコミットされた後に実行する関数を登録します。
+// トランザクション外で呼び出された場合は即座に実行します。
+func (tm *InMemoryTransactionManager) AfterCommit(ctx context.Context, f func()) {
+	state := getTxState(ctx)
+	if state == nil {
+		f()
+		return
+	}
+	state.afterCommit = append(state.afterCommit, f)
+}
+
+// AfterRollback はコンテキストのトランザクションがロールバックされた後に実行する関数を登録します。
+// トランザクション外で呼び出された場合は何もしません。
+func (tm *InMemoryTransactionManager) AfterRollback(ctx context.Context, f func()) {
+	if state := getTxState(ctx); state != nil {
+		state.afterRollback = append(state.afterRollback, f)
+	}
+}
+
+// beginNested は既存のトランザクション内で関数を実行します。
+func (tm *InMemoryTransactionManager) beginNested(ctx context.Context, state *txState, o shared.TxOptions, f func(ctx context.Context) error) error {
+	if o.Isolation != shared.IsolationDefault && o.Isolation != state.opts.Isolation {
+		return fmt.Errorf("トランザクションの開始に失敗しました: 既存のトランザクションと分離レベルが異なります")
+	}
+	if state.opts.ReadOnly && !o.ReadOnly {
+		return fmt.Errorf("トランザクションの開始に失敗しました: 読み取り専用のトランザクション内で書き込みトランザクションは開始できません")
+	}
+
+	if o.Propagation != shared.PropagationNested {
+		return f(ctx)
+	}
+
+	// セーブポイントまで戻した場合は、その間に登録されたフックも巻き戻す
+	savepoint := state.tables.clone()
+	commitHooks, rollbackHooks := len(state.afterCommit), len(state.afterRollback)
+
+	if err := f(ctx); err != nil {
+		state.tables = savepoint
+		state.afterCommit = state.afterCommit[:commitHooks]
+		state.runAfterRollback(rollbackHooks)
+		return err
+	}
+	return nil
+}
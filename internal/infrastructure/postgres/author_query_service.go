@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	appauthor "ddd-hands-on-go/internal/application/author"
+	"fmt"
+)
+
+// PostgresAuthorQueryService はPostgreSQLを使用したAuthorQueryServiceの実装です。
+type PostgresAuthorQueryService struct {
+	db *sql.DB
+}
+
+// NewPostgresAuthorQueryService は新しいPostgresAuthorQueryServiceを生成します。
+func NewPostgresAuthorQueryService(db *sql.DB) *PostgresAuthorQueryService {
+	return &PostgresAuthorQueryService{db: db}
+}
+
+// ListAuthors は著者を著者名順（同じ名前の場合はID順）に返します。
+// 名前はGoの文字列比較と同じ順序になるよう、バイト順（COLLATE "C"）で比較します。
+func (s *PostgresAuthorQueryService) ListAuthors(ctx context.Context, q appauthor.ListAuthorsQuery) ([]*appauthor.AuthorDTO, error) {
+	query := `
+		SELECT "authorId", "name"
+		FROM "Author"
+		ORDER BY "name" COLLATE "C", "authorId"::text
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := getExecutor(ctx, s.db).QueryContext(ctx, query, q.Limit, q.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("著者一覧の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	authors := make([]*appauthor.AuthorDTO, 0, q.Limit)
+	for rows.Next() {
+		var dto appauthor.AuthorDTO
+		if err := rows.Scan(&dto.ID, &dto.Name); err != nil {
+			return nil, fmt.Errorf("著者一覧の取得に失敗しました: %w", err)
+		}
+		authors = append(authors, &dto)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("著者一覧の取得に失敗しました: %w", err)
+	}
+	return authors, nil
+}
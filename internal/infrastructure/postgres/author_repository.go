@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"ddd-hands-on-go/internal/domain/model/author"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/metrics"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// foreignKeyViolationCode は外部キー制約違反のエラーコードです。
+const foreignKeyViolationCode pq.ErrorCode = "23503"
+
+// PostgresAuthorRepository はPostgreSQLを使用したAuthorRepositoryの実装です。
+type PostgresAuthorRepository struct {
+	db      *sql.DB
+	metrics *metrics.Metrics
+}
+
+// NewPostgresAuthorRepository は新しいPostgresAuthorRepositoryを生成します。
+// mには操作の所要時間を記録します。nilの場合は記録しません。
+func NewPostgresAuthorRepository(db *sql.DB, m *metrics.Metrics) *PostgresAuthorRepository {
+	return &PostgresAuthorRepository{db: db, metrics: m}
+}
+
+// Save は著者を保存(作成または更新)します。
+func (r *PostgresAuthorRepository) Save(ctx context.Context, a *author.Author) (err error) {
+	ctx, end := r.observe(ctx, "Save", "author_id", a.AuthorId().Value())
+	defer end(&err)
+
+	query := `
+		INSERT INTO "Author" ("authorId", "name")
+		VALUES ($1, $2)
+		ON CONFLICT ("authorId") DO UPDATE
+		SET "name" = $2
+	`
+	if _, err = getExecutor(ctx, r.db).ExecContext(ctx, query, a.AuthorId().Value(), a.Name().Value()); err != nil {
+		return fmt.Errorf("著者の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Find は指定されたIDの著者を検索します。見つからない場合はnilを返します。
+func (r *PostgresAuthorRepository) Find(ctx context.Context, authorId *author.AuthorId) (_ *author.Author, err error) {
+	ctx, end := r.observe(ctx, "Find", "author_id", authorId.Value())
+	defer end(&err)
+
+	query := `SELECT "authorId", "name" FROM "Author" WHERE "authorId" = $1`
+	a, err := scanAuthor(getExecutor(ctx, r.db).QueryRowContext(ctx, query, authorId.Value()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 見つからない
+		}
+		return nil, fmt.Errorf("著者の検索に失敗しました: %w", err)
+	}
+	return a, nil
+}
+
+// FindMany は指定された複数のIDの著者をまとめて検索します。
+func (r *PostgresAuthorRepository) FindMany(ctx context.Context, authorIds []*author.AuthorId) (_ []*author.Author, err error) {
+	ctx, end := r.observe(ctx, "FindMany", "count", len(authorIds))
+	defer end(&err)
+	if len(authorIds) == 0 {
+		return []*author.Author{}, nil
+	}
+
+	ids := make([]string, len(authorIds))
+	for i, id := range authorIds {
+		ids[i] = id.Value()
+	}
+
+	query := `SELECT "authorId", "name" FROM "Author" WHERE "authorId" = ANY($1::UUID[])`
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("著者の検索に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	authors := make([]*author.Author, 0, len(authorIds))
+	for rows.Next() {
+		a, err := scanAuthor(rows)
+		if err != nil {
+			return nil, fmt.Errorf("著者の検索に失敗しました: %w", err)
+		}
+		authors = append(authors, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("著者の検索に失敗しました: %w", err)
+	}
+	return authors, nil
+}
+
+// Delete は著者を削除します。著者を参照している書籍がある場合はKindConflictのエラーを返します。
+func (r *PostgresAuthorRepository) Delete(ctx context.Context, authorId *author.AuthorId) (err error) {
+	ctx, end := r.observe(ctx, "Delete", "author_id", authorId.Value())
+	defer end(&err)
+
+	query := `DELETE FROM "Author" WHERE "authorId" = $1`
+	if _, err = getExecutor(ctx, r.db).ExecContext(ctx, query, authorId.Value()); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+			return shared.NewDomainError(shared.KindConflict, i18n.MsgAuthorInUse, authorId.Value()).Wrap(err)
+		}
+		return fmt.Errorf("著者の削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// scanAuthor は"authorId"、"name"の順に取得した行からAuthorを復元します。
+func scanAuthor(row scanner) (*author.Author, error) {
+	var idStr, nameStr string
+	if err := row.Scan(&idStr, &nameStr); err != nil {
+		return nil, err
+	}
+
+	authorId, err := author.NewAuthorId(idStr)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "authorId").Wrap(err)
+	}
+	name, err := author.NewName(nameStr)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "name").Wrap(err)
+	}
+	return author.NewAuthor(authorId, name), nil
+}
+
+// observe はリポジトリの操作のスパンを開始し、スパンを格納したコンテキストと操作の終了時に呼び出す関数を返します（observeRepositoryを参照）。
+// 書籍のリポジトリと区別するため、メトリクスの操作名には"Author."を付けます。
+func (r *PostgresAuthorRepository) observe(ctx context.Context, op string, attrs ...any) (context.Context, func(err *error)) {
+	return observeRepository(ctx, r.metrics, "PostgresAuthorRepository", op, "Author."+op, attrs...)
+}
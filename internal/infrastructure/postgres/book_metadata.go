@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"database/sql"
+	appbook "ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/model/book"
+
+	"github.com/lib/pq"
+)
+
+// metadataColumns は書誌情報を復元するために取得する"Book"（別名b）の列です。metadataRow.destの順に対応します。
+// 著者は"BookAuthor"から記載順のIDの配列として取得します。
+const metadataColumns = `
+	ARRAY(SELECT ba."authorId"::text FROM "BookAuthor" ba WHERE ba."bookId" = b."bookId" ORDER BY ba."position"),
+	b."publisherId"::text,
+	to_char(b."publicationDate", 'YYYY-MM-DD'),
+	b."language",
+	b."pageCount",
+	b."format",
+	b."edition"`
+
+// metadataNameColumns は書誌情報が参照する著者名（記載順の配列）と出版社名の列です。metadataRow.nameDestの順に対応します。
+const metadataNameColumns = `
+	ARRAY(
+		SELECT a."name" FROM "BookAuthor" ba JOIN "Author" a ON a."authorId" = ba."authorId"
+		WHERE ba."bookId" = b."bookId" ORDER BY ba."position"
+	),
+	(SELECT p."name" FROM "Publisher" p WHERE p."publisherId" = b."publisherId")`
+
+// metadataRow は"Book"の書誌情報の列の値です。不明な項目はNULLです。
+type metadataRow struct {
+	authorIds       []string
+	publisherId     sql.NullString
+	publicationDate sql.NullString
+	language        sql.NullString
+	pageCount       sql.NullInt64
+	format          sql.NullString
+	edition         sql.NullInt64
+	// 参照用（metadataNameColumns）にのみ読み込みます。
+	authorNames   []string
+	publisherName sql.NullString
+}
+
+// newMetadataColumns は書誌情報を保存する列の値へ変換します。
+func newMetadataColumns(m *book.Metadata) metadataRow {
+	// nilのスライスはNULLとして渡されるため、著者が不明な場合も空の配列にする
+	r := metadataRow{authorIds: make([]string, 0, len(m.AuthorIds()))}
+	for _, id := range m.AuthorIds() {
+		r.authorIds = append(r.authorIds, id.Value())
+	}
+	if m.PublisherId() != nil {
+		r.publisherId = sql.NullString{String: m.PublisherId().Value(), Valid: true}
+	}
+	if m.PublicationDate() != nil {
+		r.publicationDate = sql.NullString{String: m.PublicationDate().String(), Valid: true}
+	}
+	if m.Language() != nil {
+		r.language = sql.NullString{String: m.Language().Code(), Valid: true}
+	}
+	if m.PageCount() != nil {
+		r.pageCount = sql.NullInt64{Int64: int64(m.PageCount().Value()), Valid: true}
+	}
+	if m.Format() != "" {
+		r.format = sql.NullString{String: m.Format().String(), Valid: true}
+	}
+	if m.Edition() != nil {
+		r.edition = sql.NullInt64{Int64: int64(m.Edition().Value()), Valid: true}
+	}
+	return r
+}
+
+// dest はmetadataColumnsの順に値を読み込む先を返します。
+func (r *metadataRow) dest() []interface{} {
+	return []interface{}{
+		pq.Array(&r.authorIds),
+		&r.publisherId,
+		&r.publicationDate,
+		&r.language,
+		&r.pageCount,
+		&r.format,
+		&r.edition,
+	}
+}
+
+// nameDest はmetadataNameColumnsの順に値を読み込む先を返します。
+func (r *metadataRow) nameDest() []interface{} {
+	return []interface{}{pq.Array(&r.authorNames), &r.publisherName}
+}
+
+// toMetadata は読み込んだ値から書誌情報を復元します。
+func (r *metadataRow) toMetadata() (*book.Metadata, error) {
+	return book.BuildMetadata(
+		r.authorIds,
+		r.publisherId.String,
+		r.publicationDate.String,
+		r.language.String,
+		int(r.pageCount.Int64),
+		r.format.String,
+		int(r.edition.Int64),
+	)
+}
+
+// fillDTO は読み込んだ値をBookDTOの書誌情報の項目へ設定します。destとnameDestの両方で読み込んでいる必要があります。
+func (r *metadataRow) fillDTO(dto *appbook.BookDTO) {
+	for i, id := range r.authorIds {
+		a := appbook.BookAuthorDTO{ID: id}
+		if i < len(r.authorNames) {
+			a.Name = r.authorNames[i]
+		}
+		dto.Authors = append(dto.Authors, a)
+	}
+	if r.publisherId.Valid {
+		dto.Publisher = &appbook.BookPublisherDTO{ID: r.publisherId.String, Name: r.publisherName.String}
+	}
+	dto.PublicationDate = r.publicationDate.String
+	dto.Language = r.language.String
+	dto.PageCount = int(r.pageCount.Int64)
+	dto.Format = r.format.String
+	dto.Edition = int(r.edition.Int64)
+}
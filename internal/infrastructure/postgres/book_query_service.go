@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	appbook "ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/model/book/stock/status"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// PostgresBookQueryService はPostgreSQLを使用したBookQueryServiceの実装です。
+type PostgresBookQueryService struct {
+	db *sql.DB
+}
+
+// NewPostgresBookQueryService は新しいPostgresBookQueryServiceを生成します。
+func NewPostgresBookQueryService(db *sql.DB) *PostgresBookQueryService {
+	return &PostgresBookQueryService{db: db}
+}
+
+// listBooksOrderBy は並び順ごとのORDER BY句です。
+// 照合キーはGoの文字列比較と同じ順序になるよう、バイト順（COLLATE "C"）で比較します。
+var listBooksOrderBy = map[appbook.ListBooksSort]string{
+	appbook.SortByISBN:  `b."bookId"`,
+	appbook.SortByTitle: `b."titleCollationKey" COLLATE "C", b."bookId"`,
+}
+
+// ListBooks は条件に一致する書籍をq.Sortの順に返します。
+func (s *PostgresBookQueryService) ListBooks(ctx context.Context, q appbook.ListBooksQuery) ([]*appbook.BookDTO, error) {
+	orderBy, ok := listBooksOrderBy[q.Sort]
+	if !ok {
+		orderBy = listBooksOrderBy[appbook.SortByISBN]
+	}
+	// カテゴリによる絞り込みでは、指定したカテゴリとその子孫のカテゴリ（"CategoryTree"）のいずれかが割り当てられた書籍を返す
+	query := `
+		WITH RECURSIVE "CategoryTree" ("categoryId") AS (
+			SELECT c."categoryId" FROM "Category" c WHERE c."categoryId" = $5::UUID
+			UNION
+			SELECT c."categoryId" FROM "Category" c JOIN "CategoryTree" t ON c."parentId" = t."categoryId"
+		)
+		SELECT` + bookDTOColumns + `
+		FROM "Book" b
+		JOIN "Stock" s ON b."bookId" = s."bookId"
+		WHERE ($1 = '' OR s."status" = $1)
+		  AND ($2::INTEGER IS NULL OR s."quantityAvailable" <= $2)
+		  AND ($5::UUID IS NULL OR EXISTS (
+			SELECT 1 FROM "BookCategory" bc JOIN "CategoryTree" t ON bc."categoryId" = t."categoryId"
+			WHERE bc."bookId" = b."bookId"
+		  ))
+		ORDER BY ` + orderBy + `
+		LIMIT $3 OFFSET $4
+	`
+
+	var maxQuantity sql.NullInt64
+	if q.MaxQuantityAvailable != nil {
+		maxQuantity = sql.NullInt64{Int64: int64(*q.MaxQuantityAvailable), Valid: true}
+	}
+	categoryId := sql.NullString{String: q.CategoryId, Valid: q.CategoryId != ""}
+
+	rows, err := getExecutor(ctx, s.db).QueryContext(ctx, query, q.Status, maxQuantity, q.Limit, q.Offset, categoryId)
+	if err != nil {
+		return nil, fmt.Errorf("書籍一覧の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	books := make([]*appbook.BookDTO, 0, q.Limit)
+	for rows.Next() {
+		dto, err := scanBookDTO(rows)
+		if err != nil {
+			return nil, fmt.Errorf("書籍一覧の取得に失敗しました: %w", err)
+		}
+		books = append(books, dto)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("書籍一覧の取得に失敗しました: %w", err)
+	}
+	return books, nil
+}
+
+// EachBook は全ての書籍をISBN順に1件ずつfへ渡します。
+// 結果は1行ずつ読み込むため、件数に関わらずメモリ使用量は一定です。
+func (s *PostgresBookQueryService) EachBook(ctx context.Context, f func(*appbook.BookDTO) error) error {
+	query := `
+		SELECT` + bookDTOColumns + `
+		FROM "Book" b
+		JOIN "Stock" s ON b."bookId" = s."bookId"
+		ORDER BY b."bookId"
+	`
+
+	rows, err := getExecutor(ctx, s.db).QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("書籍の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		dto, err := scanBookDTO(rows)
+		if err != nil {
+			return fmt.Errorf("書籍の取得に失敗しました: %w", err)
+		}
+		if err := f(dto); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("書籍の取得に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// bookDTOColumns はBookDTOを生成するために取得する列です。
+const bookDTOColumns = `
+	b."bookId",
+	b."title",
+	b."subtitle",
+	b."priceAmount",` + metadataColumns + `,` + metadataNameColumns + `,` + categoryColumns + `,
+	s."quantityAvailable",
+	s."status"
+`
+
+// categoryColumns は書籍に割り当てたカテゴリのIDと名前の配列の列です。
+// BookDTOAssemblerと同じく、カテゴリ名順（同じ名前の場合はID順）に取得します。
+const categoryColumns = `
+	ARRAY(
+		SELECT c."categoryId"::text FROM "BookCategory" bc JOIN "Category" c ON c."categoryId" = bc."categoryId"
+		WHERE bc."bookId" = b."bookId" ORDER BY c."name" COLLATE "C", c."categoryId"::text
+	),
+	ARRAY(
+		SELECT c."name" FROM "BookCategory" bc JOIN "Category" c ON c."categoryId" = bc."categoryId"
+		WHERE bc."bookId" = b."bookId" ORDER BY c."name" COLLATE "C", c."categoryId"::text
+	)`
+
+// scanBookDTO はbookDTOColumnsの順に取得した行からBookDTOを生成します。bookDTOColumnsに続けて取得した列はextraへ読み込みます。
+// 参照用のため、書誌情報は値オブジェクトとして検証せず、保存されている値をそのまま返します。
+func scanBookDTO(row scanner, extra ...interface{}) (*appbook.BookDTO, error) {
+	var dto appbook.BookDTO
+	var m metadataRow
+	dest := append([]interface{}{&dto.ISBN, &dto.Title, &dto.Subtitle, &dto.PriceAmount}, m.dest()...)
+	dest = append(dest, m.nameDest()...)
+	var categoryIds, categoryNames []string
+	dest = append(dest, pq.Array(&categoryIds), pq.Array(&categoryNames), &dto.QuantityAvailable, &dto.Status)
+	dest = append(dest, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	m.fillDTO(&dto)
+	for i, id := range categoryIds {
+		c := appbook.BookCategoryDTO{ID: id}
+		if i < len(categoryNames) {
+			c.Name = categoryNames[i]
+		}
+		dto.Categories = append(dto.Categories, c)
+	}
+	return &dto, nil
+}
+
+// CountBooksByStatus は在庫ステータスごとの書籍数を返します。書籍のない在庫ステータスは0件として含めます。
+func (s *PostgresBookQueryService) CountBooksByStatus(ctx context.Context) (map[string]int, error) {
+	query := `
+		SELECT s."status", COUNT(*)
+		FROM "Stock" s
+		GROUP BY s."status"
+	`
+
+	rows, err := getExecutor(ctx, s.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("在庫ステータスごとの書籍数の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	counts := emptyStatusCounts()
+	for rows.Next() {
+		var st string
+		var n int
+		if err := rows.Scan(&st, &n); err != nil {
+			return nil, fmt.Errorf("在庫ステータスごとの書籍数の取得に失敗しました: %w", err)
+		}
+		counts[st] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("在庫ステータスごとの書籍数の取得に失敗しました: %w", err)
+	}
+	return counts, nil
+}
+
+// emptyStatusCounts は全ての在庫ステータスを0件とした集計結果を返します。
+func emptyStatusCounts() map[string]int {
+	return map[string]int{
+		status.InStock.String():    0,
+		status.LowStock.String():   0,
+		status.OutOfStock.String(): 0,
+	}
+}
@@ -9,49 +9,93 @@ import (
 	"ddd-hands-on-go/internal/domain/model/book/stock/quantity_available"
 	"ddd-hands-on-go/internal/domain/model/book/stock/status"
 	"ddd-hands-on-go/internal/domain/model/book/stock/stock_id"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/metrics"
 	"fmt"
+
+	"github.com/lib/pq"
 )
 
 // PostgresBookRepository はPostgreSQLを使用したBookRepositoryの実装です。
 type PostgresBookRepository struct {
-	db *sql.DB
+	db      *sql.DB
+	metrics *metrics.Metrics
 }
 
 // NewPostgresBookRepository は新しいPostgresBookRepositoryを生成します。
-func NewPostgresBookRepository(db *sql.DB) *PostgresBookRepository {
-	return &PostgresBookRepository{db: db}
+// mには操作の所要時間を記録します。nilの場合は記録しません。
+func NewPostgresBookRepository(db *sql.DB, m *metrics.Metrics) *PostgresBookRepository {
+	return &PostgresBookRepository{db: db, metrics: m}
 }
 
 // Save は書籍情報を保存(作成または更新)します。
-func (r *PostgresBookRepository) Save(ctx context.Context, b *book.Book) error {
-	var executor interface {
-		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
-		QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
-	}
-
-	tx := GetTx(ctx)
-	if tx != nil {
-		executor = tx
-	} else {
-		executor = r.db
-	}
+func (r *PostgresBookRepository) Save(ctx context.Context, b *book.Book) (err error) {
+	ctx, end := r.observe(ctx, "Save", "isbn", b.BookId().Value())
+	defer end(&err)
+	executor := getExecutor(ctx, r.db)
 
 	// Bookの保存 (Upsert)
 	queryBook := `
-		INSERT INTO "Book" ("bookId", "title", "priceAmount")
-		VALUES ($1, $2, $3)
+		INSERT INTO "Book" (
+			"bookId", "title", "subtitle", "titleCollationKey", "priceAmount",
+			"publisherId", "publicationDate", "language", "pageCount", "format", "edition"
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		ON CONFLICT ("bookId") DO UPDATE
-		SET "title" = $2, "priceAmount" = $3
+		SET "title" = $2, "subtitle" = $3, "titleCollationKey" = $4, "priceAmount" = $5,
+			"publisherId" = $6, "publicationDate" = $7, "language" = $8,
+			"pageCount" = $9, "format" = $10, "edition" = $11
 	`
-	_, err := executor.ExecContext(ctx, queryBook,
+	m := newMetadataColumns(b.Metadata())
+	_, err = executor.ExecContext(ctx, queryBook,
 		b.BookId().Value(),
 		b.Title().Value(),
+		b.Title().Subtitle(),
+		b.Title().CollationKey(),
 		b.Price().Amount(),
+		m.publisherId,
+		m.publicationDate,
+		m.language,
+		m.pageCount,
+		m.format,
+		m.edition,
 	)
 	if err != nil {
 		return fmt.Errorf("書籍の保存に失敗しました: %w", err)
 	}
 
+	// 著者の保存。記載順を"position"に保存するため、全て削除してから登録し直す
+	if _, err = executor.ExecContext(ctx, `DELETE FROM "BookAuthor" WHERE "bookId" = $1`, b.BookId().Value()); err != nil {
+		return fmt.Errorf("書籍の著者の保存に失敗しました: %w", err)
+	}
+	queryAuthors := `
+		INSERT INTO "BookAuthor" ("bookId", "position", "authorId")
+		SELECT $1, a."position", a."authorId"
+		FROM unnest($2::UUID[]) WITH ORDINALITY AS a("authorId", "position")
+	`
+	if _, err = executor.ExecContext(ctx, queryAuthors, b.BookId().Value(), pq.Array(m.authorIds)); err != nil {
+		return fmt.Errorf("書籍の著者の保存に失敗しました: %w", err)
+	}
+
+	// カテゴリの保存。割り当ての順序は意味を持たないため、全て削除してから登録し直す
+	if _, err = executor.ExecContext(ctx, `DELETE FROM "BookCategory" WHERE "bookId" = $1`, b.BookId().Value()); err != nil {
+		return fmt.Errorf("書籍のカテゴリの保存に失敗しました: %w", err)
+	}
+	categoryIds := make([]string, 0, len(b.CategoryIds()))
+	for _, id := range b.CategoryIds() {
+		categoryIds = append(categoryIds, id.Value())
+	}
+	queryCategories := `
+		INSERT INTO "BookCategory" ("bookId", "categoryId")
+		SELECT $1, c."categoryId"
+		FROM unnest($2::UUID[]) AS c("categoryId")
+	`
+	if _, err = executor.ExecContext(ctx, queryCategories, b.BookId().Value(), pq.Array(categoryIds)); err != nil {
+		return fmt.Errorf("書籍のカテゴリの保存に失敗しました: %w", err)
+	}
+
 	// Stockの保存 (Upsert)
 	queryStock := `
 		INSERT INTO "Stock" ("stockId", "bookId", "quantityAvailable", "status")
@@ -72,57 +116,192 @@ func (r *PostgresBookRepository) Save(ctx context.Context, b *book.Book) error {
 		return fmt.Errorf("在庫の保存に失敗しました: %w", err)
 	}
 
+	// 在庫の変更履歴の追記。まだ取り出されていない在庫数変更イベントを同じトランザクションで記録する
+	queryHistory := `
+		INSERT INTO "StockHistory" ("bookId", "delta", "quantityAvailable", "status", "occurredAt")
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	for _, event := range b.Events() {
+		changed, ok := event.(*book.StockQuantityChanged)
+		if !ok {
+			continue
+		}
+		_, err = executor.ExecContext(ctx, queryHistory,
+			changed.BookId, changed.Delta, changed.QuantityAvailable, changed.Status, changed.OccurredAt,
+		)
+		if err != nil {
+			return fmt.Errorf("在庫の変更履歴の保存に失敗しました: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// bookColumns は書籍と在庫を復元するために取得する列です。
+const bookColumns = `
+	b."bookId",
+	b."title",
+	b."subtitle",
+	b."priceAmount",` + metadataColumns + `,` + categoryIdsColumn + `,
+	s."stockId",
+	s."quantityAvailable",
+	s."status"
+`
+
+// categoryIdsColumn は書籍に割り当てたカテゴリのIDの配列の列です。割り当ての順序は意味を持たないため、ID順に取得します。
+const categoryIdsColumn = `
+	ARRAY(SELECT bc."categoryId"::text FROM "BookCategory" bc WHERE bc."bookId" = b."bookId" ORDER BY bc."categoryId")`
+
 // Find は指定されたIDの書籍を検索します。
-func (r *PostgresBookRepository) Find(ctx context.Context, bookId *book.BookId) (*book.Book, error) {
+func (r *PostgresBookRepository) Find(ctx context.Context, bookId *book.BookId) (_ *book.Book, err error) {
+	ctx, end := r.observe(ctx, "Find", "isbn", bookId.Value())
+	defer end(&err)
 	query := `
-		SELECT
-			b."title",
-			b."priceAmount",
-			s."stockId",
-			s."quantityAvailable",
-			s."status"
+		SELECT` + bookColumns + `
 		FROM "Book" b
 		LEFT JOIN "Stock" s ON b."bookId" = s."bookId"
 		WHERE b."bookId" = $1
 	`
 
-	row := r.db.QueryRowContext(ctx, query, bookId.Value())
+	row := getExecutor(ctx, r.db).QueryRowContext(ctx, query, bookId.Value())
 
+	b, err := scanBook(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 見つからない
+		}
+		return nil, fmt.Errorf("書籍の検索に失敗しました: %w", err)
+	}
+	return b, nil
+}
+
+// FindForUpdate は指定されたIDの書籍を検索し、トランザクションの終了まで在庫の行をロック（SELECT ... FOR UPDATE）します。
+// 同じ書籍をFindForUpdateで読み取る他のトランザクションは、ロックが解放されるまで待機し、コミット後の値を読み取ります。
+func (r *PostgresBookRepository) FindForUpdate(ctx context.Context, bookId *book.BookId) (_ *book.Book, err error) {
+	ctx, end := r.observe(ctx, "FindForUpdate", "isbn", bookId.Value())
+	defer end(&err)
+	// 外部結合のNULLになりうる側はロックできないため、在庫は内部結合する（書籍の保存時に必ず在庫も保存される）
+	query := `
+		SELECT` + bookColumns + `
+		FROM "Book" b
+		JOIN "Stock" s ON b."bookId" = s."bookId"
+		WHERE b."bookId" = $1
+		FOR UPDATE OF s
+	`
+
+	row := getExecutor(ctx, r.db).QueryRowContext(ctx, query, bookId.Value())
+
+	b, err := scanBook(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 見つからない
+		}
+		return nil, fmt.Errorf("書籍の検索に失敗しました: %w", err)
+	}
+	return b, nil
+}
+
+// FindMany は指定された複数のIDの書籍をまとめて検索します。
+func (r *PostgresBookRepository) FindMany(ctx context.Context, bookIds []*book.BookId) (_ []*book.Book, err error) {
+	ctx, end := r.observe(ctx, "FindMany", "count", len(bookIds))
+	defer end(&err)
+	if len(bookIds) == 0 {
+		return []*book.Book{}, nil
+	}
+
+	ids := make([]string, len(bookIds))
+	for i, id := range bookIds {
+		ids[i] = id.Value()
+	}
+
+	query := `
+		SELECT` + bookColumns + `
+		FROM "Book" b
+		LEFT JOIN "Stock" s ON b."bookId" = s."bookId"
+		WHERE b."bookId" = ANY($1)
+	`
+
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("書籍の検索に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	books := make([]*book.Book, 0, len(bookIds))
+	for rows.Next() {
+		b, err := scanBook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("書籍の検索に失敗しました: %w", err)
+		}
+		books = append(books, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("書籍の検索に失敗しました: %w", err)
+	}
+	return books, nil
+}
+
+// scanner は*sql.Rowと*sql.Rowsの共通インターフェースです。
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanBook はbookColumnsの順に取得した行からBookを復元します。
+func scanBook(row scanner) (*book.Book, error) {
+	var bookIdStr string
 	var titleStr string
+	var subtitleStr string
 	var priceAmount float64
+	var m metadataRow
+	var categoryIdStrs []string
 	var stockIdStr string
 	var quantityAvailableInt int
 	var statusStr string
 
-	if err := row.Scan(&titleStr, &priceAmount, &stockIdStr, &quantityAvailableInt, &statusStr); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil // 見つからない
-		}
-		return nil, fmt.Errorf("書籍の検索に失敗しました: %w", err)
+	dest := append([]interface{}{&bookIdStr, &titleStr, &subtitleStr, &priceAmount}, m.dest()...)
+	dest = append(dest, pq.Array(&categoryIdStrs), &stockIdStr, &quantityAvailableInt, &statusStr)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
 	}
 
-	title, err := book.NewTitle(titleStr)
+	bookId, err := book.NewBookId(bookIdStr)
 	if err != nil {
-		return nil, fmt.Errorf("データ整合性エラー: DB内のタイトルが不正です: %w", err)
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "bookId").Wrap(err)
+	}
+
+	title, err := book.NewTitleWithSubtitle(titleStr, subtitleStr)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "title").Wrap(err)
 	}
 
 	price, err := price.NewPrice(priceAmount, price.JPY)
 	if err != nil {
-		return nil, fmt.Errorf("データ整合性エラー: DB内の価格が不正です: %w", err)
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "priceAmount").Wrap(err)
+	}
+
+	metadata, err := m.toMetadata()
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "metadata").Wrap(err)
+	}
+
+	categoryIds := make([]*category.CategoryId, 0, len(categoryIdStrs))
+	for _, idStr := range categoryIdStrs {
+		id, err := category.NewCategoryId(idStr)
+		if err != nil {
+			return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "categoryId").Wrap(err)
+		}
+		categoryIds = append(categoryIds, id)
 	}
 
 	// Stockの再構築
 	sId, err := stock_id.NewStockId(stockIdStr)
 	if err != nil {
-		return nil, fmt.Errorf("データ整合性エラー: DB内の在庫IDが不正です: %w", err)
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "stockId").Wrap(err)
 	}
 
 	q, err := quantity_available.NewQuantityAvailable(quantityAvailableInt)
 	if err != nil {
-		return nil, fmt.Errorf("データ整合性エラー: DB内の在庫数が不正です: %w", err)
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "quantityAvailable").Wrap(err)
 	}
 
 	stEnum := status.ToStatusEnum(statusStr)
@@ -130,27 +309,25 @@ func (r *PostgresBookRepository) Find(ctx context.Context, bookId *book.BookId)
 
 	stk := stock.Reconstruct(sId, q, st)
 
-	return book.ReconstructBook(bookId, title, price, stk), nil
+	return book.ReconstructBook(bookId, title, price, metadata, categoryIds, stk), nil
 }
 
 // Delete は書籍を削除します。
-func (r *PostgresBookRepository) Delete(ctx context.Context, bookId *book.BookId) error {
-	var executor interface {
-		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
-	}
-
-	tx := GetTx(ctx)
-	if tx != nil {
-		executor = tx
-	} else {
-		executor = r.db
-	}
+func (r *PostgresBookRepository) Delete(ctx context.Context, bookId *book.BookId) (err error) {
+	ctx, end := r.observe(ctx, "Delete", "isbn", bookId.Value())
+	defer end(&err)
+	executor := getExecutor(ctx, r.db)
 
 	query := `DELETE FROM "Book" WHERE "bookId" = $1`
-	_, err := executor.ExecContext(ctx, query, bookId.Value())
+	_, err = executor.ExecContext(ctx, query, bookId.Value())
 	if err != nil {
 		return fmt.Errorf("書籍の削除に失敗しました: %w", err)
 	}
 
 	return nil
 }
+
+// observe はリポジトリの操作のスパンを開始し、スパンを格納したコンテキストと操作の終了時に呼び出す関数を返します（observeRepositoryを参照）。
+func (r *PostgresBookRepository) observe(ctx context.Context, op string, attrs ...any) (context.Context, func(err *error)) {
+	return observeRepository(ctx, r.metrics, "PostgresBookRepository", op, op, attrs...)
+}
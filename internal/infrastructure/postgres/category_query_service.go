@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	appcategory "ddd-hands-on-go/internal/application/category"
+	"fmt"
+)
+
+// PostgresCategoryQueryService はPostgreSQLを使用したCategoryQueryServiceの実装です。
+type PostgresCategoryQueryService struct {
+	db *sql.DB
+}
+
+// NewPostgresCategoryQueryService は新しいPostgresCategoryQueryServiceを生成します。
+func NewPostgresCategoryQueryService(db *sql.DB) *PostgresCategoryQueryService {
+	return &PostgresCategoryQueryService{db: db}
+}
+
+// ListCategories は全てのカテゴリをカテゴリ名順（同じ名前の場合はID順）に返します。
+// 名前はGoの文字列比較と同じ順序になるよう、バイト順（COLLATE "C"）で比較します。
+func (s *PostgresCategoryQueryService) ListCategories(ctx context.Context) ([]*appcategory.CategoryDTO, error) {
+	query := `
+		SELECT "categoryId", "name", COALESCE("parentId"::text, '')
+		FROM "Category"
+		ORDER BY "name" COLLATE "C", "categoryId"::text
+	`
+	rows, err := getExecutor(ctx, s.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("カテゴリ一覧の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []*appcategory.CategoryDTO{}
+	for rows.Next() {
+		var dto appcategory.CategoryDTO
+		if err := rows.Scan(&dto.ID, &dto.Name, &dto.ParentID); err != nil {
+			return nil, fmt.Errorf("カテゴリ一覧の取得に失敗しました: %w", err)
+		}
+		categories = append(categories, &dto)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("カテゴリ一覧の取得に失敗しました: %w", err)
+	}
+	return categories, nil
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/metrics"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// PostgresCategoryRepository はPostgreSQLを使用したCategoryRepositoryの実装です。
+type PostgresCategoryRepository struct {
+	db      *sql.DB
+	metrics *metrics.Metrics
+}
+
+// NewPostgresCategoryRepository は新しいPostgresCategoryRepositoryを生成します。
+// mには操作の所要時間を記録します。nilの場合は記録しません。
+func NewPostgresCategoryRepository(db *sql.DB, m *metrics.Metrics) *PostgresCategoryRepository {
+	return &PostgresCategoryRepository{db: db, metrics: m}
+}
+
+// Save はカテゴリを保存(作成または更新)します。
+func (r *PostgresCategoryRepository) Save(ctx context.Context, c *category.Category) (err error) {
+	ctx, end := r.observe(ctx, "Save", "category_id", c.CategoryId().Value())
+	defer end(&err)
+
+	query := `
+		INSERT INTO "Category" ("categoryId", "name", "parentId")
+		VALUES ($1, $2, $3)
+		ON CONFLICT ("categoryId") DO UPDATE
+		SET "name" = $2, "parentId" = $3
+	`
+	var parentId sql.NullString
+	if c.ParentId() != nil {
+		parentId = sql.NullString{String: c.ParentId().Value(), Valid: true}
+	}
+	if _, err = getExecutor(ctx, r.db).ExecContext(ctx, query, c.CategoryId().Value(), c.Name().Value(), parentId); err != nil {
+		return fmt.Errorf("カテゴリの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Find は指定されたIDのカテゴリを検索します。見つからない場合はnilを返します。
+func (r *PostgresCategoryRepository) Find(ctx context.Context, categoryId *category.CategoryId) (_ *category.Category, err error) {
+	ctx, end := r.observe(ctx, "Find", "category_id", categoryId.Value())
+	defer end(&err)
+
+	query := `SELECT "categoryId", "name", "parentId"::text FROM "Category" WHERE "categoryId" = $1`
+	c, err := scanCategory(getExecutor(ctx, r.db).QueryRowContext(ctx, query, categoryId.Value()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 見つからない
+		}
+		return nil, fmt.Errorf("カテゴリの検索に失敗しました: %w", err)
+	}
+	return c, nil
+}
+
+// FindMany は指定された複数のIDのカテゴリをまとめて検索します。
+func (r *PostgresCategoryRepository) FindMany(ctx context.Context, categoryIds []*category.CategoryId) (_ []*category.Category, err error) {
+	ctx, end := r.observe(ctx, "FindMany", "count", len(categoryIds))
+	defer end(&err)
+	if len(categoryIds) == 0 {
+		return []*category.Category{}, nil
+	}
+
+	ids := make([]string, len(categoryIds))
+	for i, id := range categoryIds {
+		ids[i] = id.Value()
+	}
+
+	query := `SELECT "categoryId", "name", "parentId"::text FROM "Category" WHERE "categoryId" = ANY($1::UUID[])`
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("カテゴリの検索に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	categories := make([]*category.Category, 0, len(categoryIds))
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("カテゴリの検索に失敗しました: %w", err)
+		}
+		categories = append(categories, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("カテゴリの検索に失敗しました: %w", err)
+	}
+	return categories, nil
+}
+
+// Delete はカテゴリを削除します。
+// 子カテゴリがある場合、またはカテゴリを割り当てられた書籍がある場合はKindConflictのエラーを返します。
+func (r *PostgresCategoryRepository) Delete(ctx context.Context, categoryId *category.CategoryId) (err error) {
+	ctx, end := r.observe(ctx, "Delete", "category_id", categoryId.Value())
+	defer end(&err)
+
+	query := `DELETE FROM "Category" WHERE "categoryId" = $1`
+	if _, err = getExecutor(ctx, r.db).ExecContext(ctx, query, categoryId.Value()); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+			// どちらの参照による違反かを制約名（0006_create_category.sqlを参照）で区別する
+			key := i18n.MsgCategoryInUse
+			if pqErr.Constraint == "fk_category_parent" {
+				key = i18n.MsgCategoryHasChildren
+			}
+			return shared.NewDomainError(shared.KindConflict, key, categoryId.Value()).Wrap(err)
+		}
+		return fmt.Errorf("カテゴリの削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// scanCategory は"categoryId"、"name"、"parentId"の順に取得した行からCategoryを復元します。
+func scanCategory(row scanner) (*category.Category, error) {
+	var idStr, nameStr string
+	var parentIdStr sql.NullString
+	if err := row.Scan(&idStr, &nameStr, &parentIdStr); err != nil {
+		return nil, err
+	}
+
+	categoryId, err := category.NewCategoryId(idStr)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "categoryId").Wrap(err)
+	}
+	name, err := category.NewName(nameStr)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "name").Wrap(err)
+	}
+	var parentId *category.CategoryId
+	if parentIdStr.Valid {
+		if parentId, err = category.NewCategoryId(parentIdStr.String); err != nil {
+			return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "parentId").Wrap(err)
+		}
+	}
+	c, err := category.NewCategory(categoryId, name, parentId)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "parentId").Wrap(err)
+	}
+	return c, nil
+}
+
+// observe はリポジトリの操作のスパンを開始し、スパンを格納したコンテキストと操作の終了時に呼び出す関数を返します（observeRepositoryを参照）。
+// 書籍のリポジトリと区別するため、メトリクスの操作名には"Category."を付けます。
+func (r *PostgresCategoryRepository) observe(ctx context.Context, op string, attrs ...any) (context.Context, func(err *error)) {
+	return observeRepository(ctx, r.metrics, "PostgresCategoryRepository", op, "Category."+op, attrs...)
+}
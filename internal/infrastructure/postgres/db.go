@@ -1,25 +1,72 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"ddd-hands-on-go/internal/logging"
+	"errors"
 	"fmt"
+	"time"
 
 	_ "github.com/lib/pq"
 )
 
-// NewDB はPostgreSQLデータベースへの接続を確立します。
-func NewDB(host, port, user, password, dbname string) (*sql.DB, error) {
-	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		host, port, user, password, dbname)
+// DBConfig はデータベース接続と接続プールの設定です。
+type DBConfig struct {
+	// DSN はlib/pqの接続文字列です（key=value形式またはURL形式）。
+	DSN string
+	// MaxOpenConns は接続プールの最大接続数です。0の場合は無制限です。
+	MaxOpenConns int
+	// MaxIdleConns は接続プールに保持するアイドル接続数です。
+	MaxIdleConns int
+	// ConnMaxLifetime は接続を再利用する最大時間です。0の場合は無制限です。
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime はアイドル状態の接続を保持する最大時間です。0の場合は無制限です。
+	ConnMaxIdleTime time.Duration
+	// ConnectRetry は起動時の接続確認（Ping）の再試行方針です。
+	// MaxAttemptsが1以下の場合は再試行しません。
+	ConnectRetry RetryPolicy
+}
 
-	db, err := sql.Open("postgres", connStr)
+// NewDB はPostgreSQLデータベースへの接続を確立します。
+// データベースがまだ起動していない場合に備え、接続確認はConnectRetryに従って再試行します。
+func NewDB(ctx context.Context, cfg DBConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN)
 	if err != nil {
 		return nil, fmt.Errorf("データベース接続のオープンに失敗しました: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("データベースへのPingに失敗しました: %w", err)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := pingWithRetry(ctx, db, cfg.ConnectRetry); err != nil {
+		db.Close()
+		return nil, err
 	}
 
 	return db, nil
 }
+
+// pingWithRetry は接続確認が成功するか、試行回数の上限に達するまでPingを繰り返します。
+func pingWithRetry(ctx context.Context, db *sql.DB, policy RetryPolicy) error {
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+		if attempt >= policy.MaxAttempts {
+			return fmt.Errorf("データベースへのPingに失敗しました (%d回試行): %w", attempt, err)
+		}
+
+		wait := policy.backoff(attempt)
+		logging.FromContext(ctx).Warn("データベースへのPingに失敗しました。再試行します",
+			"wait", wait, "attempt", attempt, "max_attempts", policy.MaxAttempts, "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("データベースへのPingに失敗しました: %w", errors.Join(err, ctx.Err()))
+		case <-time.After(wait):
+		}
+	}
+}
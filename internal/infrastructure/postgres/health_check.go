@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DBHealthCheck はデータベースに接続できるかを確認します。
+type DBHealthCheck struct {
+	db *sql.DB
+}
+
+// NewDBHealthCheck は新しいDBHealthCheckを生成します。
+func NewDBHealthCheck(db *sql.DB) *DBHealthCheck {
+	return &DBHealthCheck{db: db}
+}
+
+// Name は確認項目の名前を返します。
+func (c *DBHealthCheck) Name() string {
+	return "database"
+}
+
+// Check はデータベースへPingします。
+func (c *DBHealthCheck) Check(ctx context.Context) error {
+	if err := c.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("データベースへのPingに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// MigrationHealthCheck はアプリケーションが必要とするマイグレーションが適用済みかを確認します。
+type MigrationHealthCheck struct {
+	db *sql.DB
+}
+
+// NewMigrationHealthCheck は新しいMigrationHealthCheckを生成します。
+func NewMigrationHealthCheck(db *sql.DB) *MigrationHealthCheck {
+	return &MigrationHealthCheck{db: db}
+}
+
+// Name は確認項目の名前を返します。
+func (c *MigrationHealthCheck) Name() string {
+	return "migrations"
+}
+
+// Check は適用済みのバージョンが埋め込まれたマイグレーションの最新バージョン以上であることを確認します。
+// ローリングアップデート中は新しいバージョンのスキーマで旧バージョンが動作するため、適用済みのバージョンの方が新しい場合は正常とします。
+func (c *MigrationHealthCheck) Check(ctx context.Context) error {
+	latest, err := LatestMigrationVersion()
+	if err != nil {
+		return err
+	}
+	current, err := MigrationVersion(ctx, c.db)
+	if err != nil {
+		return err
+	}
+	if current < latest {
+		return fmt.Errorf("未適用のマイグレーションがあります (適用済み: %d, 最新: %d)", current, latest)
+	}
+	return nil
+}
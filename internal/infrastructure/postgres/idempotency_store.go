@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"ddd-hands-on-go/internal/application/idempotency"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PostgresIdempotencyStore はPostgreSQLを使用したidempotency.Storeの実装です。
+type PostgresIdempotencyStore struct {
+	db *sql.DB
+}
+
+// NewPostgresIdempotencyStore は新しいPostgresIdempotencyStoreを生成します。
+func NewPostgresIdempotencyStore(db *sql.DB) *PostgresIdempotencyStore {
+	return &PostgresIdempotencyStore{db: db}
+}
+
+// Reserve はキーを予約します。有効期限を過ぎた同じキーは新しい予約で置き換えます。
+// 同じキーを別のトランザクションが予約中の場合、INSERTはそのトランザクションの完了まで待機します。
+func (s *PostgresIdempotencyStore) Reserve(ctx context.Context, r *idempotency.Record) (*idempotency.Record, error) {
+	executor := getExecutor(ctx, s.db)
+
+	query := `
+		INSERT INTO "IdempotencyKey" ("actor", "key", "fingerprint", "createdAt", "expiresAt")
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT ("actor", "key") DO UPDATE
+		SET "fingerprint" = $3, "createdAt" = $4, "expiresAt" = $5,
+		    "statusCode" = NULL, "header" = NULL, "body" = NULL
+		WHERE "IdempotencyKey"."expiresAt" <= $4
+	`
+	result, err := executor.ExecContext(ctx, query, r.Actor, r.Key, r.Fingerprint, r.CreatedAt, r.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("冪等キーの予約に失敗しました: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("冪等キーの予約に失敗しました: %w", err)
+	}
+	if n == 1 {
+		return nil, nil
+	}
+	return s.find(ctx, executor, r.Actor, r.Key)
+}
+
+// find は保存された冪等キーを取得します。
+func (s *PostgresIdempotencyStore) find(ctx context.Context, executor executor, actor, key string) (*idempotency.Record, error) {
+	query := `
+		SELECT "fingerprint", "statusCode", "header", "body", "createdAt", "expiresAt"
+		FROM "IdempotencyKey"
+		WHERE "actor" = $1 AND "key" = $2
+	`
+	record := &idempotency.Record{Actor: actor, Key: key}
+	var statusCode sql.NullInt64
+	var header []byte
+	var body []byte
+	err := executor.QueryRowContext(ctx, query, actor, key).Scan(
+		&record.Fingerprint, &statusCode, &header, &body, &record.CreatedAt, &record.ExpiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("予約済みの冪等キーが見つかりません: %s", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("冪等キーの取得に失敗しました: %w", err)
+	}
+
+	if statusCode.Valid {
+		record.Response = &idempotency.Response{StatusCode: int(statusCode.Int64), Body: body}
+		if len(header) > 0 {
+			if err := json.Unmarshal(header, &record.Response.Header); err != nil {
+				return nil, fmt.Errorf("冪等キーのレスポンスヘッダーの読み込みに失敗しました: %w", err)
+			}
+		}
+	}
+	return record, nil
+}
+
+// Complete は予約したキーにレスポンスを保存します。
+func (s *PostgresIdempotencyStore) Complete(ctx context.Context, r *idempotency.Record) error {
+	header, err := json.Marshal(r.Response.Header)
+	if err != nil {
+		return fmt.Errorf("冪等キーのレスポンスヘッダーの変換に失敗しました: %w", err)
+	}
+
+	query := `
+		UPDATE "IdempotencyKey"
+		SET "statusCode" = $3, "header" = $4, "body" = $5
+		WHERE "actor" = $1 AND "key" = $2
+	`
+	_, err = getExecutor(ctx, s.db).ExecContext(ctx, query, r.Actor, r.Key, r.Response.StatusCode, string(header), r.Response.Body)
+	if err != nil {
+		return fmt.Errorf("冪等キーのレスポンスの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired は有効期限がnow以前のキーを削除します。
+func (s *PostgresIdempotencyStore) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := getExecutor(ctx, s.db).ExecContext(ctx, `DELETE FROM "IdempotencyKey" WHERE "expiresAt" <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("期限切れの冪等キーの削除に失敗しました: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("期限切れの冪等キーの削除に失敗しました: %w", err)
+	}
+	return int(n), nil
+}
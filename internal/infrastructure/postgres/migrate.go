@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockId はマイグレーションの同時実行を防ぐためのアドバイザリーロックのキーです。
+const migrationLockId = 7305001
+
+// Migration はスキーマのマイグレーション1件を表します。
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// Migrations は埋め込まれたマイグレーションをバージョン順に返します。
+// ファイル名は "0001_説明.sql" の形式である必要があります。
+func Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("マイグレーションの読み込みに失敗しました: %w", err)
+	}
+
+	migrations := make([]Migration, 0, len(entries))
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("マイグレーションのファイル名が不正です: %s", e.Name())
+		}
+
+		body, err := migrationFiles.ReadFile(path.Join("migrations", e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("マイグレーションの読み込みに失敗しました: %w", err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name, SQL: string(body)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// LatestMigrationVersion は埋め込まれたマイグレーションの最新バージョンを返します。
+func LatestMigrationVersion() (int, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return 0, err
+	}
+	if len(migrations) == 0 {
+		return 0, nil
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// MigrationVersion はデータベースに適用済みのマイグレーションの最新バージョンを返します。
+// 一度もマイグレーションが実行されていない場合は0を返します。
+func MigrationVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var exists bool
+	if err := db.QueryRowContext(ctx, `SELECT to_regclass('"schema_migrations"') IS NOT NULL`).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("マイグレーションバージョンの取得に失敗しました: %w", err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	var version int
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX("version"), 0) FROM "schema_migrations"`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("マイグレーションバージョンの取得に失敗しました: %w", err)
+	}
+	return version, nil
+}
+
+// Migrate は未適用のマイグレーションをバージョン順に適用し、適用したマイグレーションを返します。
+// 各マイグレーションは個別のトランザクションで適用されます。
+func Migrate(ctx context.Context, db *sql.DB) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	// アドバイザリーロックはセッション単位のため、同じ接続で取得・解放する
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("データベース接続の取得に失敗しました: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockId); err != nil {
+		return nil, fmt.Errorf("マイグレーションのロック取得に失敗しました: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockId) //nolint:errcheck // 接続のクローズでも解放される
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS "schema_migrations" (
+			"version" INTEGER PRIMARY KEY,
+			"name" VARCHAR(255) NOT NULL,
+			"appliedAt" TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`); err != nil {
+		return nil, fmt.Errorf("マイグレーション管理テーブルの作成に失敗しました: %w", err)
+	}
+
+	var current int
+	if err := conn.QueryRowContext(ctx, `SELECT COALESCE(MAX("version"), 0) FROM "schema_migrations"`).Scan(&current); err != nil {
+		return nil, fmt.Errorf("マイグレーションバージョンの取得に失敗しました: %w", err)
+	}
+
+	applied := make([]Migration, 0)
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := applyMigration(ctx, conn, m); err != nil {
+			return applied, err
+		}
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, m Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // コミット後のロールバックは何もしない
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return fmt.Errorf("マイグレーション %s の適用に失敗しました: %w", m.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO "schema_migrations" ("version", "name") VALUES ($1, $2)`, m.Version, m.Name); err != nil {
+		return fmt.Errorf("マイグレーション %s の記録に失敗しました: %w", m.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("マイグレーション %s のコミットに失敗しました: %w", m.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "IdempotencyKey" (
+    "actor" VARCHAR(255) NOT NULL,
+    "key" VARCHAR(255) NOT NULL,
+    "fingerprint" CHAR(64) NOT NULL,
+    "statusCode" INTEGER,
+    "header" JSONB,
+    "body" BYTEA,
+    "createdAt" TIMESTAMPTZ NOT NULL,
+    "expiresAt" TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY ("actor", "key")
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_key_expires_at ON "IdempotencyKey" ("expiresAt");
@@ -0,0 +1,20 @@
+-- 書籍の副題と、タイトル順の並べ替えに使用する照合キー（book.Title.CollationKey）を追加する
+ALTER TABLE "Book" ADD COLUMN IF NOT EXISTS "subtitle" VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE "Book" ADD COLUMN IF NOT EXISTS "titleCollationKey" TEXT NOT NULL DEFAULT '';
+
+-- 既存の書籍の照合キーをCollationKeyと同じ規則で生成する
+-- (NFKC正規化、小文字化、カタカナからひらがなへの変換、空白の圧縮、先頭の英語の冠詞の除去)
+UPDATE "Book"
+SET "titleCollationKey" = regexp_replace(
+    btrim(regexp_replace(
+        translate(
+            lower(normalize("title", NFKC)),
+            'ァアィイゥウェエォオカガキギクグケゲコゴサザシジスズセゼソゾタダチヂッツヅテデトドナニヌネノハバパヒビピフブプヘベペホボポマミムメモャヤュユョヨラリルレロヮワヰヱヲンヴヵヶ',
+            'ぁあぃいぅうぇえぉおかがきぎくぐけげこごさざしじすずせぜそぞただちぢっつづてでとどなにぬねのはばぱひびぴふぶぷへべぺほぼぽまみむめもゃやゅゆょよらりるれろゎわゐゑをんゔゕゖ'
+        ),
+        '\s+', ' ', 'g'
+    )),
+    '^(the|an|a) (.)', '\2'
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_title_collation_key ON "Book" ("titleCollationKey" COLLATE "C", "bookId");
@@ -0,0 +1,8 @@
+-- 書籍の書誌情報（book.Metadata）を追加する。不明な項目はNULL（著者は空の配列）とする
+ALTER TABLE "Book" ADD COLUMN IF NOT EXISTS "authors" TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE "Book" ADD COLUMN IF NOT EXISTS "publisher" VARCHAR(255);
+ALTER TABLE "Book" ADD COLUMN IF NOT EXISTS "publicationDate" DATE;
+ALTER TABLE "Book" ADD COLUMN IF NOT EXISTS "language" VARCHAR(3);
+ALTER TABLE "Book" ADD COLUMN IF NOT EXISTS "pageCount" INTEGER;
+ALTER TABLE "Book" ADD COLUMN IF NOT EXISTS "format" VARCHAR(20);
+ALTER TABLE "Book" ADD COLUMN IF NOT EXISTS "edition" INTEGER;
@@ -0,0 +1,54 @@
+-- 著者と出版社を独立した集約（"Author"、"Publisher"）とし、書籍からはIDで参照する
+CREATE TABLE IF NOT EXISTS "Author" (
+    "authorId" UUID PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS "Publisher" (
+    "publisherId" UUID PRIMARY KEY,
+    "name" VARCHAR(255) NOT NULL
+);
+
+-- 書籍の著者。"position"は記載順（1始まり、筆頭著者が1）
+-- 書籍から参照されている著者は削除できないよう、著者への外部キーはON DELETEを指定しない（RESTRICT相当）
+CREATE TABLE IF NOT EXISTS "BookAuthor" (
+    "bookId" VARCHAR(255) NOT NULL,
+    "position" INTEGER NOT NULL,
+    "authorId" UUID NOT NULL,
+    PRIMARY KEY ("bookId", "position"),
+    UNIQUE ("bookId", "authorId"),
+    CONSTRAINT fk_book
+        FOREIGN KEY ("bookId")
+        REFERENCES "Book" ("bookId")
+        ON DELETE CASCADE,
+    CONSTRAINT fk_author
+        FOREIGN KEY ("authorId")
+        REFERENCES "Author" ("authorId")
+);
+CREATE INDEX IF NOT EXISTS "BookAuthor_authorId_idx" ON "BookAuthor" ("authorId");
+
+ALTER TABLE "Book" ADD COLUMN IF NOT EXISTS "publisherId" UUID REFERENCES "Publisher" ("publisherId");
+CREATE INDEX IF NOT EXISTS "Book_publisherId_idx" ON "Book" ("publisherId");
+
+-- 既存の書籍に記録された著者名・出版社名から著者と出版社を作成し、同じ名前は同じ著者（出版社）とする
+INSERT INTO "Author" ("authorId", "name")
+SELECT gen_random_uuid(), a."name"
+FROM (SELECT DISTINCT unnest("authors") AS "name" FROM "Book") a;
+
+INSERT INTO "BookAuthor" ("bookId", "position", "authorId")
+SELECT b."bookId", a."position", au."authorId"
+FROM "Book" b
+CROSS JOIN LATERAL unnest(b."authors") WITH ORDINALITY AS a("name", "position")
+JOIN "Author" au ON au."name" = a."name";
+
+INSERT INTO "Publisher" ("publisherId", "name")
+SELECT gen_random_uuid(), p."name"
+FROM (SELECT DISTINCT "publisher" AS "name" FROM "Book" WHERE "publisher" IS NOT NULL) p;
+
+UPDATE "Book" b
+SET "publisherId" = p."publisherId"
+FROM "Publisher" p
+WHERE p."name" = b."publisher";
+
+ALTER TABLE "Book" DROP COLUMN IF EXISTS "authors";
+ALTER TABLE "Book" DROP COLUMN IF EXISTS "publisher";
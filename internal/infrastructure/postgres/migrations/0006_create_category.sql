@@ -0,0 +1,27 @@
+-- カテゴリ（ジャンル）を独立した集約（"Category"）とし、親カテゴリへの参照で階層を表す
+-- 子カテゴリのあるカテゴリは削除できないよう、親への外部キーはON DELETEを指定しない（RESTRICT相当）
+CREATE TABLE IF NOT EXISTS "Category" (
+    "categoryId" UUID PRIMARY KEY,
+    "name" VARCHAR(100) NOT NULL,
+    "parentId" UUID,
+    CONSTRAINT fk_category_parent
+        FOREIGN KEY ("parentId")
+        REFERENCES "Category" ("categoryId")
+);
+CREATE INDEX IF NOT EXISTS "Category_parentId_idx" ON "Category" ("parentId");
+
+-- 書籍に割り当てたカテゴリ。割り当ての順序は意味を持たない
+-- 書籍に割り当てられているカテゴリは削除できないよう、カテゴリへの外部キーはON DELETEを指定しない（RESTRICT相当）
+CREATE TABLE IF NOT EXISTS "BookCategory" (
+    "bookId" VARCHAR(255) NOT NULL,
+    "categoryId" UUID NOT NULL,
+    PRIMARY KEY ("bookId", "categoryId"),
+    CONSTRAINT fk_book
+        FOREIGN KEY ("bookId")
+        REFERENCES "Book" ("bookId")
+        ON DELETE CASCADE,
+    CONSTRAINT fk_book_category
+        FOREIGN KEY ("categoryId")
+        REFERENCES "Category" ("categoryId")
+);
+CREATE INDEX IF NOT EXISTS "BookCategory_categoryId_idx" ON "BookCategory" ("categoryId");
@@ -0,0 +1,23 @@
+-- 書籍の全文検索（PostgresSearchIndex）に使用する正規化の関数とインデックスを追加する
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- 検索語と検索対象の文字列を、NormalizeSearchText（internal/application/book/search_text.go）と同じ規則で正規化する
+-- (NFKC正規化、小文字化、カタカナからひらがなへの変換、空白の圧縮)
+CREATE OR REPLACE FUNCTION search_normalize(s TEXT) RETURNS TEXT
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+AS $$
+    SELECT regexp_replace(
+        btrim(translate(
+            lower(normalize(s, NFKC)),
+            'ァアィイゥウェエォオカガキギクグケゲコゴサザシジスズセゼソゾタダチヂッツヅテデトドナニヌネノハバパヒビピフブプヘベペホボポマミムメモャヤュユョヨラリルレロヮワヰヱヲンヴヵヶ',
+            'ぁあぃいぅうぇえぉおかがきぎくぐけげこごさざしじすずせぜそぞただちぢっつづてでとどなにぬねのはばぱひびぴふぶぷへべぺほぼぽまみむめもゃやゅゆょよらりるれろゎわゐゑをんゔゕゖ'
+        )),
+        '\s+', ' ', 'g'
+    )
+$$;
+
+-- 分かち書きのない日本語でも検索できるよう、検索語の部分一致（LIKE）をトライグラムのインデックスで絞り込む
+-- 3文字未満の検索語（日本語で多い2文字の語など）はトライグラムを作れないため、インデックス全体の走査になる
+CREATE INDEX IF NOT EXISTS "Book_search_trgm_idx" ON "Book" USING GIN (search_normalize("title" || ' ' || "subtitle") gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "Author_search_trgm_idx" ON "Author" USING GIN (search_normalize("name") gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "Publisher_search_trgm_idx" ON "Publisher" USING GIN (search_normalize("name") gin_trgm_ops);
@@ -0,0 +1,15 @@
+-- 在庫数の変更履歴。在庫数変更イベント（StockQuantityChanged）ごとに1行を追記する
+-- 書籍の削除時には履歴も削除する
+CREATE TABLE IF NOT EXISTS "StockHistory" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "bookId" VARCHAR(255) NOT NULL,
+    "delta" INTEGER NOT NULL,
+    "quantityAvailable" INTEGER NOT NULL,
+    "status" VARCHAR(50) NOT NULL,
+    "occurredAt" TIMESTAMPTZ NOT NULL,
+    CONSTRAINT fk_book
+        FOREIGN KEY ("bookId")
+        REFERENCES "Book" ("bookId")
+        ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "StockHistory_bookId_id_idx" ON "StockHistory" ("bookId", "id" DESC);
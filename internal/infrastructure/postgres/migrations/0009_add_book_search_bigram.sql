@@ -0,0 +1,19 @@
+-- 3文字未満の検索語（日本語で多い2文字の語や1文字の語）でもインデックスを使えるよう、1文字と2文字の部分文字列（バイグラム）のインデックスを追加する
+-- pg_bigm拡張は公式のPostgreSQLイメージに含まれないため、部分文字列の配列をGINインデックスで検索する
+
+-- 文字列に含まれる1文字と2文字の部分文字列の集合を返す。空白を含む部分文字列は検索語に現れないため含めない
+-- 検索対象の文字列sは search_normalize で正規化した値を渡す
+CREATE OR REPLACE FUNCTION search_grams(s TEXT) RETURNS TEXT[]
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+AS $$
+    SELECT COALESCE(array_agg(DISTINCT g), '{}')
+    FROM generate_series(1, char_length(s)) AS i
+    CROSS JOIN LATERAL (VALUES (substr(s, i, 1)), (substr(s, i, 2))) AS v (g)
+    WHERE g !~ '\s'
+$$;
+
+-- 検索語の部分文字列を全て含む行（@>）に絞り込み、部分一致（LIKE）で確認する
+-- 2文字以下の検索語は部分文字列の集合に検索語自体が含まれるため、インデックスだけで一致を判定できる
+CREATE INDEX IF NOT EXISTS "Book_search_gram_idx" ON "Book" USING GIN (search_grams(search_normalize("title" || ' ' || "subtitle")));
+CREATE INDEX IF NOT EXISTS "Author_search_gram_idx" ON "Author" USING GIN (search_grams(search_normalize("name")));
+CREATE INDEX IF NOT EXISTS "Publisher_search_gram_idx" ON "Publisher" USING GIN (search_grams(search_normalize("name")));
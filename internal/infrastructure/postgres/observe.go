@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/metrics"
+	"ddd-hands-on-go/internal/tracing"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// observeRepository はリポジトリの操作のスパン（repository.op）を開始し、スパンを格納したコンテキストと操作の終了時に呼び出す関数を返します。
+// 終了時の関数はスパンを終了し、所要時間をmetricOpとしてメトリクスに記録して、コンテキストのロガー（リクエストIDを含む）へデバッグレベルで出力します。
+// 終了時の関数はdeferで呼び出し、名前付きの返り値のエラーへのポインタを渡します。
+func observeRepository(ctx context.Context, m *metrics.Metrics, repository, op, metricOp string, attrs ...any) (context.Context, func(err *error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, repository+"."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(tracing.Attrs(attrs...),
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", op),
+		)...),
+	)
+
+	return ctx, func(err *error) {
+		tracing.End(span, err)
+		duration := time.Since(start)
+		m.ObserveQuery(metricOp, duration, *err)
+
+		logger := logging.FromContext(ctx)
+		if !logger.Enabled(ctx, slog.LevelDebug) {
+			return
+		}
+		attrs = append(attrs, "op", metricOp, "duration", duration, "in_tx", GetTx(ctx) != nil)
+		if *err != nil {
+			attrs = append(attrs, "error", *err)
+		}
+		logger.DebugContext(ctx, "リポジトリを操作しました", attrs...)
+	}
+}
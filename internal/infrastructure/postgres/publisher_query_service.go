@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	apppublisher "ddd-hands-on-go/internal/application/publisher"
+	"fmt"
+)
+
+// PostgresPublisherQueryService はPostgreSQLを使用したPublisherQueryServiceの実装です。
+type PostgresPublisherQueryService struct {
+	db *sql.DB
+}
+
+// NewPostgresPublisherQueryService は新しいPostgresPublisherQueryServiceを生成します。
+func NewPostgresPublisherQueryService(db *sql.DB) *PostgresPublisherQueryService {
+	return &PostgresPublisherQueryService{db: db}
+}
+
+// ListPublishers は出版社を出版社名順（同じ名前の場合はID順）に返します。
+// 名前はGoの文字列比較と同じ順序になるよう、バイト順（COLLATE "C"）で比較します。
+func (s *PostgresPublisherQueryService) ListPublishers(ctx context.Context, q apppublisher.ListPublishersQuery) ([]*apppublisher.PublisherDTO, error) {
+	query := `
+		SELECT "publisherId", "name"
+		FROM "Publisher"
+		ORDER BY "name" COLLATE "C", "publisherId"::text
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := getExecutor(ctx, s.db).QueryContext(ctx, query, q.Limit, q.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("出版社一覧の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	publishers := make([]*apppublisher.PublisherDTO, 0, q.Limit)
+	for rows.Next() {
+		var dto apppublisher.PublisherDTO
+		if err := rows.Scan(&dto.ID, &dto.Name); err != nil {
+			return nil, fmt.Errorf("出版社一覧の取得に失敗しました: %w", err)
+		}
+		publishers = append(publishers, &dto)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("出版社一覧の取得に失敗しました: %w", err)
+	}
+	return publishers, nil
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/metrics"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// PostgresPublisherRepository はPostgreSQLを使用したPublisherRepositoryの実装です。
+type PostgresPublisherRepository struct {
+	db      *sql.DB
+	metrics *metrics.Metrics
+}
+
+// NewPostgresPublisherRepository は新しいPostgresPublisherRepositoryを生成します。
+// mには操作の所要時間を記録します。nilの場合は記録しません。
+func NewPostgresPublisherRepository(db *sql.DB, m *metrics.Metrics) *PostgresPublisherRepository {
+	return &PostgresPublisherRepository{db: db, metrics: m}
+}
+
+// Save は出版社を保存(作成または更新)します。
+func (r *PostgresPublisherRepository) Save(ctx context.Context, p *publisher.Publisher) (err error) {
+	ctx, end := r.observe(ctx, "Save", "publisher_id", p.PublisherId().Value())
+	defer end(&err)
+
+	query := `
+		INSERT INTO "Publisher" ("publisherId", "name")
+		VALUES ($1, $2)
+		ON CONFLICT ("publisherId") DO UPDATE
+		SET "name" = $2
+	`
+	if _, err = getExecutor(ctx, r.db).ExecContext(ctx, query, p.PublisherId().Value(), p.Name().Value()); err != nil {
+		return fmt.Errorf("出版社の保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Find は指定されたIDの出版社を検索します。見つからない場合はnilを返します。
+func (r *PostgresPublisherRepository) Find(ctx context.Context, publisherId *publisher.PublisherId) (_ *publisher.Publisher, err error) {
+	ctx, end := r.observe(ctx, "Find", "publisher_id", publisherId.Value())
+	defer end(&err)
+
+	query := `SELECT "publisherId", "name" FROM "Publisher" WHERE "publisherId" = $1`
+	p, err := scanPublisher(getExecutor(ctx, r.db).QueryRowContext(ctx, query, publisherId.Value()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // 見つからない
+		}
+		return nil, fmt.Errorf("出版社の検索に失敗しました: %w", err)
+	}
+	return p, nil
+}
+
+// FindMany は指定された複数のIDの出版社をまとめて検索します。
+func (r *PostgresPublisherRepository) FindMany(ctx context.Context, publisherIds []*publisher.PublisherId) (_ []*publisher.Publisher, err error) {
+	ctx, end := r.observe(ctx, "FindMany", "count", len(publisherIds))
+	defer end(&err)
+	if len(publisherIds) == 0 {
+		return []*publisher.Publisher{}, nil
+	}
+
+	ids := make([]string, len(publisherIds))
+	for i, id := range publisherIds {
+		ids[i] = id.Value()
+	}
+
+	query := `SELECT "publisherId", "name" FROM "Publisher" WHERE "publisherId" = ANY($1::UUID[])`
+	rows, err := getExecutor(ctx, r.db).QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("出版社の検索に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	publishers := make([]*publisher.Publisher, 0, len(publisherIds))
+	for rows.Next() {
+		p, err := scanPublisher(rows)
+		if err != nil {
+			return nil, fmt.Errorf("出版社の検索に失敗しました: %w", err)
+		}
+		publishers = append(publishers, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("出版社の検索に失敗しました: %w", err)
+	}
+	return publishers, nil
+}
+
+// Delete は出版社を削除します。出版社を参照している書籍がある場合はKindConflictのエラーを返します。
+func (r *PostgresPublisherRepository) Delete(ctx context.Context, publisherId *publisher.PublisherId) (err error) {
+	ctx, end := r.observe(ctx, "Delete", "publisher_id", publisherId.Value())
+	defer end(&err)
+
+	query := `DELETE FROM "Publisher" WHERE "publisherId" = $1`
+	if _, err = getExecutor(ctx, r.db).ExecContext(ctx, query, publisherId.Value()); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode {
+			return shared.NewDomainError(shared.KindConflict, i18n.MsgPublisherInUse, publisherId.Value()).Wrap(err)
+		}
+		return fmt.Errorf("出版社の削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// scanPublisher は"publisherId"、"name"の順に取得した行からPublisherを復元します。
+func scanPublisher(row scanner) (*publisher.Publisher, error) {
+	var idStr, nameStr string
+	if err := row.Scan(&idStr, &nameStr); err != nil {
+		return nil, err
+	}
+
+	publisherId, err := publisher.NewPublisherId(idStr)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "publisherId").Wrap(err)
+	}
+	name, err := publisher.NewName(nameStr)
+	if err != nil {
+		return nil, shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "name").Wrap(err)
+	}
+	return publisher.NewPublisher(publisherId, name), nil
+}
+
+// observe はリポジトリの操作のスパンを開始し、スパンを格納したコンテキストと操作の終了時に呼び出す関数を返します（observeRepositoryを参照）。
+// 書籍のリポジトリと区別するため、メトリクスの操作名には"Publisher."を付けます。
+func (r *PostgresPublisherRepository) observe(ctx context.Context, op string, attrs ...any) (context.Context, func(err *error)) {
+	return observeRepository(ctx, r.metrics, "PostgresPublisherRepository", op, "Publisher."+op, attrs...)
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	appbook "ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/metrics"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// PostgresSearchIndex はPostgreSQLの全文検索と、トライグラム（pg_trgm）・バイグラムのインデックスを使用したSearchIndexの実装です。
+type PostgresSearchIndex struct {
+	db      *sql.DB
+	metrics *metrics.Metrics
+}
+
+// NewPostgresSearchIndex は新しいPostgresSearchIndexを生成します。
+// mには操作の所要時間を記録します。nilの場合は記録しません。
+func NewPostgresSearchIndex(db *sql.DB, m *metrics.Metrics) *PostgresSearchIndex {
+	return &PostgresSearchIndex{db: db, metrics: m}
+}
+
+// Search はtermsの全てを、タイトル（副題を含む）・著者名・出版社名のいずれかに含む書籍を関連度の高い順（同じ場合はISBN順）に返します。
+//
+// 一致の判定は search_normalize（0007_add_book_search.sql）で正規化した文字列の部分一致で、分かち書きのない日本語にも一致します。
+// 部分一致は、検索語の1文字と2文字の部分文字列を全て含む行へバイグラムのインデックス（search_grams、0009_add_book_search_bigram.sql）で
+// 絞り込んでから判定するため、3文字未満の検索語でもインデックスを使用します。
+// 関連度は、検索語ごとに含む項目の重み（タイトル1.0、著者名0.6、出版社名0.3）と、全文検索の順位（ts_rank）の合計です。
+// 全文検索は空白で区切られた語単位で一致するため、語全体が一致する書籍ほど上位になります。
+func (s *PostgresSearchIndex) Search(ctx context.Context, terms []string, limit, offset int) (_ []*appbook.BookSearchResult, err error) {
+	ctx, end := observeRepository(ctx, s.metrics, "PostgresSearchIndex", "Search", "Search", "terms", len(terms))
+	defer end(&err)
+
+	query := `
+		WITH "Term" ("term", "pattern", "grams") AS (
+			SELECT t, '%' || replace(replace(replace(t, '\', '\\'), '%', '\%'), '_', '\_') || '%', search_grams(t)
+			FROM unnest($1::TEXT[]) AS t
+		),
+		-- 検索語ごとに、検索語を含むタイトル・著者名・出版社名の書籍を探す
+		-- （バイグラムのインデックスで絞り込み、3文字以上の検索語はトライグラムのインデックスでも絞り込まれる）
+		"Match" ("term", "bookId") AS (
+			SELECT t."term", b."bookId"
+			FROM "Term" t
+			JOIN "Book" b ON search_grams(search_normalize(b."title" || ' ' || b."subtitle")) @> t."grams"
+				AND search_normalize(b."title" || ' ' || b."subtitle") LIKE t."pattern"
+			UNION
+			SELECT t."term", ba."bookId"
+			FROM "Term" t
+			JOIN "Author" a ON search_grams(search_normalize(a."name")) @> t."grams"
+				AND search_normalize(a."name") LIKE t."pattern"
+			JOIN "BookAuthor" ba ON ba."authorId" = a."authorId"
+			UNION
+			SELECT t."term", b."bookId"
+			FROM "Term" t
+			JOIN "Publisher" p ON search_grams(search_normalize(p."name")) @> t."grams"
+				AND search_normalize(p."name") LIKE t."pattern"
+			JOIN "Book" b ON b."publisherId" = p."publisherId"
+		),
+		"Hit" ("bookId") AS (
+			SELECT "bookId" FROM "Match"
+			GROUP BY "bookId"
+			HAVING COUNT(*) = cardinality($1::TEXT[])
+		)
+		SELECT` + bookDTOColumns + `, r."score"
+		FROM "Hit" h
+		JOIN "Book" b ON b."bookId" = h."bookId"
+		JOIN "Stock" s ON b."bookId" = s."bookId"
+		CROSS JOIN LATERAL (
+			SELECT
+				search_normalize(b."title" || ' ' || b."subtitle") AS "title",
+				COALESCE(search_normalize((
+					SELECT string_agg(a."name", ' ' ORDER BY ba."position")
+					FROM "BookAuthor" ba JOIN "Author" a ON a."authorId" = ba."authorId"
+					WHERE ba."bookId" = b."bookId"
+				)), '') AS "authors",
+				COALESCE(search_normalize((SELECT p."name" FROM "Publisher" p WHERE p."publisherId" = b."publisherId")), '') AS "publisher"
+		) d
+		CROSS JOIN LATERAL (
+			SELECT SUM(
+				CASE WHEN strpos(d."title", t."term") > 0 THEN 1.0 ELSE 0 END
+				+ CASE WHEN strpos(d."authors", t."term") > 0 THEN 0.6 ELSE 0 END
+				+ CASE WHEN strpos(d."publisher", t."term") > 0 THEN 0.3 ELSE 0 END
+				+ ts_rank(
+					setweight(to_tsvector('simple', d."title"), 'A')
+						|| setweight(to_tsvector('simple', d."authors"), 'B')
+						|| setweight(to_tsvector('simple', d."publisher"), 'C'),
+					plainto_tsquery('simple', t."term")
+				)
+			)::DOUBLE PRECISION AS "score"
+			FROM "Term" t
+		) r
+		ORDER BY r."score" DESC, b."bookId"
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := getExecutor(ctx, s.db).QueryContext(ctx, query, pq.Array(terms), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("書籍の検索に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]*appbook.BookSearchResult, 0, limit)
+	for rows.Next() {
+		var r appbook.BookSearchResult
+		dto, err := scanBookDTO(rows, &r.Score)
+		if err != nil {
+			return nil, fmt.Errorf("書籍の検索に失敗しました: %w", err)
+		}
+		r.Book = dto
+		results = append(results, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("書籍の検索に失敗しました: %w", err)
+	}
+	return results, nil
+}
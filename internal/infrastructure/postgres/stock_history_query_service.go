@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	appbook "ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/metrics"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// PostgresStockHistoryQueryService はPostgreSQLを使用したStockHistoryQueryServiceの実装です。
+type PostgresStockHistoryQueryService struct {
+	db      *sql.DB
+	metrics *metrics.Metrics
+}
+
+// NewPostgresStockHistoryQueryService は新しいPostgresStockHistoryQueryServiceを生成します。
+// mには操作の所要時間を記録します。nilの場合は記録しません。
+func NewPostgresStockHistoryQueryService(db *sql.DB, m *metrics.Metrics) *PostgresStockHistoryQueryService {
+	return &PostgresStockHistoryQueryService{db: db, metrics: m}
+}
+
+// ListStockHistory は指定された書籍ごとに、新しい順に最大limit件の変更履歴を返します。
+// 書籍ごとの件数の制限には、書籍ごとに番号を振るウィンドウ関数（ROW_NUMBER）を使います。
+func (s *PostgresStockHistoryQueryService) ListStockHistory(ctx context.Context, isbns []string, limit int) (_ map[string][]*appbook.StockHistoryEntryDTO, err error) {
+	ctx, end := observeRepository(ctx, s.metrics, "PostgresStockHistoryQueryService", "ListStockHistory", "ListStockHistory", "count", len(isbns))
+	defer end(&err)
+
+	query := `
+		SELECT "bookId", "delta", "quantityAvailable", "status", "occurredAt"
+		FROM (
+			SELECT h.*, ROW_NUMBER() OVER (PARTITION BY h."bookId" ORDER BY h."id" DESC) AS "rank"
+			FROM "StockHistory" h
+			WHERE h."bookId" = ANY($1)
+		) ranked
+		WHERE "rank" <= $2
+		ORDER BY "bookId", "id" DESC
+	`
+	rows, err := getExecutor(ctx, s.db).QueryContext(ctx, query, pq.Array(isbns), limit)
+	if err != nil {
+		return nil, fmt.Errorf("在庫の変更履歴の取得に失敗しました: %w", err)
+	}
+	defer rows.Close()
+
+	history := make(map[string][]*appbook.StockHistoryEntryDTO)
+	for rows.Next() {
+		var isbn string
+		var entry appbook.StockHistoryEntryDTO
+		if err := rows.Scan(&isbn, &entry.Delta, &entry.QuantityAvailable, &entry.Status, &entry.OccurredAt); err != nil {
+			return nil, fmt.Errorf("在庫の変更履歴の取得に失敗しました: %w", err)
+		}
+		history[isbn] = append(history[isbn], &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("在庫の変更履歴の取得に失敗しました: %w", err)
+	}
+	return history, nil
+}
@@ -3,7 +3,17 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/logging"
+	"ddd-hands-on-go/internal/metrics"
+	"ddd-hands-on-go/internal/tracing"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type key int
@@ -12,44 +22,282 @@ const (
 	txKey key = iota
 )
 
+// PostgreSQLのエラーコード
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	serializationFailureCode pq.ErrorCode = "40001"
+	deadlockDetectedCode     pq.ErrorCode = "40P01"
+)
+
+// RetryPolicy はシリアライゼーション失敗やデッドロック時の再試行方針です。
+type RetryPolicy struct {
+	// MaxAttempts は最初の試行を含む最大試行回数です。1以下の場合は再試行しません。
+	MaxAttempts int
+	// InitialBackoff は最初の再試行までの待機時間です。以降は倍々で増加します。
+	InitialBackoff time.Duration
+	// MaxBackoff は待機時間の上限です。
+	MaxBackoff time.Duration
+	// Sleep は再試行の前にdだけ待機する関数です。待機中にctxが終了した場合はctxのエラーを返します。
+	// nilの場合はタイマーで待機します。テストで待機時間を記録する場合などに差し替えます。
+	Sleep func(ctx context.Context, d time.Duration) error
+}
+
+// DefaultRetryPolicy は既定の再試行方針です。
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 10 * time.Millisecond,
+	MaxBackoff:     200 * time.Millisecond,
+}
+
+// backoff はattempt回目（1始まり）の失敗後に待機する時間を返します。
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return d
+}
+
+// sleep はSleepが指定されていればSleepで、指定されていなければタイマーでdだけ待機します。
+func (p RetryPolicy) sleep(ctx context.Context, d time.Duration) error {
+	if p.Sleep != nil {
+		return p.Sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// txState はコンテキストに格納するトランザクションの状態です。
+type txState struct {
+	tx            *sql.Tx
+	opts          shared.TxOptions
+	savepoint     int // 発行済みセーブポイントの連番
+	afterCommit   []func()
+	afterRollback []func()
+}
+
+// runAfterRollback はfrom番目以降に登録されたロールバック後フックを実行します。
+func (s *txState) runAfterRollback(from int) {
+	for _, f := range s.afterRollback[from:] {
+		f()
+	}
+	s.afterRollback = s.afterRollback[:from]
+}
+
 // PostgresTransactionManager はPostgreSQL用のトランザクションマネージャー実装です。
 type PostgresTransactionManager struct {
-	db *sql.DB
+	db          *sql.DB
+	retryPolicy RetryPolicy
+	metrics     *metrics.Metrics
 }
 
 // NewPostgresTransactionManager は新しいPostgresTransactionManagerを生成します。
-func NewPostgresTransactionManager(db *sql.DB) *PostgresTransactionManager {
-	return &PostgresTransactionManager{db: db}
+// mには最上位のトランザクションのコミット・ロールバックの件数を記録します。nilの場合は記録しません。
+func NewPostgresTransactionManager(db *sql.DB, m *metrics.Metrics) *PostgresTransactionManager {
+	return &PostgresTransactionManager{db: db, retryPolicy: DefaultRetryPolicy, metrics: m}
+}
+
+// WithRetryPolicy は再試行方針を差し替えたPostgresTransactionManagerを返します。
+func (tm *PostgresTransactionManager) WithRetryPolicy(p RetryPolicy) *PostgresTransactionManager {
+	return &PostgresTransactionManager{db: tm.db, retryPolicy: p, metrics: tm.metrics}
 }
 
 // Begin はトランザクションを開始します。
-func (tm *PostgresTransactionManager) Begin(ctx context.Context, f func(ctx context.Context) error) error {
-	tx, err := tm.db.BeginTx(ctx, nil)
+// コンテキストに既存のトランザクションがある場合は新たに開始せず、
+// PropagationRequiredであれば参加し、PropagationNestedであればセーブポイントを作成します。
+// 最上位のトランザクションがシリアライゼーション失敗またはデッドロックで失敗した場合は、
+// RetryPolicyに従って関数ごと再実行します。
+func (tm *PostgresTransactionManager) Begin(ctx context.Context, f func(ctx context.Context) error, opts ...shared.TxOption) (err error) {
+	o := shared.NewTxOptions(opts...)
+	state := getTxState(ctx)
+
+	ctx, span := tracing.Start(ctx, "PostgresTransactionManager.Begin", trace.WithAttributes(txAttributes(o, state != nil)...))
+	defer tracing.End(span, &err)
+
+	if state != nil {
+		return tm.beginNested(ctx, state, o, f)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := tm.beginTx(ctx, o, f)
+		if err == nil || !isRetryable(err) || attempt >= tm.retryPolicy.MaxAttempts {
+			return err
+		}
+		logging.FromContext(ctx).Warn("トランザクションを再試行します",
+			"attempt", attempt, "max_attempts", tm.retryPolicy.MaxAttempts, "error", err)
+		span.AddEvent("retry", trace.WithAttributes(attribute.Int("tx.attempt", attempt), attribute.String("error", err.Error())))
+
+		if sleepErr := tm.retryPolicy.sleep(ctx, tm.retryPolicy.backoff(attempt)); sleepErr != nil {
+			return errors.Join(err, sleepErr)
+		}
+	}
+}
+
+// beginTx は新しいトランザクションを開始し、関数を1回実行します。
+func (tm *PostgresTransactionManager) beginTx(ctx context.Context, o shared.TxOptions, f func(ctx context.Context) error) error {
+	tx, err := tm.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: toSQLIsolation(o.Isolation),
+		ReadOnly:  o.ReadOnly,
+	})
 	if err != nil {
 		return fmt.Errorf("トランザクションの開始に失敗しました: %w", err)
 	}
 
 	// トランザクションをコンテキストに格納
-	ctx = context.WithValue(ctx, txKey, tx)
+	state := &txState{tx: tx, opts: o}
+	ctx = context.WithValue(ctx, txKey, state)
 
 	if err := f(ctx); err != nil {
-		if rbErr := tx.Rollback(); rbErr != nil {
+		rbErr := tx.Rollback()
+		tm.metrics.IncTransaction(metrics.TxRollback)
+		state.runAfterRollback(0)
+		if rbErr != nil {
 			return fmt.Errorf("処理エラー: %w, ロールバックエラー: %v", err, rbErr)
 		}
 		return err
 	}
 
 	if err := tx.Commit(); err != nil {
+		tm.metrics.IncTransaction(metrics.TxRollback)
+		state.runAfterRollback(0)
 		return fmt.Errorf("トランザクションのコミットに失敗しました: %w", err)
 	}
+	tm.metrics.IncTransaction(metrics.TxCommit)
+
+	for _, f := range state.afterCommit {
+		f()
+	}
+
+	return nil
+}
+
+// AfterCommit はコンテキストのトランザクションがコミットされた後に実行する関数を登録します。
+// トランザクション外で呼び出された場合は即座に実行します。
+func (tm *PostgresTransactionManager) AfterCommit(ctx context.Context, f func()) {
+	state := getTxState(ctx)
+	if state == nil {
+		f()
+		return
+	}
+	state.afterCommit = append(state.afterCommit, f)
+}
+
+// AfterRollback はコンテキストのトランザクションがロールバックされた後に実行する関数を登録します。
+// トランザクション外で呼び出された場合は何もしません。
+func (tm *PostgresTransactionManager) AfterRollback(ctx context.Context, f func()) {
+	if state := getTxState(ctx); state != nil {
+		state.afterRollback = append(state.afterRollback, f)
+	}
+}
+
+// beginNested は既存のトランザクション内で関数を実行します。
+func (tm *PostgresTransactionManager) beginNested(ctx context.Context, state *txState, o shared.TxOptions, f func(ctx context.Context) error) error {
+	// 実行中のトランザクションの分離レベルや読み取り専用属性は変更できない
+	if o.Isolation != shared.IsolationDefault && o.Isolation != state.opts.Isolation {
+		return fmt.Errorf("トランザクションの開始に失敗しました: 既存のトランザクションと分離レベルが異なります")
+	}
+	if state.opts.ReadOnly && !o.ReadOnly {
+		return fmt.Errorf("トランザクションの開始に失敗しました: 読み取り専用のトランザクション内で書き込みトランザクションは開始できません")
+	}
+
+	if o.Propagation != shared.PropagationNested {
+		return f(ctx)
+	}
+
+	state.savepoint++
+	name := fmt.Sprintf("sp_%d", state.savepoint)
+
+	// セーブポイントまでロールバックした場合は、その間に登録されたフックを巻き戻す
+	commitHooks, rollbackHooks := len(state.afterCommit), len(state.afterRollback)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("セーブポイントの作成に失敗しました: %w", err)
+	}
+
+	if err := f(ctx); err != nil {
+		if _, rbErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return fmt.Errorf("処理エラー: %w, セーブポイントへのロールバックエラー: %v", err, rbErr)
+		}
+		state.afterCommit = state.afterCommit[:commitHooks]
+		state.runAfterRollback(rollbackHooks)
+		return err
+	}
+
+	if _, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("セーブポイントの解放に失敗しました: %w", err)
+	}
 
 	return nil
 }
 
+// txAttributes はトランザクションのスパンの属性です。inTxは既存のトランザクション内で開始されたかどうかです。
+func txAttributes(o shared.TxOptions, inTx bool) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Bool("tx.in_tx", inTx),
+		attribute.Bool("tx.nested", inTx && o.Propagation == shared.PropagationNested),
+		attribute.Bool("tx.read_only", o.ReadOnly),
+		attribute.String("tx.isolation", toSQLIsolation(o.Isolation).String()),
+	}
+}
+
+// isRetryable は再試行によって成功する可能性のあるエラーかどうかを判定します。
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == serializationFailureCode || pqErr.Code == deadlockDetectedCode
+}
+
+// toSQLIsolation はドメインの分離レベルをdatabase/sqlの分離レベルへ変換します。
+func toSQLIsolation(level shared.IsolationLevel) sql.IsolationLevel {
+	switch level {
+	case shared.IsolationReadCommitted:
+		return sql.LevelReadCommitted
+	case shared.IsolationRepeatableRead:
+		return sql.LevelRepeatableRead
+	case shared.IsolationSerializable:
+		return sql.LevelSerializable
+	default:
+		return sql.LevelDefault
+	}
+}
+
+func getTxState(ctx context.Context) *txState {
+	if state, ok := ctx.Value(txKey).(*txState); ok {
+		return state
+	}
+	return nil
+}
+
 // GetTx はコンテキストからトランザクションを取得します。存在しない場合はnilを返します。
 func GetTx(ctx context.Context) *sql.Tx {
-	if tx, ok := ctx.Value(txKey).(*sql.Tx); ok {
-		return tx
+	if state := getTxState(ctx); state != nil {
+		return state.tx
 	}
 	return nil
 }
+
+// executor はクエリを実行できる*sql.DBと*sql.Txの共通インターフェースです。
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// getExecutor はコンテキストにトランザクションがあればそれを、なければdbを返します。
+func getExecutor(ctx context.Context, db *sql.DB) executor {
+	if tx := GetTx(ctx); tx != nil {
+		return tx
+	}
+	return db
+}
@@ -3,15 +3,19 @@ package subscriber
 import (
 	"ddd-hands-on-go/internal/domain/model/book"
 	"ddd-hands-on-go/internal/domain/shared"
-	"log"
+	"log/slog"
 )
 
 // LogSubscriber はイベントをログ出力するサブスクライバーです。
-type LogSubscriber struct{}
+// イベントの相関IDをcorrelation_idとして出力するため、発生元のリクエストのログ（request_id）と突き合わせられます。
+// 操作を行ったアクターはactorとして出力します。
+type LogSubscriber struct {
+	logger *slog.Logger
+}
 
 // NewLogSubscriber は新しいLogSubscriberを生成します。
-func NewLogSubscriber() *LogSubscriber {
-	return &LogSubscriber{}
+func NewLogSubscriber(logger *slog.Logger) *LogSubscriber {
+	return &LogSubscriber{logger: logger.With("subscriber", "LogSubscriber")}
 }
 
 // Subscribe は指定されたイベントを購読します。
@@ -19,7 +23,13 @@ func NewLogSubscriber() *LogSubscriber {
 // 汎用的にするためにインターフェースで受けて型アサーションすることも可能です。
 func (s *LogSubscriber) Subscribe(event shared.DomainEvent) {
 	if e, ok := event.(*book.BookCreated); ok {
-		log.Printf("[LogSubscriber] 書籍が作成されました: ID=%s, Title=%s, At=%s",
-			e.BookId, e.Title, e.OccurredAt)
+		s.logger.Info("書籍が作成されました",
+			"event", e.EventName(),
+			"book_id", e.BookId,
+			"title", e.Title,
+			"occurred_at", e.OccurredAt,
+			"correlation_id", e.CorrelationID(),
+			"actor", e.Actor(),
+		)
 	}
 }
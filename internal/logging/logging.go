@@ -0,0 +1,97 @@
+// Package logging はlog/slogによる構造化ログと、リクエストごとのロガー・リクエストIDのコンテキストへの格納を提供します。
+// リクエストを受け付けるHTTPのミドルウェアとgRPCのインターセプターがロガーを格納し、ハンドラー・アプリケーションサービス・リポジトリは
+// FromContextでリクエストIDとアクターを付与したロガーを取り出します。
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// ログの出力形式
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// RequestIDKey はログにリクエストIDを出力する際の属性名です。
+const RequestIDKey = "request_id"
+
+type key int
+
+const (
+	loggerKey key = iota
+	requestIDKey
+)
+
+// New は指定された形式とレベルでwへ出力するロガーを生成します。levelはdebug, info, warn, errorのいずれかです。
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lv slog.Level
+	if err := lv.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("ログレベルが不正です: %q", level)
+	}
+	opts := &slog.HandlerOptions{Level: lv}
+
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("ログの出力形式が不正です: %q", format)
+	}
+}
+
+// WithLogger はロガーを格納したコンテキストを返します。
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// FromContext はコンテキストに格納されたロガーを返します。格納されていない場合はslog.Default()を返します。
+// HTTP・gRPCのリクエストの処理中は、リクエストIDが属性として付与されたロガーが格納されています。
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// WithRequestID はリクエストIDを格納したコンテキストを返します。
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID はコンテキストに格納されたリクエストIDを返します。格納されていない場合は空文字列を返します。
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// NewRequestID はランダムなリクエストID（32文字の16進数）を生成します。
+func NewRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// ValidRequestID はクライアントから受け取ったリクエストIDをそのまま使用してよいかを判定します。
+// ログへの不正な文字の混入を防ぐため、128文字以下の英数字と - _ . : のみを許可します。
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+// Package metrics はPrometheus形式のメトリクスの収集と公開を提供します。
+// APIサーバーが生成し、HTTPのミドルウェアがリクエスト数と処理時間を、イベントの配信がイベントの件数を、
+// PostgreSQLのリポジトリとトランザクションがクエリの処理時間とトランザクションの結果を記録します。
+// Metricsのメソッドはnilのレシーバーでも呼び出すことができ、その場合は何も記録しません（CLIなどメトリクスを公開しない場合）。
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace は全てのメトリクス名の接頭辞です。
+const Namespace = "bookapi"
+
+// トランザクションの結果を表すラベルの値
+const (
+	TxCommit   = "commit"
+	TxRollback = "rollback"
+)
+
+// UnmatchedRoute はどのルートにも一致しなかったリクエストのrouteラベルの値です。
+// パスをそのままラベルにすると系列数が際限なく増えるため、まとめて集計します。
+const UnmatchedRoute = "unmatched"
+
+// BookStatusCounter は在庫ステータスごとの書籍数を返すクエリです。書籍数のゲージの収集時に呼び出します。
+type BookStatusCounter interface {
+	CountBooksByStatus(ctx context.Context) (map[string]int, error)
+}
+
+// Metrics はアプリケーションのメトリクスと、それを登録するレジストリです。
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	queryDuration   *prometheus.HistogramVec
+	transactions    *prometheus.CounterVec
+	eventsPublished *prometheus.CounterVec
+	eventsFailed    *prometheus.CounterVec
+}
+
+// New は新しいレジストリにアプリケーションのメトリクスとGoランタイム・プロセスのメトリクスを登録して返します。
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTPリクエストの件数 (ルート・メソッド・ステータスコード別)",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTPリクエストの処理時間 (ルート・メソッド別)",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "repository_query_duration_seconds",
+			Help:      "リポジトリの操作の所要時間 (操作・結果別)",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"op", "result"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "transactions_total",
+			Help:      "最上位のトランザクションの件数 (commit|rollback別)",
+		}, []string{"result"}),
+		eventsPublished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "events_published_total",
+			Help:      "発行されたドメインイベントの件数 (イベント名別)",
+		}, []string{"event"}),
+		eventsFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "events_failed_total",
+			Help:      "リスナーがパニックしたドメインイベントの件数 (イベント名別)",
+		}, []string{"event"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.queryDuration,
+		m.transactions,
+		m.eventsPublished,
+		m.eventsFailed,
+	)
+	return m
+}
+
+// Registry はメトリクスを登録したレジストリを返します。
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler はメトリクスをPrometheusのテキスト形式で返すハンドラーです。
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterBookStatusCounter は在庫ステータスごとの書籍数のゲージ（bookapi_books）を登録します。
+// 書籍数は収集のたびにcounterで数えます。
+func (m *Metrics) RegisterBookStatusCounter(counter BookStatusCounter) {
+	if m == nil {
+		return
+	}
+	m.registry.MustRegister(&bookStatusCollector{counter: counter})
+}
+
+// ObserveHTTPRequest はHTTPリクエストの件数と処理時間を記録します。
+func (m *Metrics) ObserveHTTPRequest(route, method string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.httpRequests.WithLabelValues(route, method, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(route, method).Observe(duration.Seconds())
+}
+
+// ObserveQuery はリポジトリの操作の所要時間を、成功（ok）・失敗（error）別に記録します。
+func (m *Metrics) ObserveQuery(op string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	m.queryDuration.WithLabelValues(op, result).Observe(duration.Seconds())
+}
+
+// IncTransaction はトランザクションの結果（TxCommitまたはTxRollback）を数えます。
+func (m *Metrics) IncTransaction(result string) {
+	if m == nil {
+		return
+	}
+	m.transactions.WithLabelValues(result).Inc()
+}
+
+// IncEventPublished は発行されたイベントを数えます。
+func (m *Metrics) IncEventPublished(eventName string) {
+	if m == nil {
+		return
+	}
+	m.eventsPublished.WithLabelValues(eventName).Inc()
+}
+
+// IncEventFailed はリスナーが失敗したイベントを数えます。
+func (m *Metrics) IncEventFailed(eventName string) {
+	if m == nil {
+		return
+	}
+	m.eventsFailed.WithLabelValues(eventName).Inc()
+}
+
+// bookStatusCollector は収集のたびに在庫ステータスごとの書籍数を数えるコレクターです。
+type bookStatusCollector struct {
+	counter BookStatusCounter
+}
+
+// bookStatusTimeout は書籍数を数えるクエリの制限時間です。収集が遅れてもスクレイプ全体を止めないようにします。
+const bookStatusTimeout = 5 * time.Second
+
+var booksDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(Namespace, "", "books"),
+	"書籍数 (在庫ステータス別)",
+	[]string{"status"}, nil,
+)
+
+func (c *bookStatusCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- booksDesc
+}
+
+func (c *bookStatusCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), bookStatusTimeout)
+	defer cancel()
+
+	counts, err := c.counter.CountBooksByStatus(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(booksDesc, err)
+		return
+	}
+	for status, n := range counts {
+		ch <- prometheus.MustNewConstMetric(booksDesc, prometheus.GaugeValue, float64(n), status)
+	}
+}
@@ -0,0 +1,173 @@
+// Package ratelimit はクライアントごとのトークンバケットによるレート制限を提供します。
+// HTTPのmiddleware.RateLimiterが、アクター・IPアドレスごとのリクエストと認証の失敗の頻度の制限に使用します。
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate は期間あたりのリクエスト数の上限です。
+// トークンバケットの容量はRequestsで、Periodの間にRequests個のトークンが補充されます。
+// そのため、上限までのリクエストは一度に受け付け、その後は一定の間隔で受け付けます。
+type Rate struct {
+	Requests int
+	Period   time.Duration
+}
+
+// IsZero はレートが指定されていない（制限しない）かどうかを返します。
+func (r Rate) IsZero() bool {
+	return r.Requests == 0
+}
+
+func (r Rate) String() string {
+	return fmt.Sprintf("%d/%s", r.Requests, r.Period)
+}
+
+// perSecond は1秒あたりに補充するトークン数です。
+func (r Rate) perSecond() float64 {
+	return float64(r.Requests) / r.Period.Seconds()
+}
+
+// ParseRate は「<回数>/<期間>」（60/1m など）の形式のレートを読み込みます。空文字列の場合はゼロ値（制限しない）を返します。
+func ParseRate(s string) (Rate, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Rate{}, nil
+	}
+	count, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return Rate{}, fmt.Errorf("レートの形式が不正です (<回数>/<期間>): %q", s)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || n <= 0 {
+		return Rate{}, fmt.Errorf("レートの回数は正の整数である必要があります: %q", s)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		return Rate{}, fmt.Errorf("レートの期間は正の時間である必要があります: %q", s)
+	}
+	return Rate{Requests: n, Period: d}, nil
+}
+
+// ParseRouteRates は「<ルートのパターン>=<回数>/<期間>」をカンマで区切って並べたルートごとのレートを読み込みます。
+//
+//	POST /books=60/1m,POST /books/import=5/1m
+func ParseRouteRates(spec string) (map[string]Rate, error) {
+	rates := make(map[string]Rate)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pattern, rate, ok := strings.Cut(entry, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("ルートのレートの形式が不正です (<パターン>=<回数>/<期間>): %q", entry)
+		}
+		r, err := ParseRate(rate)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pattern, err)
+		}
+		if r.IsZero() {
+			return nil, fmt.Errorf("%s: レートが指定されていません", pattern)
+		}
+		if _, dup := rates[pattern]; dup {
+			return nil, fmt.Errorf("%s のレートが重複しています", pattern)
+		}
+		rates[pattern] = r
+	}
+	return rates, nil
+}
+
+// Limiter はキー（クライアント）ごとのトークンバケットです。
+type Limiter struct {
+	rate Rate
+	now  func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// bucket はクライアント1件分のトークンバケットです。
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewLimiter は新しいLimiterを生成します。
+func NewLimiter(rate Rate) *Limiter {
+	return &Limiter{rate: rate, now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+// Allow はkeyのバケットからトークンを1つ取り出せればtrueを返します。
+// 取り出せない場合はfalseと、次のトークンが補充されるまでの時間を返します。
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.rate.Requests), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / l.rate.perSecond() * float64(time.Second))
+	return false, wait
+}
+
+// Available はkeyのバケットにトークンが残っているかを、トークンを取り出さずに返します。
+// 残っていない場合はfalseと、次のトークンが補充されるまでの時間を返します。
+// 失敗した認証のように、リクエストの結果を見てからAllowでトークンを取り出す場合に、事前の確認に使用します。
+func (l *Limiter) Available(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		return true, 0
+	}
+	tokens := l.refill(b, l.now())
+	if tokens >= 1 {
+		return true, 0
+	}
+	return false, time.Duration((1 - tokens) / l.rate.perSecond() * float64(time.Second))
+}
+
+// refill はバケットの更新からnowまでに補充されたトークンを加えた数を返します。
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	elapsed := now.Sub(b.updated).Seconds()
+	return math.Min(float64(l.rate.Requests), b.tokens+elapsed*l.rate.perSecond())
+}
+
+// sweep は満杯まで補充されたバケットを削除します。満杯のバケットは新しく作成したものと同じため、削除しても制限は変わりません。
+// クライアントの数だけバケットが増え続けないよう、レートの期間（最短1分）ごとに実行します。
+func (l *Limiter) sweep(now time.Time) {
+	interval := l.rate.Period
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+	if now.Sub(l.lastSweep) < interval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= float64(l.rate.Requests) {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Package tracing はOpenTelemetryによる分散トレースのスパンの作成と、トレースコンテキストの受け渡しを提供します。
+// HTTPのミドルウェア・アプリケーションサービス・リポジトリがStartでスパンを作成し、ドメインイベントはInjectとExtractで
+// トレースコンテキストを非同期のサブスクライバーへ引き継ぎます。
+//
+// スパンはグローバルなTracerProviderから作成します。NewTracerProviderで登録するまでは何も記録しませんが、
+// 受け取ったトレースコンテキストはそのまま引き継がれます。
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName はこのアプリケーションが作成するスパンの計装ライブラリ名です。
+const InstrumentationName = "ddd-hands-on-go"
+
+// トレースの出力先
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// propagator はW3C Trace Context（traceparent・tracestate）でトレースコンテキストを受け渡します。
+var propagator = propagation.TraceContext{}
+
+func init() {
+	otel.SetTextMapPropagator(propagator)
+}
+
+// Config はTracerProviderの設定です。
+type Config struct {
+	// Exporter はExporterNone、ExporterStdout、ExporterOTLPのいずれかです。
+	Exporter string
+	// OTLPEndpoint はOTLP/gRPCでトレースを送信するコレクターのアドレス（host:port）です。
+	OTLPEndpoint string
+	// OTLPInsecure がtrueの場合、コレクターへの送信にTLSを使用しません。
+	OTLPInsecure bool
+	// ServiceName はトレースに記録するサービス名です。
+	ServiceName string
+	// Stdout はExporterStdoutの出力先です。
+	Stdout io.Writer
+}
+
+// NewTracerProvider は設定された出力先へスパンを送信するTracerProviderを生成し、グローバルに登録します。
+// ExporterNoneの場合はnilを返し、グローバルのTracerProviderは変更しません。
+// 停止時にはShutdownを呼び出し、送信待ちのスパンを出力してください。
+func NewTracerProvider(ctx context.Context, cfg Config) (*sdktrace.TracerProvider, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(cfg.Stdout))
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("トレースの出力先が不正です: %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("トレースのエクスポーターの初期化エラー: %w", err)
+	}
+
+	resource, err := sdkresource.Merge(
+		sdkresource.Default(),
+		sdkresource.NewSchemaless(attribute.String("service.name", cfg.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("トレースのリソースの初期化エラー: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(time.Second)),
+		sdktrace.WithResource(resource),
+	)
+	otel.SetTracerProvider(tp)
+	return tp, nil
+}
+
+// Start はctxのスパンを親とするスパンを開始し、スパンを格納したコンテキストを返します。
+// スパンはEndで終了させてください。
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, opts...)
+}
+
+// End はスパンを終了します。errがnilでない場合はエラーとして記録します。
+// deferで呼び出し、errには名前付きの返り値へのポインタを渡します。
+func End(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
+
+// RecordError はctxのスパンにエラーを記録します。スパンを終了させる関数以外でエラーを処理する場合に使用します。
+func RecordError(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// RecordPanic はパニックをスパンのエラーとして記録します。recoverした値を渡します。
+func RecordPanic(span trace.Span, r any) {
+	err := fmt.Errorf("panic: %v", r)
+	span.RecordError(err, trace.WithStackTrace(true))
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Inject はctxのトレースコンテキストをW3C Trace Contextのキーと値で返します。
+// ドメインイベントなど、context.Contextを引き継げない先へトレースコンテキストを渡すために使用します。
+// ctxにスパンがない場合はnilを返します。
+func Inject(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier
+}
+
+// Extract はInjectで取り出したトレースコンテキストをctxに格納して返します。
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// TraceID はctxのスパンのトレースIDを返します。スパンがない場合は空文字列を返します。
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// WithAttrs はslog形式のキーと値の組をスパンの属性として指定するStartのオプションです。
+func WithAttrs(kvs ...any) trace.SpanStartOption {
+	return trace.WithAttributes(Attrs(kvs...)...)
+}
+
+// Attrs はslog形式のキーと値の組（"isbn", "978-..." など）をスパンの属性に変換します。
+// 文字列・整数・真偽値以外の値は文字列に変換します。
+func Attrs(kvs ...any) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(kvs)/2)
+	for i := 0; i+1 < len(kvs); i += 2 {
+		key, ok := kvs[i].(string)
+		if !ok {
+			continue
+		}
+		switch v := kvs[i+1].(type) {
+		case string:
+			attrs = append(attrs, attribute.String(key, v))
+		case int:
+			attrs = append(attrs, attribute.Int(key, v))
+		case int64:
+			attrs = append(attrs, attribute.Int64(key, v))
+		case bool:
+			attrs = append(attrs, attribute.Bool(key, v))
+		default:
+			attrs = append(attrs, attribute.String(key, fmt.Sprint(v)))
+		}
+	}
+	return attrs
+}
+
+// Shutdown は送信待ちのスパンを出力してTracerProviderを停止します。tpがnilの場合は何もしません。
+func Shutdown(ctx context.Context, tp *sdktrace.TracerProvider) error {
+	if tp == nil {
+		return nil
+	}
+	if err := tp.Shutdown(ctx); err != nil {
+		return fmt.Errorf("トレースの送信を完了できませんでした: %w", err)
+	}
+	return nil
+}
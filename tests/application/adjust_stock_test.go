@@ -0,0 +1,111 @@
+package application_test
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/application/book"
+	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/book/price"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/infrastructure/memory"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// lockCountingBookRepository は在庫をロックして読み取った回数と、ロックせずに読み取った回数を記録するリポジトリです。
+type lockCountingBookRepository struct {
+	*memory.InMemoryBookRepository
+	findCalls          atomic.Int32
+	findForUpdateCalls atomic.Int32
+}
+
+func (r *lockCountingBookRepository) Find(ctx context.Context, bookId *domain_book.BookId) (*domain_book.Book, error) {
+	r.findCalls.Add(1)
+	return r.InMemoryBookRepository.Find(ctx, bookId)
+}
+
+func (r *lockCountingBookRepository) FindForUpdate(ctx context.Context, bookId *domain_book.BookId) (*domain_book.Book, error) {
+	r.findForUpdateCalls.Add(1)
+	return r.InMemoryBookRepository.FindForUpdate(ctx, bookId)
+}
+
+// stockEventCounter はコミット後に配信された在庫数変更イベントを数えるパブリッシャーです。
+type stockEventCounter struct {
+	count atomic.Int32
+}
+
+func (p *stockEventCounter) Publish(event shared.DomainEvent) {
+	if _, ok := event.(*domain_book.StockQuantityChanged); ok {
+		p.count.Add(1)
+	}
+}
+
+func TestAdjustStockApplicationService_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := &lockCountingBookRepository{InMemoryBookRepository: memory.NewInMemoryBookRepository(store)}
+	assembler := book.NewBookDTOAssembler(
+		memory.NewInMemoryAuthorRepository(store), memory.NewInMemoryPublisherRepository(store), memory.NewInMemoryCategoryRepository(store))
+	publisher := &stockEventCounter{}
+	svc := book.NewAdjustStockApplicationService(repo, memory.NewInMemoryTransactionManager(store), assembler, publisher)
+
+	isbn := "978-4-00-111111-1"
+	id, _ := domain_book.NewBookId(isbn)
+	title, _ := domain_book.NewTitle("Test Book")
+	p, _ := price.NewPrice(1000, price.JPY)
+	b, _ := domain_book.NewBook(id, title, p, nil)
+	if err := repo.Save(ctx, b); err != nil {
+		t.Fatalf("書籍の保存に失敗しました: %v", err)
+	}
+
+	// adjustConcurrently はn件の在庫調整を同時に実行し、成功した件数と在庫不足で失敗した件数を返します。
+	adjustConcurrently := func(n, delta int) (succeeded, conflicted int) {
+		var wg sync.WaitGroup
+		var ok, conflict atomic.Int32
+		start := make(chan struct{})
+		for range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				_, err := svc.Execute(ctx, book.AdjustStockCommand{ISBN: isbn, Delta: delta})
+				switch {
+				case err == nil:
+					ok.Add(1)
+				case shared.KindOf(err) == shared.KindConflict:
+					conflict.Add(1)
+				default:
+					t.Errorf("在庫の調整に失敗しました: %v", err)
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+		return int(ok.Load()), int(conflict.Load())
+	}
+	quantity := func() int {
+		found, err := repo.InMemoryBookRepository.Find(ctx, id)
+		if err != nil || found == nil {
+			t.Fatalf("書籍の検索に失敗しました: %v", err)
+		}
+		return found.Stock().QuantityAvailable().Value()
+	}
+
+	// 同時の入荷はいずれも失われない
+	if succeeded, _ := adjustConcurrently(10, 1); succeeded != 10 || quantity() != 10 {
+		t.Errorf("期待する結果: 成功10件/在庫10, 実際: 成功%d件/在庫%d", succeeded, quantity())
+	}
+
+	// 在庫を超える同時の出荷は、在庫の分だけ成功し、残りは在庫不足になる（在庫が負にならない）
+	succeeded, conflicted := adjustConcurrently(15, -1)
+	if succeeded != 10 || conflicted != 5 || quantity() != 0 {
+		t.Errorf("期待する結果: 成功10件/在庫不足5件/在庫0, 実際: 成功%d件/在庫不足%d件/在庫%d", succeeded, conflicted, quantity())
+	}
+
+	if got := publisher.count.Load(); got != 20 {
+		t.Errorf("期待する在庫数変更イベントの件数: 20, 実際: %d", got)
+	}
+	if repo.findCalls.Load() != 0 || repo.findForUpdateCalls.Load() != 25 {
+		t.Errorf("在庫はロックして読み取る必要があります: Find %d回/FindForUpdate %d回", repo.findCalls.Load(), repo.findForUpdateCalls.Load())
+	}
+}
@@ -0,0 +1,64 @@
+package application_test
+
+import (
+	"context"
+	appcategory "ddd-hands-on-go/internal/application/category"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"testing"
+)
+
+func TestCategoryApplicationService_Hierarchy(t *testing.T) {
+	repo := &mockCategoryRepository{categories: make(map[string]*category.Category)}
+	txManager := &mockTransactionManager{}
+	hierarchySvc := service.NewCategoryHierarchyDomainService(repo)
+	registerSvc := appcategory.NewRegisterCategoryApplicationService(repo, txManager, hierarchySvc)
+	updateSvc := appcategory.NewUpdateCategoryApplicationService(repo, txManager, hierarchySvc)
+	ctx := context.Background()
+
+	// 小説 > ミステリー > 警察小説
+	novel, err := registerSvc.Execute(ctx, appcategory.RegisterCategoryCommand{Name: "小説"})
+	if err != nil {
+		t.Fatalf("カテゴリ登録に失敗しました: %v", err)
+	}
+	mystery, err := registerSvc.Execute(ctx, appcategory.RegisterCategoryCommand{Name: "ミステリー", ParentID: novel.ID})
+	if err != nil {
+		t.Fatalf("カテゴリ登録に失敗しました: %v", err)
+	}
+	police, err := registerSvc.Execute(ctx, appcategory.RegisterCategoryCommand{Name: "警察小説", ParentID: mystery.ID})
+	if err != nil {
+		t.Fatalf("カテゴリ登録に失敗しました: %v", err)
+	}
+	if police.ParentID != mystery.ID {
+		t.Errorf("期待する親カテゴリ: %s, 実際: %s", mystery.ID, police.ParentID)
+	}
+
+	tests := []struct {
+		name     string
+		cmd      appcategory.UpdateCategoryCommand
+		wantCode i18n.Key
+		wantKind shared.ErrorKind
+	}{
+		{"自身を親とする変更", appcategory.UpdateCategoryCommand{ID: novel.ID, Name: "小説", ParentID: novel.ID}, i18n.MsgCategoryCyclicParent, shared.KindInvalid},
+		{"孫を親とする変更", appcategory.UpdateCategoryCommand{ID: novel.ID, Name: "小説", ParentID: police.ID}, i18n.MsgCategoryCyclicParent, shared.KindInvalid},
+		{"未登録の親への変更", appcategory.UpdateCategoryCommand{ID: mystery.ID, Name: "ミステリー", ParentID: category.GenerateCategoryId().Value()}, i18n.MsgCategoryParentNotFound, shared.KindInvalid},
+		{"未登録のカテゴリの変更", appcategory.UpdateCategoryCommand{ID: category.GenerateCategoryId().Value(), Name: "SF"}, i18n.MsgCategoryNotFound, shared.KindNotFound},
+	}
+	for _, tt := range tests {
+		_, err := updateSvc.Execute(ctx, tt.cmd)
+		if i18n.ErrorCode(err) != tt.wantCode || shared.KindOf(err) != tt.wantKind {
+			t.Errorf("%s: 期待するエラー: %s, 実際: %v", tt.name, tt.wantCode, err)
+		}
+	}
+
+	// 警察小説を最上位へ移動する
+	updated, err := updateSvc.Execute(ctx, appcategory.UpdateCategoryCommand{ID: police.ID, Name: "警察小説"})
+	if err != nil {
+		t.Fatalf("カテゴリ更新に失敗しました: %v", err)
+	}
+	if updated.ParentID != "" {
+		t.Errorf("最上位へ移動したカテゴリに親カテゴリがあります: %s", updated.ParentID)
+	}
+}
@@ -0,0 +1,128 @@
+package application_test
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/application/book"
+	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/service"
+	"io"
+	"testing"
+)
+
+// sliceRowReader はスライスの行を順に返すテスト用のImportRowReaderです。
+type sliceRowReader struct {
+	rows []book.ImportRow
+}
+
+func (r *sliceRowReader) Read() (book.ImportRow, error) {
+	if len(r.rows) == 0 {
+		return book.ImportRow{}, io.EOF
+	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	return row, nil
+}
+
+// importRows は正常な2行と、重複・不正な行を含む取り込み行を返します。
+func importRows() []book.ImportRow {
+	return []book.ImportRow{
+		{Line: 2, ISBN: "978-4-00-111111-1", Title: "Book A", PriceAmount: 1000},
+		{Line: 3, ISBN: "978-4-00-222222-2", Title: "Book B", PriceAmount: 2000},
+		{Line: 4, ISBN: "978-4-00-111111-1", Title: "Book A (dup)", PriceAmount: 1000},
+		{Line: 5, ISBN: "978-4-00-444444-4", Title: "Book C", PriceAmount: -1},
+		{Line: 6, ISBN: "978-4-00-333333-3", Title: "Existing", PriceAmount: 3000},
+	}
+}
+
+func newImportService(t *testing.T) (*book.ImportBooksApplicationService, *mockBookRepository, *mockEventPublisher) {
+	t.Helper()
+	repo := &mockBookRepository{books: make(map[string]*domain_book.Book)}
+	txManager := &mockTransactionManager{}
+	eventPublisher := &mockEventPublisher{}
+
+	_, _, refCheck, assembler := newBookReferences()
+
+	// 既存の書籍を1件登録しておく
+	register := book.NewRegisterBookApplicationService(repo, txManager, service.NewISBNDuplicationCheckDomainService(repo), refCheck, assembler, eventPublisher)
+	if _, err := register.Execute(context.Background(), book.RegisterBookCommand{
+		ISBN: "978-4-00-333333-3", Title: "Existing", PriceAmount: 3000,
+	}); err != nil {
+		t.Fatalf("書籍登録に失敗しました: %v", err)
+	}
+	eventPublisher.events = nil
+
+	svc := book.NewImportBooksApplicationService(repo, txManager, service.NewISBNDuplicationCheckDomainService(repo), eventPublisher)
+	return svc, repo, eventPublisher
+}
+
+func TestImportBooksApplicationService(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         book.ImportMode
+		dryRun       bool
+		wantImported int
+		wantEvents   int
+		wantSaved    bool // Book Aがリポジトリに保存されていること
+	}{
+		// all-or-nothingでは失敗した行があればロールバックされ、イベントも発行されない
+		{name: "all-or-nothing", mode: book.ImportAllOrNothing, wantImported: 0, wantEvents: 0},
+		{name: "best-effort", mode: book.ImportBestEffort, wantImported: 2, wantEvents: 2, wantSaved: true},
+		{name: "dry-run", mode: book.ImportBestEffort, dryRun: true, wantImported: 0, wantEvents: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, eventPublisher := newImportService(t)
+
+			report, err := svc.Execute(context.Background(), book.ImportBooksCommand{
+				Rows:   &sliceRowReader{rows: importRows()},
+				Mode:   tt.mode,
+				DryRun: tt.dryRun,
+			})
+			if err != nil {
+				t.Fatalf("一括取り込みに失敗しました: %v", err)
+			}
+
+			if report.TotalRows != 5 || report.FailedRows != 3 || report.ImportedRows != tt.wantImported {
+				t.Errorf("期待する件数: total=5 failed=3 imported=%d, 実際: total=%d failed=%d imported=%d",
+					tt.wantImported, report.TotalRows, report.FailedRows, report.ImportedRows)
+			}
+
+			// 行番号順に、ファイル内重複・不正な価格・既存書籍との重複が報告される
+			wantLines := []int{4, 5, 6}
+			if len(report.Errors) != len(wantLines) {
+				t.Fatalf("期待するエラー数: %d, 実際: %v", len(wantLines), report.Errors)
+			}
+			for i, line := range wantLines {
+				if report.Errors[i].Line != line {
+					t.Errorf("期待するエラー行: %d, 実際: %d (%s)", line, report.Errors[i].Line, report.Errors[i].Message)
+				}
+			}
+
+			if len(eventPublisher.events) != tt.wantEvents {
+				t.Errorf("期待するイベント数: %d, 実際: %d", tt.wantEvents, len(eventPublisher.events))
+			}
+			if tt.dryRun {
+				if _, ok := repo.books["978-4-00-111111-1"]; ok {
+					t.Errorf("ドライランでは書籍を保存してはいけません")
+				}
+			}
+			if tt.wantSaved {
+				if _, ok := repo.books["978-4-00-111111-1"]; !ok {
+					t.Errorf("書籍が保存されていません")
+				}
+			}
+		})
+	}
+}
+
+func TestImportBooksApplicationService_InvalidMode(t *testing.T) {
+	svc, _, _ := newImportService(t)
+
+	if _, err := svc.Execute(context.Background(), book.ImportBooksCommand{
+		Rows: &sliceRowReader{},
+		Mode: "partial",
+	}); err == nil {
+		t.Errorf("不正なモードのエラーが発生すべきですが、nilが返されました")
+	}
+}
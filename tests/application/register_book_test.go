@@ -3,9 +3,14 @@ package application_test
 import (
 	"context"
 	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/model/author"
 	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/model/publisher"
 	"ddd-hands-on-go/internal/domain/service"
 	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"errors"
 	"testing"
 )
 
@@ -26,21 +31,171 @@ func (m *mockBookRepository) Find(ctx context.Context, bookId *domain_book.BookI
 	return nil, nil // Not found
 }
 
+func (m *mockBookRepository) FindForUpdate(ctx context.Context, bookId *domain_book.BookId) (*domain_book.Book, error) {
+	return m.Find(ctx, bookId)
+}
+
+func (m *mockBookRepository) FindMany(ctx context.Context, bookIds []*domain_book.BookId) ([]*domain_book.Book, error) {
+	books := make([]*domain_book.Book, 0, len(bookIds))
+	for _, id := range bookIds {
+		if b, ok := m.books[id.Value()]; ok {
+			books = append(books, b)
+		}
+	}
+	return books, nil
+}
+
 func (m *mockBookRepository) Delete(ctx context.Context, bookId *domain_book.BookId) error {
 	delete(m.books, bookId.Value())
 	return nil
 }
 
-type mockTransactionManager struct{}
+// mockTransactionManager はコミット後/ロールバック後フックの実行タイミングを
+// 本番実装と揃えたテスト用のトランザクションマネージャーです。
+type mockTransactionManager struct {
+	commitErr     error // 設定されている場合はコミットに失敗したものとして扱う
+	inTx          bool
+	afterCommit   []func()
+	afterRollback []func()
+}
+
+func (m *mockTransactionManager) Begin(ctx context.Context, f func(ctx context.Context) error, opts ...shared.TxOption) error {
+	if m.inTx {
+		return f(ctx)
+	}
+
+	m.inTx = true
+	err := f(ctx)
+	m.inTx = false
+	if err == nil {
+		err = m.commitErr
+	}
+
+	hooks := m.afterCommit
+	if err != nil {
+		hooks = m.afterRollback
+	}
+	m.afterCommit, m.afterRollback = nil, nil
+	for _, hook := range hooks {
+		hook()
+	}
+	return err
+}
+
+func (m *mockTransactionManager) AfterCommit(ctx context.Context, f func()) {
+	if !m.inTx {
+		f()
+		return
+	}
+	m.afterCommit = append(m.afterCommit, f)
+}
 
-func (m *mockTransactionManager) Begin(ctx context.Context, f func(ctx context.Context) error) error {
-	return f(ctx)
+func (m *mockTransactionManager) AfterRollback(ctx context.Context, f func()) {
+	if m.inTx {
+		m.afterRollback = append(m.afterRollback, f)
+	}
 }
 
-type mockEventPublisher struct{}
+type mockEventPublisher struct {
+	events []shared.DomainEvent
+}
 
 func (m *mockEventPublisher) Publish(event shared.DomainEvent) {
-	// テスト用のモック実装: 何もしない
+	m.events = append(m.events, event)
+}
+
+type mockAuthorRepository struct {
+	authors map[string]*author.Author
+}
+
+func (m *mockAuthorRepository) Save(ctx context.Context, a *author.Author) error {
+	m.authors[a.AuthorId().Value()] = a
+	return nil
+}
+
+func (m *mockAuthorRepository) Find(ctx context.Context, authorId *author.AuthorId) (*author.Author, error) {
+	return m.authors[authorId.Value()], nil
+}
+
+func (m *mockAuthorRepository) FindMany(ctx context.Context, authorIds []*author.AuthorId) ([]*author.Author, error) {
+	authors := make([]*author.Author, 0, len(authorIds))
+	for _, id := range authorIds {
+		if a, ok := m.authors[id.Value()]; ok {
+			authors = append(authors, a)
+		}
+	}
+	return authors, nil
+}
+
+func (m *mockAuthorRepository) Delete(ctx context.Context, authorId *author.AuthorId) error {
+	delete(m.authors, authorId.Value())
+	return nil
+}
+
+type mockPublisherRepository struct {
+	publishers map[string]*publisher.Publisher
+}
+
+func (m *mockPublisherRepository) Save(ctx context.Context, p *publisher.Publisher) error {
+	m.publishers[p.PublisherId().Value()] = p
+	return nil
+}
+
+func (m *mockPublisherRepository) Find(ctx context.Context, publisherId *publisher.PublisherId) (*publisher.Publisher, error) {
+	return m.publishers[publisherId.Value()], nil
+}
+
+func (m *mockPublisherRepository) FindMany(ctx context.Context, publisherIds []*publisher.PublisherId) ([]*publisher.Publisher, error) {
+	publishers := make([]*publisher.Publisher, 0, len(publisherIds))
+	for _, id := range publisherIds {
+		if p, ok := m.publishers[id.Value()]; ok {
+			publishers = append(publishers, p)
+		}
+	}
+	return publishers, nil
+}
+
+func (m *mockPublisherRepository) Delete(ctx context.Context, publisherId *publisher.PublisherId) error {
+	delete(m.publishers, publisherId.Value())
+	return nil
+}
+
+type mockCategoryRepository struct {
+	categories map[string]*category.Category
+}
+
+func (m *mockCategoryRepository) Save(ctx context.Context, c *category.Category) error {
+	m.categories[c.CategoryId().Value()] = c
+	return nil
+}
+
+func (m *mockCategoryRepository) Find(ctx context.Context, categoryId *category.CategoryId) (*category.Category, error) {
+	return m.categories[categoryId.Value()], nil
+}
+
+func (m *mockCategoryRepository) FindMany(ctx context.Context, categoryIds []*category.CategoryId) ([]*category.Category, error) {
+	categories := make([]*category.Category, 0, len(categoryIds))
+	for _, id := range categoryIds {
+		if c, ok := m.categories[id.Value()]; ok {
+			categories = append(categories, c)
+		}
+	}
+	return categories, nil
+}
+
+func (m *mockCategoryRepository) Delete(ctx context.Context, categoryId *category.CategoryId) error {
+	delete(m.categories, categoryId.Value())
+	return nil
+}
+
+// newBookReferences は空の著者・出版社・カテゴリのリポジトリを使う、参照確認のドメインサービスとBookDTOAssemblerを生成します。
+func newBookReferences() (*mockAuthorRepository, *mockPublisherRepository, *service.BookReferenceCheckDomainService, *book.BookDTOAssembler) {
+	authorRepo := &mockAuthorRepository{authors: make(map[string]*author.Author)}
+	publisherRepo := &mockPublisherRepository{publishers: make(map[string]*publisher.Publisher)}
+	categoryRepo := &mockCategoryRepository{categories: make(map[string]*category.Category)}
+	return authorRepo, publisherRepo,
+		service.NewBookReferenceCheckDomainService(authorRepo, publisherRepo, categoryRepo),
+		book.NewBookDTOAssembler(authorRepo, publisherRepo, categoryRepo)
 }
 
 func TestRegisterBookApplicationService(t *testing.T) {
@@ -48,7 +203,8 @@ func TestRegisterBookApplicationService(t *testing.T) {
 	txManager := &mockTransactionManager{}
 	dupSvc := service.NewISBNDuplicationCheckDomainService(repo)
 	eventPublisher := &mockEventPublisher{}
-	appSvc := book.NewRegisterBookApplicationService(repo, txManager, dupSvc, eventPublisher)
+	_, _, refCheck, assembler := newBookReferences()
+	appSvc := book.NewRegisterBookApplicationService(repo, txManager, dupSvc, refCheck, assembler, eventPublisher)
 
 	cmd := book.RegisterBookCommand{
 		ISBN:        "978-4-00-111111-1",
@@ -57,9 +213,13 @@ func TestRegisterBookApplicationService(t *testing.T) {
 	}
 
 	// 1. 正常系: 書籍登録成功
-	if err := appSvc.Execute(context.Background(), cmd); err != nil {
+	dto, err := appSvc.Execute(context.Background(), cmd)
+	if err != nil {
 		t.Fatalf("書籍登録に失敗しました: %v", err)
 	}
+	if dto.ISBN != "978-4-00-111111-1" || dto.Status != "OUT_OF_STOCK" {
+		t.Errorf("登録した書籍のDTOが不正です: %+v", dto)
+	}
 
 	// 検証: リポジトリに保存されているか
 	savedBook, err := repo.Find(context.Background(), mustBookId("978-4-00-111111-1"))
@@ -73,10 +233,82 @@ func TestRegisterBookApplicationService(t *testing.T) {
 		t.Errorf("期待するタイトル: 'Test Book', 実際: %s", savedBook.Title().Value())
 	}
 
+	// 検証: コミット後にBookCreatedイベントが発行されているか
+	if len(eventPublisher.events) != 1 || eventPublisher.events[0].EventName() != "BookCreated" {
+		t.Errorf("期待するイベント: [BookCreated], 実際: %v", eventPublisher.events)
+	}
+
 	// 2. 異常系: 同一ISBNの重複登録 (ドメインサービスによる検証)
-	if err := appSvc.Execute(context.Background(), cmd); err == nil {
+	if _, err := appSvc.Execute(context.Background(), cmd); err == nil {
 		t.Errorf("重複ISBNのエラーが発生すべきですが、nilが返されました")
 	}
+
+	// 検証: ロールバックされた登録のイベントは発行されない
+	if len(eventPublisher.events) != 1 {
+		t.Errorf("期待するイベント数: 1, 実際: %d", len(eventPublisher.events))
+	}
+}
+
+func TestRegisterBookApplicationService_CommitFailure(t *testing.T) {
+	repo := &mockBookRepository{books: make(map[string]*domain_book.Book)}
+	txManager := &mockTransactionManager{commitErr: errors.New("commit failed")}
+	dupSvc := service.NewISBNDuplicationCheckDomainService(repo)
+	eventPublisher := &mockEventPublisher{}
+	_, _, refCheck, assembler := newBookReferences()
+	appSvc := book.NewRegisterBookApplicationService(repo, txManager, dupSvc, refCheck, assembler, eventPublisher)
+
+	cmd := book.RegisterBookCommand{
+		ISBN:        "978-4-00-111111-1",
+		Title:       "Test Book",
+		PriceAmount: 1500,
+	}
+
+	if _, err := appSvc.Execute(context.Background(), cmd); err == nil {
+		t.Fatalf("コミット失敗のエラーが発生すべきですが、nilが返されました")
+	}
+
+	// コミットされなかった登録のイベントは購読者に届いてはならない
+	if len(eventPublisher.events) != 0 {
+		t.Errorf("期待するイベント数: 0, 実際: %d", len(eventPublisher.events))
+	}
+}
+
+func TestRegisterBookApplicationService_References(t *testing.T) {
+	repo := &mockBookRepository{books: make(map[string]*domain_book.Book)}
+	dupSvc := service.NewISBNDuplicationCheckDomainService(repo)
+	authorRepo, publisherRepo, refCheck, assembler := newBookReferences()
+	appSvc := book.NewRegisterBookApplicationService(repo, &mockTransactionManager{}, dupSvc, refCheck, assembler, &mockEventPublisher{})
+
+	name, _ := author.NewName("著者A")
+	a := author.NewAuthor(author.GenerateAuthorId(), name)
+	_ = authorRepo.Save(context.Background(), a)
+	pName, _ := publisher.NewName("出版社")
+	p := publisher.NewPublisher(publisher.GeneratePublisherId(), pName)
+	_ = publisherRepo.Save(context.Background(), p)
+	unknown := author.GenerateAuthorId().Value()
+
+	// 存在しない著者を参照する登録はエラーとし、書籍を保存しない
+	_, err := appSvc.Execute(context.Background(), book.RegisterBookCommand{
+		ISBN: "978-4-00-111111-1", Title: "Test Book", PriceAmount: 1500,
+		Metadata: book.MetadataInput{AuthorIds: []string{a.AuthorId().Value(), unknown}},
+	})
+	if i18n.ErrorCode(err) != "author.not_found" || shared.KindOf(err) != shared.KindInvalid {
+		t.Errorf("期待するエラー: author.not_found, 実際: %v", err)
+	}
+	if len(repo.books) != 0 {
+		t.Errorf("参照先のない書籍が保存されています")
+	}
+
+	dto, err := appSvc.Execute(context.Background(), book.RegisterBookCommand{
+		ISBN: "978-4-00-111111-1", Title: "Test Book", PriceAmount: 1500,
+		Metadata: book.MetadataInput{AuthorIds: []string{a.AuthorId().Value()}, PublisherId: p.PublisherId().Value()},
+	})
+	if err != nil {
+		t.Fatalf("書籍登録に失敗しました: %v", err)
+	}
+	if len(dto.Authors) != 1 || dto.Authors[0].Name != "著者A" || dto.Publisher == nil || dto.Publisher.Name != "出版社" {
+		t.Errorf("DTOに著者・出版社の名前が含まれていません: %+v", dto)
+	}
 }
 
 func mustBookId(v string) *domain_book.BookId {
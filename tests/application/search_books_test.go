@@ -0,0 +1,84 @@
+package application_test
+
+import (
+	"context"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// mockSearchIndex は受け取った検索語を記録し、固定の検索結果を返すSearchIndexです。
+type mockSearchIndex struct {
+	terms   []string
+	results []*book.BookSearchResult
+}
+
+func (m *mockSearchIndex) Search(ctx context.Context, terms []string, limit, offset int) ([]*book.BookSearchResult, error) {
+	m.terms = terms
+	return m.results, nil
+}
+
+func TestSearchBooksApplicationService(t *testing.T) {
+	index := &mockSearchIndex{results: []*book.BookSearchResult{{
+		Book: &book.BookDTO{
+			ISBN:      "978-4-00-111111-1",
+			Title:     "ドメイン駆動設計 <入門>",
+			Subtitle:  "ＧＯで学ぶ",
+			Authors:   []book.BookAuthorDTO{{Name: "山田太郎"}, {Name: "佐藤花子"}},
+			Publisher: &book.BookPublisherDTO{Name: "技術書房"},
+		},
+		Score: 1.6,
+	}}}
+	svc := book.NewSearchBooksApplicationService(index)
+
+	hits, err := svc.Execute(context.Background(), book.SearchBooksQuery{Q: " どめいん　Go 入門 ドメイン 太郎 "})
+	if err != nil {
+		t.Fatalf("書籍の検索に失敗しました: %v", err)
+	}
+
+	// 検索語は全角・半角、カタカナ・ひらがな、大文字・小文字を区別しないよう正規化し、重複を除く
+	if want := []string{"どめいん", "go", "入門", "太郎"}; !slices.Equal(index.terms, want) {
+		t.Errorf("期待する検索語: %v, 実際: %v", want, index.terms)
+	}
+	if len(hits) != 1 || hits[0].Score != 1.6 {
+		t.Fatalf("検索結果が不正です: %+v", hits)
+	}
+	h := hits[0].Highlights
+	if h.Title != "<mark>ドメイン</mark>駆動設計 &lt;<mark>入門</mark>&gt;" {
+		t.Errorf("タイトルの強調表示が不正です: %s", h.Title)
+	}
+	if h.Subtitle != "<mark>ＧＯ</mark>で学ぶ" {
+		t.Errorf("副題の強調表示が不正です: %s", h.Subtitle)
+	}
+	if len(h.Authors) != 1 || h.Authors[0] != "山田<mark>太郎</mark>" {
+		t.Errorf("一致した著者名のみを強調表示する必要があります: %v", h.Authors)
+	}
+	if h.Publisher != "" {
+		t.Errorf("一致しない出版社名は省略する必要があります: %s", h.Publisher)
+	}
+}
+
+func TestSearchBooksApplicationService_InvalidQuery(t *testing.T) {
+	svc := book.NewSearchBooksApplicationService(&mockSearchIndex{})
+
+	tests := []struct {
+		name     string
+		query    book.SearchBooksQuery
+		wantCode i18n.Key
+	}{
+		{"空白のみの検索語", book.SearchBooksQuery{Q: " 　"}, i18n.MsgSearchQueryRequired},
+		{"長すぎる検索語", book.SearchBooksQuery{Q: strings.Repeat("本", book.MaxSearchQueryLength+1)}, i18n.MsgSearchQueryTooLong},
+		{"多すぎる検索語", book.SearchBooksQuery{Q: "a b c d e f g h i j k"}, i18n.MsgSearchTooManyTerms},
+		{"上限を超える件数", book.SearchBooksQuery{Q: "go", Limit: book.MaxListLimit + 1}, i18n.MsgListLimitOutOfRange},
+		{"負の開始位置", book.SearchBooksQuery{Q: "go", Offset: -1}, i18n.MsgListOffsetNegative},
+	}
+	for _, tt := range tests {
+		_, err := svc.Execute(context.Background(), tt.query)
+		if i18n.ErrorCode(err) != tt.wantCode || shared.KindOf(err) != shared.KindInvalid {
+			t.Errorf("%s: 期待するエラー: %s, 実際: %v", tt.name, tt.wantCode, err)
+		}
+	}
+}
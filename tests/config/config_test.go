@@ -0,0 +1,180 @@
+package config_test
+
+import (
+	"bytes"
+	"ddd-hands-on-go/internal/config"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// envMap はテスト用の環境変数です。
+type envMap map[string]string
+
+func (m envMap) lookup(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("設定ファイルの作成に失敗しました: %v", err)
+	}
+	return path
+}
+
+func load(t *testing.T, env envMap, args ...string) (*config.Config, error) {
+	t.Helper()
+	loader := config.NewLoader(env.lookup)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("フラグの解析に失敗しました: %v", err)
+	}
+	return loader.Load()
+}
+
+func TestLoader_Precedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  http_port: 8000
+  read_timeout: 5s
+database:
+  host: db.internal
+  port: 6432
+events:
+  dispatch_mode: async
+`)
+
+	cfg, err := load(t, envMap{"DB_PORT": "7432", "HTTP_PORT": "8100", "AUTH_API_KEYS": "bot:viewer:change-me"},
+		"-config", path, "-http-port", "8200", "-feature-grpc=false")
+	if err != nil {
+		t.Fatalf("設定の読み込みに失敗しました: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"フラグが環境変数より優先される", cfg.Server.HTTPPort, 8200},
+		{"環境変数が設定ファイルより優先される", cfg.Database.Port, 7432},
+		{"設定ファイルが既定値より優先される", cfg.Database.Host, "db.internal"},
+		{"設定ファイルの時間", cfg.Server.ReadTimeout, 5 * time.Second},
+		{"設定ファイルの列挙値", cfg.Events.DispatchMode, config.DispatchAsync},
+		{"bool型のフラグ", cfg.Features.GRPC, false},
+		{"指定のない項目は既定値", cfg.Database.Name, "ddd_hands_on"},
+		{"認証は既定で有効", cfg.Auth.Enabled, true},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: 期待する値: %v, 実際: %v", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestLoader_TOML(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[server]
+write_timeout = "30s"
+
+[database]
+sslmode = "verify-full"
+sslrootcert = "/etc/ssl/ca.pem"
+`)
+
+	cfg, err := load(t, envMap{"CONFIG_FILE": path, "AUTH_ENABLED": "false"})
+	if err != nil {
+		t.Fatalf("設定の読み込みに失敗しました: %v", err)
+	}
+	if cfg.Server.WriteTimeout != 30*time.Second {
+		t.Errorf("期待するwrite_timeout: 30s, 実際: %s", cfg.Server.WriteTimeout)
+	}
+	if got := cfg.Database.ConnString(); !strings.Contains(got, "sslmode=verify-full sslrootcert=/etc/ssl/ca.pem") {
+		t.Errorf("接続文字列にTLSの設定が含まれていません: %s", got)
+	}
+}
+
+func TestLoader_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     envMap
+		args    []string
+		wantErr string
+	}{
+		{"設定ファイルの未知のキー", envMap{"CONFIG_FILE": writeFile(t, "c.yaml", "server:\n  port: 80\n")}, nil, "port"},
+		{"サポートされていない設定ファイル", envMap{"CONFIG_FILE": writeFile(t, "c.json", "{}")}, nil, ".json"},
+		{"数値でない環境変数", envMap{"DB_PORT": "abc"}, nil, "DB_PORT"},
+		{"不正なsslmode", nil, []string{"-db-sslmode", "prefer"}, "database.sslmode"},
+		{"不正な配信方式", envMap{"EVENT_DISPATCH_MODE": "queue"}, nil, "events.dispatch_mode"},
+		{"アイドル接続数が最大接続数を超える", nil, []string{"-db-max-open-conns", "2", "-db-max-idle-conns", "3"}, "database.max_idle_conns"},
+		// 認証を無効にしない限り、認証の方法の設定がなければ起動しない
+		{"認証の方法の設定なし", nil, nil, "auth.enabled"},
+		{"認証の方法の設定なし(明示的に有効)", envMap{"AUTH_ENABLED": "true"}, nil, "auth.api_keys"},
+	}
+
+	for _, tt := range tests {
+		_, err := load(t, tt.env, tt.args...)
+		if err == nil {
+			t.Errorf("%s: エラーが発生すべきですが、nilが返されました", tt.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: エラーに %q が含まれていません: %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestLoader_ProfileCLI(t *testing.T) {
+	// 管理用CLIは認証の方法の設定がなくても読み込め、認証の設定は読み込んだ値のまま残る
+	env := envMap{"AUTH_ENABLED": "true", "DB_HOST": "cli-db"}
+	cfg, err := config.NewLoader(env.lookup).WithProfile(config.ProfileCLI).Load()
+	if err != nil {
+		t.Fatalf("設定の読み込みに失敗しました: %v", err)
+	}
+	if !cfg.Auth.Enabled || cfg.Database.Host != "cli-db" {
+		t.Errorf("環境変数の値が反映されていません: auth.enabled=%t, database.host=%q", cfg.Auth.Enabled, cfg.Database.Host)
+	}
+
+	// 認証以外の設定は検証する
+	env["EVENT_DISPATCH_MODE"] = "queue"
+	if _, err := config.NewLoader(env.lookup).WithProfile(config.ProfileCLI).Load(); err == nil || !strings.Contains(err.Error(), "events.dispatch_mode") {
+		t.Errorf("不正な設定のエラーが返されていません: %v", err)
+	}
+}
+
+func TestConfig_Write(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      envMap
+		wantText string
+	}{
+		{"パスワード", envMap{"DB_PASS": "s3cret"}, "password: '****'"},
+		{"key=value形式の接続文字列", envMap{"DB_DSN": "host=db password='s3cret pass' dbname=app"}, "host=db password=**** dbname=app"},
+		{"URL形式の接続文字列", envMap{"DB_DSN": "postgres://app:s3cret@db:5432/app"}, "postgres://app:xxxxx@db:5432/app"},
+	}
+
+	for _, tt := range tests {
+		tt.env["AUTH_API_KEYS"] = "bot:viewer:s3cret"
+		cfg, err := load(t, tt.env)
+		if err != nil {
+			t.Fatalf("%s: 設定の読み込みに失敗しました: %v", tt.name, err)
+		}
+
+		var buf bytes.Buffer
+		if err := cfg.Write(&buf); err != nil {
+			t.Fatalf("%s: 設定の書き出しに失敗しました: %v", tt.name, err)
+		}
+		if strings.Contains(buf.String(), "s3cret") {
+			t.Errorf("%s: 秘密情報が出力されています:\n%s", tt.name, buf.String())
+		}
+		if !strings.Contains(buf.String(), tt.wantText) {
+			t.Errorf("%s: 出力に %q が含まれていません:\n%s", tt.name, tt.wantText, buf.String())
+		}
+	}
+}
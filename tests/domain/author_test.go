@@ -0,0 +1,64 @@
+package domain_test
+
+import (
+	"ddd-hands-on-go/internal/domain/model/author"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+	"ddd-hands-on-go/internal/i18n"
+	"strings"
+	"testing"
+)
+
+func TestAuthor(t *testing.T) {
+	// 前後の空白を取り除き、NFCに正規化する
+	name, err := author.NewName(" ガリレオ ")
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	a := author.NewAuthor(author.GenerateAuthorId(), name)
+	if a.Name().Value() != "ガリレオ" {
+		t.Errorf("期待する著者名: ガリレオ, 実際: %q", a.Name().Value())
+	}
+
+	newName, _ := author.NewName("湯川学")
+	a.Rename(newName)
+	if a.Name().Value() != "湯川学" {
+		t.Errorf("期待する著者名: 湯川学, 実際: %q", a.Name().Value())
+	}
+
+	id, err := author.NewAuthorId(strings.ToUpper(a.AuthorId().Value()))
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if !id.Equals(a.AuthorId()) {
+		t.Errorf("大文字のIDが同じIDとして扱われません: %s, %s", id.Value(), a.AuthorId().Value())
+	}
+}
+
+func TestAuthorAndPublisher_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func() error
+		wantCode i18n.Key
+	}{
+		{"空の著者名", func() error { _, err := author.NewName("  "); return err }, i18n.MsgAuthorRequired},
+		{"長すぎる著者名", func() error {
+			_, err := author.NewName(strings.Repeat("a", author.MaxNameLength+1))
+			return err
+		}, i18n.MsgAuthorTooLong},
+		{"制御文字を含む著者名", func() error { _, err := author.NewName("村上\n春樹"); return err }, i18n.MsgAuthorControlCharacter},
+		{"UUIDでない著者ID", func() error { _, err := author.NewAuthorId("author-1"); return err }, i18n.MsgAuthorIdInvalid},
+		{"空の出版社名", func() error { _, err := publisher.NewName(""); return err }, i18n.MsgPublisherRequired},
+		{"長すぎる出版社名", func() error {
+			_, err := publisher.NewName(strings.Repeat("a", publisher.MaxNameLength+1))
+			return err
+		}, i18n.MsgPublisherTooLong},
+		{"制御文字を含む出版社名", func() error { _, err := publisher.NewName("新潮\t社"); return err }, i18n.MsgPublisherControlCharacter},
+		{"UUIDでない出版社ID", func() error { _, err := publisher.NewPublisherId(""); return err }, i18n.MsgPublisherIdInvalid},
+	}
+
+	for _, tt := range tests {
+		if got := i18n.ErrorCode(tt.build()); got != tt.wantCode {
+			t.Errorf("%s: 期待するコード: %s, 実際: %s", tt.name, tt.wantCode, got)
+		}
+	}
+}
@@ -25,7 +25,7 @@ func TestBook(t *testing.T) {
 	}
 
 	// 2. Bookエンティティの生成
-	b, err := book.NewBook(bookId, title, p)
+	b, err := book.NewBook(bookId, title, p, nil)
 	if err != nil {
 		t.Fatalf("Bookの生成に失敗しました: %v", err)
 	}
@@ -0,0 +1,105 @@
+package domain_test
+
+import (
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/book/price"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/i18n"
+	"strings"
+	"testing"
+)
+
+func TestCategory(t *testing.T) {
+	name, err := category.NewName(" ミステリー ")
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	parentId := category.GenerateCategoryId()
+	c, err := category.NewCategory(category.GenerateCategoryId(), name, parentId)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if c.Name().Value() != "ミステリー" || !c.ParentId().Equals(parentId) {
+		t.Errorf("カテゴリが不正です: %q, %v", c.Name().Value(), c.ParentId())
+	}
+
+	// 最上位のカテゴリへ移動する
+	if err := c.MoveTo(nil); err != nil || c.ParentId() != nil {
+		t.Errorf("最上位へ移動できません: %v", err)
+	}
+
+	// 自身を親カテゴリにはできない
+	if err := c.MoveTo(c.CategoryId()); i18n.ErrorCode(err) != i18n.MsgCategoryCyclicParent {
+		t.Errorf("期待するコード: %s, 実際: %v", i18n.MsgCategoryCyclicParent, err)
+	}
+	if c.ParentId() != nil {
+		t.Errorf("移動できなかったカテゴリの親カテゴリが変更されています: %v", c.ParentId())
+	}
+}
+
+func TestCategory_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func() error
+		wantCode i18n.Key
+	}{
+		{"空のカテゴリ名", func() error { _, err := category.NewName(" "); return err }, i18n.MsgCategoryRequired},
+		{"長すぎるカテゴリ名", func() error {
+			_, err := category.NewName(strings.Repeat("a", category.MaxNameLength+1))
+			return err
+		}, i18n.MsgCategoryTooLong},
+		{"制御文字を含むカテゴリ名", func() error { _, err := category.NewName("SF\x00"); return err }, i18n.MsgCategoryControlCharacter},
+		{"UUIDでないカテゴリID", func() error { _, err := category.NewCategoryId("mystery"); return err }, i18n.MsgCategoryIdInvalid},
+	}
+
+	for _, tt := range tests {
+		if got := i18n.ErrorCode(tt.build()); got != tt.wantCode {
+			t.Errorf("%s: 期待するコード: %s, 実際: %s", tt.name, tt.wantCode, got)
+		}
+	}
+}
+
+func TestBook_AssignCategories(t *testing.T) {
+	id, _ := book.NewBookId("978-4-00-111111-1")
+	title, _ := book.NewTitle("Test Book")
+	p, _ := price.NewPrice(1000, price.JPY)
+	b, err := book.NewBook(id, title, p, nil)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	b.PullEvents()
+
+	a, c := category.GenerateCategoryId(), category.GenerateCategoryId()
+	if err := b.AssignCategories([]*category.CategoryId{a, c}); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if len(b.CategoryIds()) != 2 {
+		t.Errorf("期待するカテゴリ数: 2, 実際: %d", len(b.CategoryIds()))
+	}
+	events := b.PullEvents()
+	if len(events) != 1 || events[0].EventName() != "BookCategoriesChanged" {
+		t.Errorf("カテゴリの変更イベントが記録されていません: %v", events)
+	}
+
+	tooMany := make([]*category.CategoryId, book.MaxCategories+1)
+	for i := range tooMany {
+		tooMany[i] = category.GenerateCategoryId()
+	}
+	tests := []struct {
+		name     string
+		ids      []*category.CategoryId
+		wantCode i18n.Key
+	}{
+		{"多すぎるカテゴリ", tooMany, i18n.MsgCategoriesTooMany},
+		{"重複したカテゴリ", []*category.CategoryId{a, a}, i18n.MsgCategoriesDuplicate},
+	}
+	for _, tt := range tests {
+		if err := b.AssignCategories(tt.ids); i18n.ErrorCode(err) != tt.wantCode {
+			t.Errorf("%s: 期待するコード: %s, 実際: %v", tt.name, tt.wantCode, err)
+		}
+	}
+	// 失敗した割り当ては変更しない
+	if len(b.CategoryIds()) != 2 {
+		t.Errorf("失敗した割り当てでカテゴリが変更されています: %d", len(b.CategoryIds()))
+	}
+}
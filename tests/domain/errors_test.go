@@ -0,0 +1,83 @@
+package domain_test
+
+import (
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/book/price"
+	"ddd-hands-on-go/internal/domain/model/book/stock/quantity_available"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestDomainErrorCodes(t *testing.T) {
+	q, _ := quantity_available.NewQuantityAvailable(1)
+
+	tests := []struct {
+		name     string
+		newError func() error
+		wantKind shared.ErrorKind
+		wantCode i18n.Key
+		wantJa   string
+		wantEn   string
+	}{
+		{"空のISBN", func() error { _, err := book.NewBookId(""); return err },
+			shared.KindInvalid, i18n.MsgBookIdRequired,
+			"BookIdの生成に失敗しました: ISBNは必須です", "Invalid book ID: ISBN is required"},
+		{"空のタイトル", func() error { _, err := book.NewTitle(""); return err },
+			shared.KindInvalid, i18n.MsgTitleRequired,
+			"Titleの生成に失敗しました: タイトルは必須です", "Invalid title: title is required"},
+		{"負の価格", func() error { _, err := price.NewPrice(-1, price.JPY); return err },
+			shared.KindInvalid, i18n.MsgPriceNegative,
+			"Priceの生成に失敗しました: 金額は0以上である必要があります", "Invalid price: amount must be 0 or greater"},
+		{"サポートされていない通貨", func() error { _, err := price.NewPrice(1, price.Currency("USD")); return err },
+			shared.KindInvalid, i18n.MsgPriceUnsupportedCurrency,
+			"Priceの生成に失敗しました: 通貨はJPYのみサポートされています", "Invalid price: only JPY is supported as the currency"},
+		{"負の在庫数", func() error { _, err := quantity_available.NewQuantityAvailable(-1); return err },
+			shared.KindInvalid, i18n.MsgQuantityNegative,
+			"QuantityAvailableの生成に失敗しました: 数値は0以上である必要があります", "Invalid quantity available: quantity must be 0 or greater"},
+		{"在庫不足", func() error { _, err := q.Decrement(2); return err },
+			shared.KindConflict, i18n.MsgStockInsufficient,
+			"在庫が不足しています", "Insufficient stock"},
+	}
+
+	for _, tt := range tests {
+		err := tt.newError()
+		if err == nil {
+			t.Fatalf("%s: エラーが返されませんでした", tt.name)
+		}
+		if got := shared.KindOf(err); got != tt.wantKind {
+			t.Errorf("%s: 期待する種類: %v, 実際: %v", tt.name, tt.wantKind, got)
+		}
+		if got := i18n.ErrorCode(err); got != tt.wantCode {
+			t.Errorf("%s: 期待するコード: %s, 実際: %s", tt.name, tt.wantCode, got)
+		}
+		if got := err.Error(); got != tt.wantJa {
+			t.Errorf("%s: 期待するメッセージ: %s, 実際: %s", tt.name, tt.wantJa, got)
+		}
+		if got := i18n.ErrorMessage(i18n.English, err); got != tt.wantEn {
+			t.Errorf("%s: 期待する英語のメッセージ: %s, 実際: %s", tt.name, tt.wantEn, got)
+		}
+	}
+}
+
+func TestDomainError_Wrap(t *testing.T) {
+	cause := shared.NewDomainError(shared.KindInvalid, i18n.MsgPriceNegative)
+	err := fmt.Errorf("保存に失敗しました: %w", shared.NewDomainError(shared.KindUnknown, i18n.MsgRepositoryCorruptedValue, "priceAmount").Wrap(cause))
+
+	// 外側のドメインエラーの種類とコードが優先される
+	if got := shared.KindOf(err); got != shared.KindUnknown {
+		t.Errorf("期待する種類: KindUnknown, 実際: %v", got)
+	}
+	if got := i18n.ErrorCode(err); got != i18n.MsgRepositoryCorruptedValue {
+		t.Errorf("期待するコード: %s, 実際: %s", i18n.MsgRepositoryCorruptedValue, got)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("原因のエラーがエラーチェーンに含まれていません")
+	}
+	want := "Data integrity error: the stored priceAmount is invalid: Invalid price: amount must be 0 or greater"
+	if got := i18n.ErrorMessage(i18n.English, err); got != want {
+		t.Errorf("期待するメッセージ: %s, 実際: %s", want, got)
+	}
+}
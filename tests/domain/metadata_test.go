@@ -0,0 +1,97 @@
+package domain_test
+
+import (
+	"ddd-hands-on-go/internal/domain/model/author"
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/book/price"
+	"ddd-hands-on-go/internal/i18n"
+	"strings"
+	"testing"
+)
+
+const (
+	authorIdA    = "6f1c2a3b-4d5e-4f60-8a1b-2c3d4e5f6a7b"
+	authorIdB    = "0e9d8c7b-6a59-4483-b2c1-d0e9f8a7b6c5"
+	publisherIdA = "3a4b5c6d-7e8f-4901-a2b3-c4d5e6f7a8b9"
+)
+
+func TestBuildMetadata(t *testing.T) {
+	// IDは小文字のハイフン区切りの形式に正規化する
+	m, err := book.BuildMetadata([]string{authorIdA, strings.ToUpper(authorIdB)}, publisherIdA, "2024-02-29", "JPN", 480, "PAPERBACK", 2)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	authorIds := m.AuthorIds()
+	if len(authorIds) != 2 || authorIds[0].Value() != authorIdA || authorIds[1].Value() != authorIdB {
+		t.Errorf("著者が不正です: %v", authorIds)
+	}
+	if m.PublisherId().Value() != publisherIdA || m.PublicationDate().String() != "2024-02-29" {
+		t.Errorf("出版社・出版日が不正です: %s, %s", m.PublisherId().Value(), m.PublicationDate())
+	}
+	// 3文字の言語コードは2文字に正規化する
+	if m.Language().Code() != "ja" || m.Language().ISO3() != "jpn" {
+		t.Errorf("言語が不正です: %s, %s", m.Language().Code(), m.Language().ISO3())
+	}
+	if m.PageCount().Value() != 480 || m.Format() != book.Paperback || m.Edition().Value() != 2 {
+		t.Errorf("ページ数・形態・版が不正です: %d, %s, %d", m.PageCount().Value(), m.Format(), m.Edition().Value())
+	}
+
+	// 空の項目は不明とする
+	empty, err := book.BuildMetadata(nil, "", "", "", 0, "", 0)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if len(empty.AuthorIds()) != 0 || empty.PublisherId() != nil || empty.PublicationDate() != nil || empty.Language() != nil ||
+		empty.PageCount() != nil || empty.Format() != "" || empty.Edition() != nil {
+		t.Error("空の書誌情報に値が設定されています")
+	}
+}
+
+func TestBuildMetadata_Invalid(t *testing.T) {
+	manyAuthors := make([]string, book.MaxAuthors+1)
+	for i := range manyAuthors {
+		manyAuthors[i] = author.GenerateAuthorId().Value()
+	}
+
+	tests := []struct {
+		name     string
+		build    func() error
+		wantCode i18n.Key
+	}{
+		{"UUIDでない著者ID", func() error { _, err := book.BuildMetadata([]string{"村上春樹"}, "", "", "", 0, "", 0); return err }, i18n.MsgAuthorIdInvalid},
+		{"著者が多すぎる", func() error { _, err := book.BuildMetadata(manyAuthors, "", "", "", 0, "", 0); return err }, i18n.MsgAuthorsTooMany},
+		// 正規化した後で比較する
+		{"重複する著者", func() error {
+			_, err := book.BuildMetadata([]string{authorIdA, strings.ToUpper(authorIdA)}, "", "", "", 0, "", 0)
+			return err
+		}, i18n.MsgAuthorsDuplicate},
+		{"UUIDでない出版社ID", func() error { _, err := book.BuildMetadata(nil, "新潮社", "", "", 0, "", 0); return err }, i18n.MsgPublisherIdInvalid},
+		{"存在しない日付", func() error { _, err := book.BuildMetadata(nil, "", "2023-02-29", "", 0, "", 0); return err }, i18n.MsgPublicationDateInvalid},
+		{"YYYY-MM-DD形式でない日付", func() error { _, err := book.BuildMetadata(nil, "", "2024/01/01", "", 0, "", 0); return err }, i18n.MsgPublicationDateInvalid},
+		{"未知の言語コード", func() error { _, err := book.BuildMetadata(nil, "", "", "xx", 0, "", 0); return err }, i18n.MsgLanguageInvalid},
+		{"未確定の言語コード", func() error { _, err := book.BuildMetadata(nil, "", "", "und", 0, "", 0); return err }, i18n.MsgLanguageInvalid},
+		{"負のページ数", func() error { _, err := book.BuildMetadata(nil, "", "", "", -1, "", 0); return err }, i18n.MsgPageCountOutOfRange},
+		{"不正な形態", func() error { _, err := book.BuildMetadata(nil, "", "", "", 0, "paperback", 0); return err }, i18n.MsgFormatInvalid},
+		{"上限を超える版", func() error { _, err := book.BuildMetadata(nil, "", "", "", 0, "", book.MaxEdition+1); return err }, i18n.MsgEditionOutOfRange},
+	}
+
+	for _, tt := range tests {
+		if got := i18n.ErrorCode(tt.build()); got != tt.wantCode {
+			t.Errorf("%s: 期待するコード: %s, 実際: %s", tt.name, tt.wantCode, got)
+		}
+	}
+}
+
+func TestNewBook_WithoutMetadata(t *testing.T) {
+	id, _ := book.NewBookId("978-4-00-111111-1")
+	title, _ := book.NewTitle("Test Book")
+	p, _ := price.NewPrice(1000, price.JPY)
+
+	b, err := book.NewBook(id, title, p, nil)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if b.Metadata() == nil || len(b.Metadata().AuthorIds()) != 0 || b.Metadata().PublisherId() != nil {
+		t.Error("書誌情報を指定しない書籍の書誌情報が空ではありません")
+	}
+}
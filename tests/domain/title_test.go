@@ -0,0 +1,89 @@
+package domain_test
+
+import (
+	"ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/i18n"
+	"strings"
+	"testing"
+)
+
+func TestNewTitleWithSubtitle(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		subtitle     string
+		wantValue    string
+		wantSubtitle string
+		wantCode     i18n.Key
+	}{
+		{"前後の空白を取り除く", "  ドメイン駆動設計　", " 入門 ", "ドメイン駆動設計", "入門", ""},
+		// "が" を結合文字（か + 濁点）で表したものはNFCで1文字にまとめる
+		{"NFCに正規化する", "がいど", "", "がいど", "", ""},
+		{"最大文字数ちょうど", strings.Repeat("あ", book.MaxTitleLength), "", strings.Repeat("あ", book.MaxTitleLength), "", ""},
+		{"空白のみのタイトル", "   ", "", "", "", i18n.MsgTitleRequired},
+		{"最大文字数を超えるタイトル", strings.Repeat("あ", book.MaxTitleLength+1), "", "", "", i18n.MsgTitleTooLong},
+		{"制御文字を含むタイトル", "Go\x00言語", "", "", "", i18n.MsgTitleControlCharacter},
+		{"最大文字数を超える副題", "Go", strings.Repeat("a", book.MaxSubtitleLength+1), "", "", i18n.MsgSubtitleTooLong},
+		{"改行を含む副題", "Go", "入門\n編", "", "", i18n.MsgSubtitleControlCharacter},
+	}
+
+	for _, tt := range tests {
+		title, err := book.NewTitleWithSubtitle(tt.value, tt.subtitle)
+		if tt.wantCode != "" {
+			if got := i18n.ErrorCode(err); got != tt.wantCode {
+				t.Errorf("%s: 期待するコード: %s, 実際: %s (%v)", tt.name, tt.wantCode, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: 予期しないエラー: %v", tt.name, err)
+		}
+		if title.Value() != tt.wantValue || title.Subtitle() != tt.wantSubtitle {
+			t.Errorf("%s: 期待する値: %q/%q, 実際: %q/%q", tt.name, tt.wantValue, tt.wantSubtitle, title.Value(), title.Subtitle())
+		}
+	}
+}
+
+func TestTitle_CollationKey(t *testing.T) {
+	key := func(value, subtitle string) string {
+		t.Helper()
+		title, err := book.NewTitleWithSubtitle(value, subtitle)
+		if err != nil {
+			t.Fatalf("Titleの生成に失敗しました: %v", err)
+		}
+		return title.CollationKey()
+	}
+
+	// 同じ照合キーになる組み合わせ
+	equal := [][2]string{
+		{"The Go Programming Language", "go programming language"},
+		{"An  Apple", "apple"},
+		{"ＤＤＤ入門", "ddd入門"},
+		{"ﾄﾞﾒｲﾝ", "ドメイン"},
+		{"ドメイン", "どめいん"},
+	}
+	for _, pair := range equal {
+		if a, b := key(pair[0], ""), key(pair[1], ""); a != b {
+			t.Errorf("照合キーが一致しません: %q -> %q, %q -> %q", pair[0], a, pair[1], b)
+		}
+	}
+
+	// 冠詞だけのタイトルは冠詞を無視しない
+	if got := key("The", ""); got != "the" {
+		t.Errorf("期待する照合キー: the, 実際: %q", got)
+	}
+
+	// 照合キーのバイト順がタイトル順になる
+	ordered := []string{
+		key("Apple", ""),
+		key("Apple", "Basics"),
+		key("Apple", "Cooking"),
+		key("Apple Pie", ""),
+		key("The Banana", ""),
+	}
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i-1] >= ordered[i] {
+			t.Errorf("照合キーの順序が正しくありません: %q >= %q", ordered[i-1], ordered[i])
+		}
+	}
+}
@@ -0,0 +1,444 @@
+package infrastructure_test
+
+import (
+	"context"
+	app_author "ddd-hands-on-go/internal/application/author"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/model/author"
+	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/model/book/price"
+	"ddd-hands-on-go/internal/domain/model/category"
+	"ddd-hands-on-go/internal/domain/model/publisher"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/i18n"
+	"ddd-hands-on-go/internal/infrastructure/memory"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func newBook(t *testing.T, isbn string) *domain_book.Book {
+	t.Helper()
+	id, _ := domain_book.NewBookId(isbn)
+	title, _ := domain_book.NewTitle("Test Book")
+	p, _ := price.NewPrice(1000, price.JPY)
+	b, err := domain_book.NewBook(id, title, p, nil)
+	if err != nil {
+		t.Fatalf("書籍の生成に失敗しました: %v", err)
+	}
+	return b
+}
+
+func TestInMemoryTransactionManager(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := memory.NewInMemoryBookRepository(store)
+	txManager := memory.NewInMemoryTransactionManager(store)
+	queryService := memory.NewInMemoryBookQueryService(store)
+	errAbort := errors.New("abort")
+
+	// 1. 外側はコミットし、セーブポイント内の変更のみ巻き戻す
+	var committed, rolledBack bool
+	err := txManager.Begin(ctx, func(ctx context.Context) error {
+		if err := repo.Save(ctx, newBook(t, "978-4-00-111111-1")); err != nil {
+			return err
+		}
+		txManager.AfterCommit(ctx, func() { committed = true })
+
+		nestedErr := txManager.Begin(ctx, func(ctx context.Context) error {
+			if err := repo.Save(ctx, newBook(t, "978-4-00-222222-2")); err != nil {
+				return err
+			}
+			txManager.AfterCommit(ctx, func() {
+				t.Error("巻き戻したセーブポイント内のコミット後フックが実行されました")
+			})
+			txManager.AfterRollback(ctx, func() { rolledBack = true })
+			return errAbort
+		}, shared.WithPropagation(shared.PropagationNested))
+		if !errors.Is(nestedErr, errAbort) {
+			t.Errorf("期待するエラー: %v, 実際: %v", errAbort, nestedErr)
+		}
+
+		// コミット前の変更は、トランザクション外からは見えない
+		if b, _ := repo.Find(context.Background(), newBook(t, "978-4-00-111111-1").BookId()); b != nil {
+			t.Error("コミット前の書籍がトランザクション外から見えています")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("トランザクションが失敗しました: %v", err)
+	}
+	if !committed || !rolledBack {
+		t.Errorf("期待するフックの実行: コミット後=true, ロールバック後=true, 実際: %t, %t", committed, rolledBack)
+	}
+
+	// 2. エラーを返したトランザクションの変更は破棄される
+	err = txManager.Begin(ctx, func(ctx context.Context) error {
+		if err := repo.Save(ctx, newBook(t, "978-4-00-333333-3")); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Errorf("期待するエラー: %v, 実際: %v", errAbort, err)
+	}
+
+	books, err := queryService.ListBooks(ctx, book.ListBooksQuery{Limit: 10})
+	if err != nil {
+		t.Fatalf("書籍一覧の取得に失敗しました: %v", err)
+	}
+	if len(books) != 1 || books[0].ISBN != "978-4-00-111111-1" {
+		t.Errorf("期待する書籍: [978-4-00-111111-1], 実際: %v", books)
+	}
+}
+
+func TestInMemoryBookQueryService_ListBooksSortByTitle(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := memory.NewInMemoryBookRepository(store)
+	queryService := memory.NewInMemoryBookQueryService(store)
+
+	books := []struct{ isbn, title, subtitle string }{
+		{"978-4-00-111111-1", "The Zebra", ""},
+		{"978-4-00-222222-2", "ｒｅｐｏｒｔ", ""},
+		{"978-4-00-333333-3", "Apple", "Cooking"},
+		{"978-4-00-444444-4", "apple", ""},
+		{"978-4-00-555555-5", "An Orange", ""},
+	}
+	for _, b := range books {
+		id, _ := domain_book.NewBookId(b.isbn)
+		title, _ := domain_book.NewTitleWithSubtitle(b.title, b.subtitle)
+		p, _ := price.NewPrice(1000, price.JPY)
+		bk, err := domain_book.NewBook(id, title, p, nil)
+		if err != nil {
+			t.Fatalf("書籍の生成に失敗しました: %v", err)
+		}
+		if err := repo.Save(ctx, bk); err != nil {
+			t.Fatalf("書籍の保存に失敗しました: %v", err)
+		}
+	}
+
+	got, err := queryService.ListBooks(ctx, book.ListBooksQuery{Sort: book.SortByTitle, Limit: 3, Offset: 1})
+	if err != nil {
+		t.Fatalf("書籍一覧の取得に失敗しました: %v", err)
+	}
+	want := []string{"978-4-00-333333-3", "978-4-00-555555-5", "978-4-00-222222-2"}
+	if len(got) != len(want) {
+		t.Fatalf("期待する件数: %d, 実際: %d", len(want), len(got))
+	}
+	for i, dto := range got {
+		if dto.ISBN != want[i] {
+			t.Errorf("%d件目: 期待する書籍: %s, 実際: %s (%s)", i, want[i], dto.ISBN, dto.Title)
+		}
+	}
+	if got[0].Subtitle != "Cooking" {
+		t.Errorf("期待する副題: Cooking, 実際: %q", got[0].Subtitle)
+	}
+}
+
+func TestInMemoryBookRepository_Metadata(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := memory.NewInMemoryBookRepository(store)
+	queryService := memory.NewInMemoryBookQueryService(store)
+	authorRepo := memory.NewInMemoryAuthorRepository(store)
+	publisherRepo := memory.NewInMemoryPublisherRepository(store)
+
+	authorA := saveAuthor(t, authorRepo, "著者A")
+	authorB := saveAuthor(t, authorRepo, "著者B")
+	publisherName, _ := publisher.NewName("出版社")
+	pub := publisher.NewPublisher(publisher.GeneratePublisherId(), publisherName)
+	if err := publisherRepo.Save(ctx, pub); err != nil {
+		t.Fatalf("出版社の保存に失敗しました: %v", err)
+	}
+
+	id, _ := domain_book.NewBookId("978-4-00-111111-1")
+	title, _ := domain_book.NewTitle("Test Book")
+	p, _ := price.NewPrice(1000, price.JPY)
+	metadata, err := domain_book.BuildMetadata(
+		[]string{authorA.AuthorId().Value(), authorB.AuthorId().Value()}, pub.PublisherId().Value(), "2024-04-01", "ja", 320, "HARDCOVER", 3)
+	if err != nil {
+		t.Fatalf("書誌情報の生成に失敗しました: %v", err)
+	}
+	b, _ := domain_book.NewBook(id, title, p, metadata)
+	if err := repo.Save(ctx, b); err != nil {
+		t.Fatalf("書籍の保存に失敗しました: %v", err)
+	}
+
+	found, err := repo.Find(ctx, id)
+	if err != nil || found == nil {
+		t.Fatalf("書籍の検索に失敗しました: %v", err)
+	}
+	m := found.Metadata()
+	if len(m.AuthorIds()) != 2 || !m.AuthorIds()[1].Equals(authorB.AuthorId()) || !m.PublisherId().Equals(pub.PublisherId()) ||
+		m.PublicationDate().String() != "2024-04-01" || m.Language().Code() != "ja" ||
+		m.PageCount().Value() != 320 || m.Format() != domain_book.Hardcover || m.Edition().Value() != 3 {
+		t.Errorf("復元した書誌情報が保存した値と異なります")
+	}
+
+	dtos, err := queryService.ListBooks(ctx, book.ListBooksQuery{Limit: 10})
+	if err != nil {
+		t.Fatalf("書籍一覧の取得に失敗しました: %v", err)
+	}
+	if len(dtos) != 1 || len(dtos[0].Authors) != 2 || dtos[0].Authors[1].Name != "著者B" || dtos[0].Publisher.Name != "出版社" ||
+		dtos[0].PublicationDate != "2024-04-01" || dtos[0].Format != "HARDCOVER" {
+		t.Errorf("一覧の書誌情報が不正です: %+v", dtos)
+	}
+
+	// 書籍から参照されている著者・出版社は削除できない
+	if err := authorRepo.Delete(ctx, authorA.AuthorId()); shared.KindOf(err) != shared.KindConflict {
+		t.Errorf("期待するエラーの種類: KindConflict, 実際: %v", err)
+	}
+	if err := publisherRepo.Delete(ctx, pub.PublisherId()); shared.KindOf(err) != shared.KindConflict {
+		t.Errorf("期待するエラーの種類: KindConflict, 実際: %v", err)
+	}
+
+	// 著者名の変更は書籍の一覧に反映される
+	newName, _ := author.NewName("著者C")
+	authorB.Rename(newName)
+	if err := authorRepo.Save(ctx, authorB); err != nil {
+		t.Fatalf("著者の保存に失敗しました: %v", err)
+	}
+	dtos, _ = queryService.ListBooks(ctx, book.ListBooksQuery{Limit: 10})
+	if dtos[0].Authors[1].Name != "著者C" {
+		t.Errorf("変更した著者名が反映されていません: %+v", dtos[0].Authors)
+	}
+}
+
+func TestInMemoryStockHistory(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := memory.NewInMemoryBookRepository(store)
+	txManager := memory.NewInMemoryTransactionManager(store)
+	queryService := memory.NewInMemoryStockHistoryQueryService(store)
+	isbn := "978-4-00-111111-1"
+	if err := repo.Save(ctx, newBook(t, isbn)); err != nil {
+		t.Fatalf("書籍の保存に失敗しました: %v", err)
+	}
+
+	// adjust はアプリケーションサービスと同様に、トランザクション内で在庫を調整して保存し、イベントを取り出します。
+	adjust := func(delta int, abort bool) {
+		t.Helper()
+		errAbort := errors.New("abort")
+		err := txManager.Begin(ctx, func(ctx context.Context) error {
+			b, err := repo.Find(ctx, newBook(t, isbn).BookId())
+			if err != nil {
+				return err
+			}
+			if delta > 0 {
+				err = b.IncreaseStock(delta)
+			} else {
+				err = b.DecreaseStock(-delta)
+			}
+			if err != nil {
+				return err
+			}
+			if err := repo.Save(ctx, b); err != nil {
+				return err
+			}
+			b.PullEvents()
+			if abort {
+				return errAbort
+			}
+			return nil
+		})
+		if err != nil && !(abort && errors.Is(err, errAbort)) {
+			t.Fatalf("在庫の調整に失敗しました: %v", err)
+		}
+	}
+	adjust(5, false)
+	adjust(-2, true) // ロールバックした変更は履歴に残らない
+	adjust(1, false)
+	adjust(-3, false)
+
+	deltas := func(limit int) []int {
+		t.Helper()
+		history, err := queryService.ListStockHistory(ctx, []string{isbn, "978-4-00-222222-2"}, limit)
+		if err != nil {
+			t.Fatalf("在庫の変更履歴の取得に失敗しました: %v", err)
+		}
+		var result []int
+		for _, entry := range history[isbn] {
+			result = append(result, entry.Delta)
+		}
+		return result
+	}
+	if got := deltas(10); !slices.Equal(got, []int{-3, 1, 5}) {
+		t.Errorf("期待する変更量（新しい順）: [-3 1 5], 実際: %v", got)
+	}
+	if got := deltas(2); !slices.Equal(got, []int{-3, 1}) {
+		t.Errorf("期待する変更量（最大2件）: [-3 1], 実際: %v", got)
+	}
+
+	history, _ := queryService.ListStockHistory(ctx, []string{isbn}, 1)
+	if e := history[isbn][0]; e.QuantityAvailable != 3 || e.Status != "IN_STOCK" || e.OccurredAt.IsZero() {
+		t.Errorf("変更後の在庫が記録されていません: %+v", e)
+	}
+
+	// 書籍を削除すると履歴も削除される
+	if err := repo.Delete(ctx, newBook(t, isbn).BookId()); err != nil {
+		t.Fatalf("書籍の削除に失敗しました: %v", err)
+	}
+	if got := deltas(10); len(got) != 0 {
+		t.Errorf("削除した書籍の履歴が残っています: %v", got)
+	}
+}
+
+func TestInMemoryAuthorQueryService_ListAuthors(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := memory.NewInMemoryAuthorRepository(store)
+	queryService := memory.NewInMemoryAuthorQueryService(store)
+
+	for _, name := range []string{"c", "a", "b"} {
+		saveAuthor(t, repo, name)
+	}
+
+	dtos, err := queryService.ListAuthors(ctx, app_author.ListAuthorsQuery{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("著者一覧の取得に失敗しました: %v", err)
+	}
+	if len(dtos) != 2 || dtos[0].Name != "b" || dtos[1].Name != "c" {
+		t.Errorf("著者一覧が名前順ではありません: %+v", dtos)
+	}
+}
+
+func saveAuthor(t *testing.T, repo *memory.InMemoryAuthorRepository, name string) *author.Author {
+	t.Helper()
+	n, err := author.NewName(name)
+	if err != nil {
+		t.Fatalf("著者名の生成に失敗しました: %v", err)
+	}
+	a := author.NewAuthor(author.GenerateAuthorId(), n)
+	if err := repo.Save(context.Background(), a); err != nil {
+		t.Fatalf("著者の保存に失敗しました: %v", err)
+	}
+	return a
+}
+
+func TestInMemoryCategory(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	bookRepo := memory.NewInMemoryBookRepository(store)
+	categoryRepo := memory.NewInMemoryCategoryRepository(store)
+	queryService := memory.NewInMemoryBookQueryService(store)
+
+	// 小説 > ミステリー > 警察小説、技術書
+	novel := saveCategory(t, categoryRepo, "小説", nil)
+	mystery := saveCategory(t, categoryRepo, "ミステリー", novel.CategoryId())
+	police := saveCategory(t, categoryRepo, "警察小説", mystery.CategoryId())
+	tech := saveCategory(t, categoryRepo, "技術書", nil)
+
+	for isbn, c := range map[string]*category.Category{"978-4-00-111111-1": police, "978-4-00-222222-2": tech} {
+		b := newBook(t, isbn)
+		if err := b.AssignCategories([]*category.CategoryId{c.CategoryId()}); err != nil {
+			t.Fatalf("カテゴリの割り当てに失敗しました: %v", err)
+		}
+		if err := bookRepo.Save(ctx, b); err != nil {
+			t.Fatalf("書籍の保存に失敗しました: %v", err)
+		}
+	}
+
+	// 祖先のカテゴリでの絞り込みには、子孫のカテゴリが割り当てられた書籍を含む
+	dtos, err := queryService.ListBooks(ctx, book.ListBooksQuery{CategoryId: novel.CategoryId().Value(), Limit: 10})
+	if err != nil {
+		t.Fatalf("書籍一覧の取得に失敗しました: %v", err)
+	}
+	if len(dtos) != 1 || dtos[0].ISBN != "978-4-00-111111-1" || len(dtos[0].Categories) != 1 || dtos[0].Categories[0].Name != "警察小説" {
+		t.Errorf("カテゴリによる絞り込みが不正です: %+v", dtos)
+	}
+
+	tests := []struct {
+		name     string
+		id       *category.CategoryId
+		wantCode i18n.Key
+	}{
+		{"子カテゴリのあるカテゴリ", mystery.CategoryId(), i18n.MsgCategoryHasChildren},
+		{"書籍に割り当てられたカテゴリ", police.CategoryId(), i18n.MsgCategoryInUse},
+	}
+	for _, tt := range tests {
+		err := categoryRepo.Delete(ctx, tt.id)
+		if i18n.ErrorCode(err) != tt.wantCode || shared.KindOf(err) != shared.KindConflict {
+			t.Errorf("%s: 期待するエラー: %s, 実際: %v", tt.name, tt.wantCode, err)
+		}
+	}
+
+	found, err := categoryRepo.Find(ctx, police.CategoryId())
+	if err != nil || found == nil || !found.ParentId().Equals(mystery.CategoryId()) {
+		t.Errorf("削除できなかったカテゴリが復元できません: %v, %v", found, err)
+	}
+}
+
+func saveCategory(t *testing.T, repo *memory.InMemoryCategoryRepository, name string, parentId *category.CategoryId) *category.Category {
+	t.Helper()
+	n, err := category.NewName(name)
+	if err != nil {
+		t.Fatalf("カテゴリ名の生成に失敗しました: %v", err)
+	}
+	c, err := category.NewCategory(category.GenerateCategoryId(), n, parentId)
+	if err != nil {
+		t.Fatalf("カテゴリの生成に失敗しました: %v", err)
+	}
+	if err := repo.Save(context.Background(), c); err != nil {
+		t.Fatalf("カテゴリの保存に失敗しました: %v", err)
+	}
+	return c
+}
+
+func TestInMemorySearchIndex(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := memory.NewInMemoryBookRepository(store)
+	index := memory.NewInMemorySearchIndex(store)
+
+	tanaka := saveAuthor(t, memory.NewInMemoryAuthorRepository(store), "田中ドメイン")
+	books := []struct {
+		isbn, title string
+		authorIds   []string
+	}{
+		{"978-4-00-111111-1", "Go言語入門", []string{tanaka.AuthorId().Value()}},
+		{"978-4-00-222222-2", "ドメイン駆動設計", nil},
+		{"978-4-00-333333-3", "ドメインモデリング", []string{tanaka.AuthorId().Value()}},
+		{"978-4-00-444444-4", "料理の本", nil},
+	}
+	for _, b := range books {
+		id, _ := domain_book.NewBookId(b.isbn)
+		title, _ := domain_book.NewTitle(b.title)
+		p, _ := price.NewPrice(1000, price.JPY)
+		metadata, err := domain_book.BuildMetadata(b.authorIds, "", "", "", 0, "", 0)
+		if err != nil {
+			t.Fatalf("書誌情報の生成に失敗しました: %v", err)
+		}
+		bk, _ := domain_book.NewBook(id, title, p, metadata)
+		if err := repo.Save(ctx, bk); err != nil {
+			t.Fatalf("書籍の保存に失敗しました: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		terms  []string
+		limit  int
+		offset int
+		want   []string
+	}{
+		// タイトルと著者名の両方に一致した書籍、タイトルのみ、著者名のみの順（同じ関連度はISBN順）
+		{"関連度順", []string{"どめいん"}, 10, 0, []string{"978-4-00-333333-3", "978-4-00-222222-2", "978-4-00-111111-1"}},
+		{"全ての検索語に一致", []string{"どめいん", "go"}, 10, 0, []string{"978-4-00-111111-1"}},
+		{"ページ指定", []string{"どめいん"}, 1, 1, []string{"978-4-00-222222-2"}},
+		{"一致なし", []string{"小説"}, 10, 0, nil},
+	}
+	for _, tt := range tests {
+		results, err := index.Search(ctx, tt.terms, tt.limit, tt.offset)
+		if err != nil {
+			t.Fatalf("%s: 書籍の検索に失敗しました: %v", tt.name, err)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, r.Book.ISBN)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: 期待する書籍: %v, 実際: %v", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package infrastructure_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/infrastructure/postgres"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// fakeDB はPostgresTransactionManagerが発行したトランザクションの操作とSQLを記録するdatabase/sqlのドライバーです。
+type fakeDB struct {
+	mu     sync.Mutex
+	log    []string
+	begins []time.Time
+}
+
+func (d *fakeDB) record(s string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, s)
+}
+
+func (d *fakeDB) Connect(ctx context.Context) (driver.Conn, error) { return &fakeConn{db: d}, nil }
+func (d *fakeDB) Driver() driver.Driver                            { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return nil, errors.New("not supported") }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.db.mu.Lock()
+	c.db.begins = append(c.db.begins, time.Now())
+	c.db.mu.Unlock()
+	c.db.record(fmt.Sprintf("BEGIN %s read_only=%t", sql.IsolationLevel(opts.Isolation), opts.ReadOnly))
+	return &fakeTx{db: c.db}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.record(query)
+	return driver.RowsAffected(0), nil
+}
+
+type fakeTx struct{ db *fakeDB }
+
+func (t *fakeTx) Commit() error   { t.db.record("COMMIT"); return nil }
+func (t *fakeTx) Rollback() error { t.db.record("ROLLBACK"); return nil }
+
+func newFakeTransactionManager(t *testing.T) (*postgres.PostgresTransactionManager, *fakeDB) {
+	t.Helper()
+	fake := &fakeDB{}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() { db.Close() })
+	return postgres.NewPostgresTransactionManager(db, nil), fake
+}
+
+func TestPostgresTransactionManager_Nested(t *testing.T) {
+	ctx := context.Background()
+	tm, fake := newFakeTransactionManager(t)
+	errInner := errors.New("inner")
+
+	var hooks []string
+	err := tm.Begin(ctx, func(ctx context.Context) error {
+		tm.AfterCommit(ctx, func() { hooks = append(hooks, "outer commit") })
+
+		// 失敗したネストしたトランザクションは自身のセーブポイントまでのみロールバックされる
+		err := tm.Begin(ctx, func(ctx context.Context) error {
+			tm.AfterCommit(ctx, func() { hooks = append(hooks, "failed nested commit") })
+			tm.AfterRollback(ctx, func() { hooks = append(hooks, "failed nested rollback") })
+			return errInner
+		}, shared.WithPropagation(shared.PropagationNested))
+		if !errors.Is(err, errInner) {
+			t.Errorf("期待するエラー: %v, 実際: %v", errInner, err)
+		}
+
+		if err := tm.Begin(ctx, func(ctx context.Context) error {
+			tm.AfterCommit(ctx, func() { hooks = append(hooks, "nested commit") })
+			return nil
+		}, shared.WithPropagation(shared.PropagationNested)); err != nil {
+			return err
+		}
+
+		// PropagationRequiredは新たなトランザクションやセーブポイントを作らずに参加する
+		return tm.Begin(ctx, func(ctx context.Context) error {
+			if postgres.GetTx(ctx) == nil {
+				t.Error("参加したトランザクションがコンテキストにありません")
+			}
+			return nil
+		})
+	}, shared.WithIsolation(shared.IsolationSerializable))
+	if err != nil {
+		t.Fatalf("トランザクションが失敗しました: %v", err)
+	}
+
+	wantLog := []string{
+		"BEGIN Serializable read_only=false",
+		"SAVEPOINT sp_1",
+		"ROLLBACK TO SAVEPOINT sp_1",
+		"SAVEPOINT sp_2",
+		"RELEASE SAVEPOINT sp_2",
+		"COMMIT",
+	}
+	if !slices.Equal(fake.log, wantLog) {
+		t.Errorf("期待する操作: %v, 実際: %v", wantLog, fake.log)
+	}
+	wantHooks := []string{"failed nested rollback", "outer commit", "nested commit"}
+	if !slices.Equal(hooks, wantHooks) {
+		t.Errorf("期待するフックの実行: %v, 実際: %v", wantHooks, hooks)
+	}
+}
+
+func TestPostgresTransactionManager_JoinedFailureRollsBackOuter(t *testing.T) {
+	ctx := context.Background()
+	tm, fake := newFakeTransactionManager(t)
+	errInner := errors.New("inner")
+
+	err := tm.Begin(ctx, func(ctx context.Context) error {
+		return tm.Begin(ctx, func(ctx context.Context) error { return errInner })
+	})
+	if !errors.Is(err, errInner) {
+		t.Errorf("期待するエラー: %v, 実際: %v", errInner, err)
+	}
+	if want := []string{"BEGIN Default read_only=false", "ROLLBACK"}; !slices.Equal(fake.log, want) {
+		t.Errorf("期待する操作: %v, 実際: %v", want, fake.log)
+	}
+}
+
+func TestPostgresTransactionManager_OptionMismatch(t *testing.T) {
+	ctx := context.Background()
+	tm, fake := newFakeTransactionManager(t)
+	called := false
+	inner := func(ctx context.Context) error { called = true; return nil }
+
+	tests := []struct {
+		name    string
+		outer   []shared.TxOption
+		inner   []shared.TxOption
+		wantErr bool
+	}{
+		{"異なる分離レベル", []shared.TxOption{shared.WithIsolation(shared.IsolationReadCommitted)}, []shared.TxOption{shared.WithIsolation(shared.IsolationSerializable)}, true},
+		{"同じ分離レベル", []shared.TxOption{shared.WithIsolation(shared.IsolationReadCommitted)}, []shared.TxOption{shared.WithIsolation(shared.IsolationReadCommitted)}, false},
+		{"読み取り専用内の書き込み", []shared.TxOption{shared.WithReadOnly()}, nil, true},
+		{"読み取り専用内の読み取り専用", []shared.TxOption{shared.WithReadOnly()}, []shared.TxOption{shared.WithReadOnly()}, false},
+		{"書き込み内の読み取り専用", nil, []shared.TxOption{shared.WithReadOnly()}, false},
+	}
+	for _, tt := range tests {
+		called = false
+		var innerErr error
+		tm.Begin(ctx, func(ctx context.Context) error {
+			innerErr = tm.Begin(ctx, inner, tt.inner...)
+			return nil
+		}, tt.outer...)
+		if (innerErr != nil) != tt.wantErr || called == tt.wantErr {
+			t.Errorf("%s: 期待するエラーの有無: %t, 実際: %v (関数の実行: %t)", tt.name, tt.wantErr, innerErr, called)
+		}
+	}
+	if fake.log[0] != "BEGIN Read Committed read_only=false" || fake.log[4] != "BEGIN Default read_only=true" {
+		t.Errorf("分離レベルと読み取り専用属性がトランザクションに指定されていません: %v", fake.log)
+	}
+}
+
+func TestPostgresTransactionManager_Retry(t *testing.T) {
+	ctx := context.Background()
+	policy := postgres.DefaultRetryPolicy
+
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{"シリアライゼーション失敗", &pq.Error{Code: "40001"}, policy.MaxAttempts},
+		{"デッドロック", &pq.Error{Code: "40P01"}, policy.MaxAttempts},
+		{"一意制約違反", &pq.Error{Code: "23505"}, 1},
+		{"データベース以外のエラー", errors.New("failed"), 1},
+	}
+	for _, tt := range tests {
+		tm, fake := newFakeTransactionManager(t)
+		err := tm.Begin(ctx, func(ctx context.Context) error { return fmt.Errorf("wrapped: %w", tt.err) })
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: 期待するエラー: %v, 実際: %v", tt.name, tt.err, err)
+		}
+		if len(fake.begins) != tt.wantAttempts {
+			t.Errorf("%s: 期待する試行回数: %d, 実際: %d", tt.name, tt.wantAttempts, len(fake.begins))
+		}
+		// 実際の時間では待機時間の下限のみを確認する（上限はマシンの負荷で超えることがあるため確認しない）
+		for i := 1; i < len(fake.begins); i++ {
+			if d := fake.begins[i].Sub(fake.begins[i-1]); d < policy.InitialBackoff {
+				t.Errorf("%s: %d回目の再試行までの待機時間が %v より短いです: %v", tt.name, i, policy.InitialBackoff, d)
+			}
+		}
+	}
+}
+
+func TestPostgresTransactionManager_RetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tm, fake := newFakeTransactionManager(t)
+	policy := postgres.DefaultRetryPolicy
+	policy.Sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+	tm = tm.WithRetryPolicy(policy)
+
+	serializationFailure := &pq.Error{Code: "40001"}
+	err := tm.Begin(ctx, func(ctx context.Context) error { return serializationFailure })
+	if !errors.Is(err, serializationFailure) || !errors.Is(err, context.Canceled) {
+		t.Errorf("期待するエラー: シリアライゼーション失敗とキャンセル, 実際: %v", err)
+	}
+	if len(fake.begins) != 1 {
+		t.Errorf("キャンセル後に再試行されました: 試行回数 %d", len(fake.begins))
+	}
+}
+
+func TestPostgresTransactionManager_RetryBackoffCap(t *testing.T) {
+	ctx := context.Background()
+	tm, fake := newFakeTransactionManager(t)
+	// 上限がなければ待機時間は10ms, 20ms, 40ms, 80ms, 160msと増える
+	var sleeps []time.Duration
+	tm = tm.WithRetryPolicy(postgres.RetryPolicy{
+		MaxAttempts: 6, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 20 * time.Millisecond,
+		Sleep: func(ctx context.Context, d time.Duration) error {
+			sleeps = append(sleeps, d)
+			return nil
+		},
+	})
+
+	attempts := 0
+	err := tm.Begin(ctx, func(ctx context.Context) error {
+		if attempts++; attempts < 6 {
+			return &pq.Error{Code: "40001"}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("再試行後のトランザクションが失敗しました: %v", err)
+	}
+	if fake.log[len(fake.log)-1] != "COMMIT" {
+		t.Errorf("最後の試行がコミットされていません: %v", fake.log)
+	}
+
+	want := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond}
+	if !slices.Equal(sleeps, want) {
+		t.Errorf("期待する待機時間: %v, 実際: %v", want, sleeps)
+	}
+}
@@ -0,0 +1,126 @@
+package presentation_test
+
+import (
+	"ddd-hands-on-go/cmd/api/handler"
+	"ddd-hands-on-go/cmd/api/middleware"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/auth"
+	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/infrastructure/event"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testJWTSecret = "test-secret"
+
+func signTestJWT(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("JWTの署名に失敗しました: %v", err)
+	}
+	return token
+}
+
+func TestAuth(t *testing.T) {
+	apiKeys, err := auth.NewAPIKeyAuthenticator("reader:viewer:viewer-key,alice:editor:editor-key")
+	if err != nil {
+		t.Fatalf("APIキーの設定に失敗しました: %v", err)
+	}
+	jwtAuth, err := auth.NewJWTAuthenticator(auth.JWTConfig{HMACSecret: testJWTSecret, Issuer: "test-issuer"})
+	if err != nil {
+		t.Fatalf("JWTの設定に失敗しました: %v", err)
+	}
+
+	repo := &mockBookRepository{books: make(map[string]*domain_book.Book)}
+	txManager := &mockTransactionManager{}
+	refCheck, assembler := newBookReferences()
+	emitter := event.NewEventEmitter()
+	var actors []string
+	emitter.Subscribe("BookCreated", func(e shared.DomainEvent) {
+		actors = append(actors, e.(shared.CorrelatedEvent).Actor())
+	})
+
+	bookHandler := handler.NewBookHandler(
+		book.NewRegisterBookApplicationService(repo, txManager, service.NewISBNDuplicationCheckDomainService(repo), refCheck, assembler, emitter),
+		book.NewGetBookApplicationService(repo, assembler),
+		book.NewUpdateBookApplicationService(repo, txManager, assembler, emitter),
+		book.NewDeleteBookApplicationService(repo, txManager, emitter),
+		book.NewAdjustStockApplicationService(repo, txManager, assembler, emitter),
+	)
+	mux := http.NewServeMux()
+	bookHandler.RegisterRoutes(mux)
+	handler.NewHealthHandler().RegisterRoutes(mux)
+	authMiddleware := middleware.NewAuth([]auth.Authenticator{apiKeys, jwtAuth}, handler.RouteRoles)
+	server := httptest.NewServer(authMiddleware.Middleware(mux, mux))
+	defer server.Close()
+
+	warehouseToken := signTestJWT(t, jwt.MapClaims{
+		"sub": "inventory-bot", "roles": []string{"warehouse"}, "iss": "test-issuer",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	expiredToken := signTestJWT(t, jwt.MapClaims{
+		"sub": "inventory-bot", "roles": []string{"warehouse"}, "iss": "test-issuer",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	})
+	otherIssuerToken := signTestJWT(t, jwt.MapClaims{
+		"sub": "inventory-bot", "roles": []string{"admin"}, "iss": "other-issuer",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	const registerBody = `{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500}`
+	tests := []struct {
+		name           string
+		method, path   string
+		body           string
+		apiKey, bearer string
+		wantStatus     int
+	}{
+		{"ヘルスチェックは認証不要", http.MethodGet, "/healthz", "", "", "", http.StatusOK},
+		{"認証情報なし", http.MethodPost, "/books", registerBody, "", "", http.StatusUnauthorized},
+		{"未登録のAPIキー", http.MethodPost, "/books", registerBody, "unknown-key", "", http.StatusUnauthorized},
+		{"viewerは登録できない", http.MethodPost, "/books", registerBody, "viewer-key", "", http.StatusForbidden},
+		{"editorは登録できる", http.MethodPost, "/books", registerBody, "editor-key", "", http.StatusCreated},
+		{"viewerは参照できる", http.MethodGet, "/books/978-4-00-111111-1", "", "viewer-key", "", http.StatusOK},
+		{"editorは在庫を調整できない", http.MethodPost, "/books/978-4-00-111111-1/stock/adjustments", `{"delta":5}`, "editor-key", "", http.StatusForbidden},
+		{"warehouseは在庫を調整できる", http.MethodPost, "/books/978-4-00-111111-1/stock/adjustments", `{"delta":5}`, "", warehouseToken, http.StatusOK},
+		{"warehouseは参照できる", http.MethodGet, "/books/978-4-00-111111-1", "", "", warehouseToken, http.StatusOK},
+		{"期限切れのJWT", http.MethodGet, "/books/978-4-00-111111-1", "", "", expiredToken, http.StatusUnauthorized},
+		{"発行者が異なるJWT", http.MethodGet, "/books/978-4-00-111111-1", "", "", otherIssuerToken, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.apiKey != "" {
+				req.Header.Set(middleware.APIKeyHeader, tt.apiKey)
+			}
+			if tt.bearer != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("リクエストに失敗しました: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("ステータスコードが不正です: got %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if resp.StatusCode == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") == "" {
+				t.Error("401のレスポンスにWWW-Authenticateヘッダーがありません")
+			}
+		})
+	}
+
+	// 認証されたアクターがドメインイベントに記録される
+	if len(actors) != 1 || actors[0] != "alice" {
+		t.Errorf("イベントのアクターが不正です: %v", actors)
+	}
+}
@@ -0,0 +1,245 @@
+package presentation_test
+
+import (
+	"context"
+	"ddd-hands-on-go/cmd/api/graphqlserver"
+	"ddd-hands-on-go/cmd/api/middleware"
+	"ddd-hands-on-go/internal/application/book"
+	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/service"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingBookRepository はFind/FindManyの呼び出し回数を記録するリポジトリです。
+type countingBookRepository struct {
+	*mockBookRepository
+	mu            sync.Mutex
+	findCalls     int
+	findManyCalls int
+}
+
+func (r *countingBookRepository) Find(ctx context.Context, bookId *domain_book.BookId) (*domain_book.Book, error) {
+	r.mu.Lock()
+	r.findCalls++
+	r.mu.Unlock()
+	return r.mockBookRepository.Find(ctx, bookId)
+}
+
+func (r *countingBookRepository) FindMany(ctx context.Context, bookIds []*domain_book.BookId) ([]*domain_book.Book, error) {
+	r.mu.Lock()
+	r.findManyCalls++
+	r.mu.Unlock()
+	return r.mockBookRepository.FindMany(ctx, bookIds)
+}
+
+// mockBookQueryService はmockBookRepositoryの書籍をISBN順に返すクエリサービスです。
+// 著者・出版社の名前はassemblerで補います。
+type mockBookQueryService struct {
+	repo      *mockBookRepository
+	assembler *book.BookDTOAssembler
+}
+
+func (q *mockBookQueryService) ListBooks(ctx context.Context, query book.ListBooksQuery) ([]*book.BookDTO, error) {
+	isbns := make([]string, 0, len(q.repo.books))
+	for isbn := range q.repo.books {
+		isbns = append(isbns, isbn)
+	}
+	sort.Strings(isbns)
+
+	books := make([]*domain_book.Book, 0)
+	for _, isbn := range isbns {
+		b := q.repo.books[isbn]
+		if query.Status != "" && b.Stock().Status().Value().String() != query.Status {
+			continue
+		}
+		if query.MaxQuantityAvailable != nil && b.Stock().QuantityAvailable().Value() > *query.MaxQuantityAvailable {
+			continue
+		}
+		books = append(books, b)
+	}
+	return q.assembler.AssembleMany(ctx, books)
+}
+
+func (q *mockBookQueryService) EachBook(ctx context.Context, f func(*book.BookDTO) error) error {
+	dtos, err := q.ListBooks(ctx, book.ListBooksQuery{})
+	if err != nil {
+		return err
+	}
+	for _, dto := range dtos {
+		if err := f(dto); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mockStockHistoryQueryService は書籍ごとに固定の変更履歴を返し、呼び出しごとの取得件数を記録するクエリサービスです。
+type mockStockHistoryQueryService struct {
+	mu     sync.Mutex
+	limits []int
+}
+
+func (q *mockStockHistoryQueryService) ListStockHistory(ctx context.Context, isbns []string, limit int) (map[string][]*book.StockHistoryEntryDTO, error) {
+	q.mu.Lock()
+	q.limits = append(q.limits, limit)
+	q.mu.Unlock()
+
+	history := make(map[string][]*book.StockHistoryEntryDTO)
+	for _, isbn := range isbns {
+		if isbn != "978-4-00-111111-1" {
+			continue
+		}
+		entries := []*book.StockHistoryEntryDTO{
+			{Delta: -1, QuantityAvailable: 2, Status: "IN_STOCK", OccurredAt: time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC)},
+			{Delta: 3, QuantityAvailable: 3, Status: "IN_STOCK", OccurredAt: time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)},
+		}
+		history[isbn] = entries[:min(limit, len(entries))]
+	}
+	return history, nil
+}
+
+type graphqlResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string            `json:"message"`
+		Extensions map[string]string `json:"extensions"`
+	} `json:"errors"`
+}
+
+func TestGraphQLHandler(t *testing.T) {
+	repo := &countingBookRepository{mockBookRepository: &mockBookRepository{books: make(map[string]*domain_book.Book)}}
+	txManager := &mockTransactionManager{}
+	refCheck, assembler := newBookReferences()
+	publisher := &mockEventPublisher{}
+	getBookService := book.NewGetBookApplicationService(repo, assembler)
+	historyQueryService := &mockStockHistoryQueryService{}
+
+	resolver := graphqlserver.NewResolver(
+		book.NewRegisterBookApplicationService(repo, txManager, service.NewISBNDuplicationCheckDomainService(repo), refCheck, assembler, publisher),
+		getBookService,
+		book.NewListBooksApplicationService(&mockBookQueryService{repo: repo.mockBookRepository, assembler: assembler}),
+		book.NewAdjustStockApplicationService(repo, txManager, assembler, publisher),
+		book.NewListStockHistoryApplicationService(historyQueryService),
+	)
+	schema, err := graphqlserver.NewSchema(resolver)
+	if err != nil {
+		t.Fatalf("GraphQLスキーマの生成に失敗しました: %v", err)
+	}
+	srv := httptest.NewServer(middleware.Locale(graphqlserver.NewHandler(schema, resolver)))
+	t.Cleanup(srv.Close)
+
+	execWithLanguage := func(query, acceptLanguage string) graphqlResponse {
+		t.Helper()
+		body, _ := json.Marshal(map[string]string{"query": query})
+		req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(string(body)))
+		req.Header.Set("Content-Type", "application/json")
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("リクエストに失敗しました: %v", err)
+		}
+		defer resp.Body.Close()
+		var out graphqlResponse
+		if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+			t.Fatalf("レスポンスのデコードに失敗しました: %v", err)
+		}
+		return out
+	}
+	exec := func(query string) graphqlResponse {
+		t.Helper()
+		return execWithLanguage(query, "")
+	}
+
+	// 1. ミューテーション: 登録と在庫調整
+	for _, isbn := range []string{"978-4-00-111111-1", "978-4-00-222222-2", "978-4-00-333333-3"} {
+		out := exec(`mutation { registerBook(input: {isbn: "` + isbn + `", title: "Test Book", price: 1500}) { isbn } }`)
+		if len(out.Errors) > 0 {
+			t.Fatalf("書籍登録に失敗しました: %v", out.Errors)
+		}
+	}
+	out := exec(`mutation { adjustStock(isbn: "978-4-00-111111-1", delta: 3) { quantityAvailable status } }`)
+	if got := string(out.Data["adjustStock"]); got != `{"quantityAvailable":3,"status":"IN_STOCK"}` {
+		t.Errorf("期待する調整結果: 在庫3/IN_STOCK, 実際: %s", got)
+	}
+
+	// 2. 同一リクエスト内の複数のbookフィールドは1回のFindManyにまとめられる
+	repo.findCalls, repo.findManyCalls = 0, 0
+	out = exec(`{
+		a: book(isbn: "978-4-00-111111-1") { title }
+		b: book(isbn: "978-4-00-222222-2") { title }
+		c: book(isbn: "978-4-00-999999-9") { title }
+	}`)
+	if len(out.Errors) > 0 {
+		t.Fatalf("書籍取得に失敗しました: %v", out.Errors)
+	}
+	if repo.findManyCalls != 1 || repo.findCalls != 0 {
+		t.Errorf("期待する呼び出し: FindMany 1回/Find 0回, 実際: FindMany %d回/Find %d回", repo.findManyCalls, repo.findCalls)
+	}
+	if got := string(out.Data["c"]); got != "null" {
+		t.Errorf("存在しない書籍はnullであるべきです, 実際: %s", got)
+	}
+
+	// 3. 一覧の在庫ステータスによる絞り込み
+	out = exec(`{ books(status: IN_STOCK) { isbn } }`)
+	if got := string(out.Data["books"]); got != `[{"isbn":"978-4-00-111111-1"}]` {
+		t.Errorf("期待する一覧: [978-4-00-111111-1], 実際: %s", got)
+	}
+
+	// 4. 在庫数の変更履歴は取得件数ごとにまとめて取得される
+	out = exec(`{
+		a: book(isbn: "978-4-00-111111-1") { stockHistory { delta quantityAvailable status occurredAt } }
+		b: book(isbn: "978-4-00-222222-2") { stockHistory { delta } }
+		c: book(isbn: "978-4-00-111111-1") { latest: stockHistory(first: 1) { delta } }
+	}`)
+	if len(out.Errors) > 0 {
+		t.Fatalf("在庫の変更履歴の取得に失敗しました: %v", out.Errors)
+	}
+	wantHistory := `{"stockHistory":[{"delta":-1,"quantityAvailable":2,"status":"IN_STOCK","occurredAt":"2024-04-02T09:00:00Z"},` +
+		`{"delta":3,"quantityAvailable":3,"status":"IN_STOCK","occurredAt":"2024-04-01T09:00:00Z"}]}`
+	if got := string(out.Data["a"]); got != wantHistory {
+		t.Errorf("期待する履歴: %s, 実際: %s", wantHistory, got)
+	}
+	if got := string(out.Data["b"]); got != `{"stockHistory":[]}` {
+		t.Errorf("履歴のない書籍は空のリストであるべきです, 実際: %s", got)
+	}
+	if got := string(out.Data["c"]); got != `{"latest":[{"delta":-1}]}` {
+		t.Errorf("期待する履歴（最大1件）: [{delta:-1}], 実際: %s", got)
+	}
+	sort.Ints(historyQueryService.limits)
+	if !slices.Equal(historyQueryService.limits, []int{1, 20}) {
+		t.Errorf("期待する呼び出し（取得件数）: [1 20], 実際: %v", historyQueryService.limits)
+	}
+
+	out = exec(`{ book(isbn: "978-4-00-111111-1") { stockHistory(first: 101) { delta } } }`)
+	if len(out.Errors) != 1 || out.Errors[0].Extensions["code"] != "BAD_USER_INPUT" {
+		t.Errorf("期待するエラーコード: BAD_USER_INPUT, 実際: %v", out.Errors)
+	}
+
+	// 5. ドメインエラーはextensions.codeに種類が設定され、メッセージはAccept-Languageの言語で記述される
+	for _, tc := range []struct {
+		acceptLanguage string
+		wantMessage    string
+	}{
+		{"", "在庫の調整に失敗しました: 在庫が不足しています"},
+		{"en-US,ja;q=0.5", "Failed to adjust the stock: Insufficient stock"},
+	} {
+		out = execWithLanguage(`mutation { adjustStock(isbn: "978-4-00-222222-2", delta: -1) { isbn } }`, tc.acceptLanguage)
+		if len(out.Errors) != 1 || out.Errors[0].Extensions["code"] != "CONFLICT" {
+			t.Errorf("期待するエラーコード: CONFLICT, 実際: %v", out.Errors)
+			continue
+		}
+		if got := out.Errors[0].Message; got != tc.wantMessage {
+			t.Errorf("Accept-Language %q: 期待するメッセージ: %q, 実際: %q", tc.acceptLanguage, tc.wantMessage, got)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package presentation_test
+
+import (
+	"context"
+	bookv1 "ddd-hands-on-go/api/gen/book/v1"
+	"ddd-hands-on-go/cmd/api/grpcserver"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/model/author"
+	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/infrastructure/event"
+	"ddd-hands-on-go/internal/infrastructure/memory"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestGRPCClient はインメモリの接続でgRPCサーバーに接続したクライアントを生成します。
+// 書籍から参照する著者・出版社・カテゴリはstoreから読み込みます。
+// サーバーの停止を検証できるよう、サーバーと監視の管理も返します。
+func newTestGRPCClient(t *testing.T, store *memory.Store) (bookv1.BookServiceClient, *grpc.Server, *grpcserver.BookWatcher) {
+	t.Helper()
+
+	repo := &mockBookRepository{books: make(map[string]*domain_book.Book)}
+	txManager := &mockTransactionManager{}
+	authorRepo := memory.NewInMemoryAuthorRepository(store)
+	publisherRepo := memory.NewInMemoryPublisherRepository(store)
+	categoryRepo := memory.NewInMemoryCategoryRepository(store)
+	refCheck := service.NewBookReferenceCheckDomainService(authorRepo, publisherRepo, categoryRepo)
+	assembler := book.NewBookDTOAssembler(authorRepo, publisherRepo, categoryRepo)
+	dupSvc := service.NewISBNDuplicationCheckDomainService(repo)
+	emitter := event.NewEventEmitter()
+
+	watcher := grpcserver.NewBookWatcher(emitter)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcserver.LocaleUnaryInterceptor),
+		grpc.ChainStreamInterceptor(grpcserver.LocaleStreamInterceptor),
+	)
+	bookv1.RegisterBookServiceServer(srv, grpcserver.NewBookServer(
+		book.NewRegisterBookApplicationService(repo, txManager, dupSvc, refCheck, assembler, emitter),
+		book.NewGetBookApplicationService(repo, assembler),
+		book.NewAdjustStockApplicationService(repo, txManager, assembler, emitter),
+		watcher,
+	))
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("gRPCクライアントの生成に失敗しました: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return bookv1.NewBookServiceClient(conn), srv, watcher
+}
+
+func TestBookServer(t *testing.T) {
+	client, _, _ := newTestGRPCClient(t, memory.NewStore())
+	ctx := context.Background()
+	isbn := "978-4-00-111111-1"
+
+	// 1. 正常系: 登録
+	registered, err := client.RegisterBook(ctx, &bookv1.RegisterBookRequest{Isbn: isbn, Title: "Test Book", Price: 1500})
+	if err != nil {
+		t.Fatalf("書籍登録に失敗しました: %v", err)
+	}
+	if registered.GetStatus() != bookv1.StockStatus_STOCK_STATUS_OUT_OF_STOCK {
+		t.Errorf("期待するステータス: OUT_OF_STOCK, 実際: %v", registered.GetStatus())
+	}
+
+	// 2. 異常系: HTTPと同じ種類のエラーがgRPCのステータスコードへ対応付けられる
+	errorCases := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"重複登録", func() error {
+			_, err := client.RegisterBook(ctx, &bookv1.RegisterBookRequest{Isbn: isbn, Title: "Test Book", Price: 1500})
+			return err
+		}, codes.AlreadyExists},
+		{"不正な価格", func() error {
+			_, err := client.RegisterBook(ctx, &bookv1.RegisterBookRequest{Isbn: "978-4-00-222222-2", Title: "Test Book", Price: -1})
+			return err
+		}, codes.InvalidArgument},
+		{"存在しない書籍", func() error {
+			_, err := client.GetBook(ctx, &bookv1.GetBookRequest{Isbn: "978-4-00-999999-9"})
+			return err
+		}, codes.NotFound},
+		{"在庫不足", func() error {
+			_, err := client.AdjustStock(ctx, &bookv1.AdjustStockRequest{Isbn: isbn, Delta: -1})
+			return err
+		}, codes.FailedPrecondition},
+	}
+	for _, tc := range errorCases {
+		if got := status.Code(tc.call()); got != tc.want {
+			t.Errorf("%s: 期待するコード: %v, 実際: %v", tc.name, tc.want, got)
+		}
+	}
+
+	// ステータスのメッセージはメタデータのaccept-languageの言語で記述される
+	for _, tc := range []struct {
+		acceptLanguage string
+		wantMessage    string
+	}{
+		{"", "在庫の調整に失敗しました: 在庫が不足しています"},
+		{"en", "Failed to adjust the stock: Insufficient stock"},
+	} {
+		callCtx := ctx
+		if tc.acceptLanguage != "" {
+			callCtx = metadata.AppendToOutgoingContext(ctx, grpcserver.LocaleMetadataKey, tc.acceptLanguage)
+		}
+		_, err := client.AdjustStock(callCtx, &bookv1.AdjustStockRequest{Isbn: isbn, Delta: -1})
+		if got := status.Convert(err).Message(); got != tc.wantMessage {
+			t.Errorf("accept-language %q: 期待するメッセージ: %q, 実際: %q", tc.acceptLanguage, tc.wantMessage, got)
+		}
+	}
+
+	// 3. WatchBook: 初期状態の後に在庫調整の結果が届く
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := client.WatchBook(watchCtx, &bookv1.WatchBookRequest{Isbn: isbn})
+	if err != nil {
+		t.Fatalf("WatchBookの開始に失敗しました: %v", err)
+	}
+	initial, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("初期状態の受信に失敗しました: %v", err)
+	}
+	if initial.GetBook().GetQuantityAvailable() != 0 {
+		t.Errorf("期待する初期在庫: 0, 実際: %d", initial.GetBook().GetQuantityAvailable())
+	}
+
+	if _, err := client.AdjustStock(ctx, &bookv1.AdjustStockRequest{Isbn: isbn, Delta: 5}); err != nil {
+		t.Fatalf("在庫調整に失敗しました: %v", err)
+	}
+
+	changed, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("変更通知の受信に失敗しました: %v", err)
+	}
+	if changed.GetEventName() != "StockQuantityChanged" || changed.GetBook().GetQuantityAvailable() != 5 {
+		t.Errorf("期待する通知: StockQuantityChanged(在庫5), 実際: %s(在庫%d)", changed.GetEventName(), changed.GetBook().GetQuantityAvailable())
+	}
+}
+
+func TestBookServer_Metadata(t *testing.T) {
+	store := memory.NewStore()
+	client, _, _ := newTestGRPCClient(t, store)
+	ctx := context.Background()
+
+	name, _ := author.NewName("著者A")
+	a := author.NewAuthor(author.GenerateAuthorId(), name)
+	if err := memory.NewInMemoryAuthorRepository(store).Save(ctx, a); err != nil {
+		t.Fatalf("著者の保存に失敗しました: %v", err)
+	}
+
+	registered, err := client.RegisterBook(ctx, &bookv1.RegisterBookRequest{
+		Isbn: "978-4-00-111111-1", Title: "Test Book", Subtitle: "Sub", Price: 1500,
+		AuthorIds: []string{a.AuthorId().Value()}, PublicationDate: "2024-04-01", Language: "jpn",
+		PageCount: 320, Format: bookv1.BookFormat_BOOK_FORMAT_PAPERBACK, Edition: 2,
+	})
+	if err != nil {
+		t.Fatalf("書籍登録に失敗しました: %v", err)
+	}
+	got, err := client.GetBook(ctx, &bookv1.GetBookRequest{Isbn: "978-4-00-111111-1"})
+	if err != nil {
+		t.Fatalf("書籍の取得に失敗しました: %v", err)
+	}
+	for _, b := range []*bookv1.Book{registered, got} {
+		if b.GetSubtitle() != "Sub" || len(b.GetAuthors()) != 1 || b.GetAuthors()[0].GetName() != "著者A" ||
+			b.GetPublisher() != nil || b.GetPublicationDate() != "2024-04-01" || b.GetLanguage() != "ja" ||
+			b.GetPageCount() != 320 || b.GetFormat() != bookv1.BookFormat_BOOK_FORMAT_PAPERBACK || b.GetEdition() != 2 {
+			t.Errorf("書誌情報が不正です: %v", b)
+		}
+	}
+
+	errorCases := []struct {
+		name string
+		req  *bookv1.RegisterBookRequest
+	}{
+		{"存在しない著者", &bookv1.RegisterBookRequest{Isbn: "978-4-00-222222-2", Title: "Test Book", Price: 1500, AuthorIds: []string{author.GenerateAuthorId().Value()}}},
+		{"未知の形態", &bookv1.RegisterBookRequest{Isbn: "978-4-00-222222-2", Title: "Test Book", Price: 1500, Format: bookv1.BookFormat(9)}},
+	}
+	for _, tc := range errorCases {
+		if _, err := client.RegisterBook(ctx, tc.req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: 期待するコード: %v, 実際: %v", tc.name, codes.InvalidArgument, err)
+		}
+	}
+}
+
+func TestBookServer_WatchBookEndsOnShutdown(t *testing.T) {
+	client, srv, watcher := newTestGRPCClient(t, memory.NewStore())
+	ctx := context.Background()
+	isbn := "978-4-00-333333-3"
+
+	if _, err := client.RegisterBook(ctx, &bookv1.RegisterBookRequest{Isbn: isbn, Title: "Test Book", Price: 1500}); err != nil {
+		t.Fatalf("書籍登録に失敗しました: %v", err)
+	}
+	stream, err := client.WatchBook(ctx, &bookv1.WatchBookRequest{Isbn: isbn})
+	if err != nil {
+		t.Fatalf("WatchBookの開始に失敗しました: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("初期状態の受信に失敗しました: %v", err)
+	}
+
+	// 監視を終了すると、クライアントが切断しなくてもストリームが終わり、GracefulStopが完了する
+	watcher.Close()
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("期待するコード: %v, 実際: %v", codes.Unavailable, err)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("GracefulStopが完了しませんでした")
+	}
+}
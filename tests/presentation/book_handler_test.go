@@ -0,0 +1,599 @@
+package presentation_test
+
+import (
+	"context"
+	"ddd-hands-on-go/api"
+	"ddd-hands-on-go/cmd/api/handler"
+	"ddd-hands-on-go/cmd/api/middleware"
+	"ddd-hands-on-go/internal/application/author"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/application/category"
+	app_publisher "ddd-hands-on-go/internal/application/publisher"
+	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/infrastructure/memory"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Mock Repositories
+type mockBookRepository struct {
+	books map[string]*domain_book.Book
+}
+
+func (m *mockBookRepository) Save(ctx context.Context, b *domain_book.Book) error {
+	m.books[b.BookId().Value()] = b
+	return nil
+}
+
+func (m *mockBookRepository) Find(ctx context.Context, bookId *domain_book.BookId) (*domain_book.Book, error) {
+	if b, ok := m.books[bookId.Value()]; ok {
+		return b, nil
+	}
+	return nil, nil // Not found
+}
+
+func (m *mockBookRepository) FindForUpdate(ctx context.Context, bookId *domain_book.BookId) (*domain_book.Book, error) {
+	return m.Find(ctx, bookId)
+}
+
+func (m *mockBookRepository) FindMany(ctx context.Context, bookIds []*domain_book.BookId) ([]*domain_book.Book, error) {
+	books := make([]*domain_book.Book, 0, len(bookIds))
+	for _, id := range bookIds {
+		if b, ok := m.books[id.Value()]; ok {
+			books = append(books, b)
+		}
+	}
+	return books, nil
+}
+
+func (m *mockBookRepository) Delete(ctx context.Context, bookId *domain_book.BookId) error {
+	delete(m.books, bookId.Value())
+	return nil
+}
+
+type mockTransactionManager struct{}
+
+func (m *mockTransactionManager) Begin(ctx context.Context, f func(ctx context.Context) error, opts ...shared.TxOption) error {
+	return f(ctx)
+}
+
+func (m *mockTransactionManager) AfterCommit(ctx context.Context, f func()) {
+	f()
+}
+
+func (m *mockTransactionManager) AfterRollback(ctx context.Context, f func()) {}
+
+type mockEventPublisher struct{}
+
+func (m *mockEventPublisher) Publish(event shared.DomainEvent) {}
+
+// newBookReferences は空のメモリ上の著者・出版社・カテゴリのリポジトリを使う、参照確認のドメインサービスとBookDTOAssemblerを生成します。
+func newBookReferences() (*service.BookReferenceCheckDomainService, *book.BookDTOAssembler) {
+	store := memory.NewStore()
+	authorRepo := memory.NewInMemoryAuthorRepository(store)
+	publisherRepo := memory.NewInMemoryPublisherRepository(store)
+	categoryRepo := memory.NewInMemoryCategoryRepository(store)
+	return service.NewBookReferenceCheckDomainService(authorRepo, publisherRepo, categoryRepo),
+		book.NewBookDTOAssembler(authorRepo, publisherRepo, categoryRepo)
+}
+
+// newTestServer はレスポンス検証を有効にしたOpenAPIバリデーターと、言語・形式の選択を設定したテストサーバーを生成します。
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	// 著者・出版社・カテゴリの削除時に書籍からの参照を確認するため、書籍と同じメモリ上のStoreに保存する
+	store := memory.NewStore()
+	repo := memory.NewInMemoryBookRepository(store)
+	authorRepo := memory.NewInMemoryAuthorRepository(store)
+	publisherRepo := memory.NewInMemoryPublisherRepository(store)
+	categoryRepo := memory.NewInMemoryCategoryRepository(store)
+	queryService := memory.NewInMemoryBookQueryService(store)
+	txManager := memory.NewInMemoryTransactionManager(store)
+	dupSvc := service.NewISBNDuplicationCheckDomainService(repo)
+	refCheck := service.NewBookReferenceCheckDomainService(authorRepo, publisherRepo, categoryRepo)
+	assembler := book.NewBookDTOAssembler(authorRepo, publisherRepo, categoryRepo)
+	publisher := &mockEventPublisher{}
+
+	bookHandler := handler.NewBookHandler(
+		book.NewRegisterBookApplicationService(repo, txManager, dupSvc, refCheck, assembler, publisher),
+		book.NewGetBookApplicationService(repo, assembler),
+		book.NewUpdateBookApplicationService(repo, txManager, assembler, publisher),
+		book.NewDeleteBookApplicationService(repo, txManager, publisher),
+		book.NewAdjustStockApplicationService(repo, txManager, assembler, publisher),
+	)
+
+	catalogHandler := handler.NewCatalogHandler(
+		book.NewListBooksApplicationService(queryService),
+		book.NewSearchBooksApplicationService(memory.NewInMemorySearchIndex(store)),
+		book.NewImportBooksApplicationService(repo, txManager, dupSvc, publisher),
+		book.NewExportBooksApplicationService(queryService),
+	)
+
+	authorHandler := handler.NewAuthorHandler(
+		author.NewRegisterAuthorApplicationService(authorRepo, txManager),
+		author.NewGetAuthorApplicationService(authorRepo),
+		author.NewListAuthorsApplicationService(memory.NewInMemoryAuthorQueryService(store)),
+		author.NewUpdateAuthorApplicationService(authorRepo, txManager),
+		author.NewDeleteAuthorApplicationService(authorRepo, txManager),
+	)
+
+	publisherHandler := handler.NewPublisherHandler(
+		app_publisher.NewRegisterPublisherApplicationService(publisherRepo, txManager),
+		app_publisher.NewGetPublisherApplicationService(publisherRepo),
+		app_publisher.NewListPublishersApplicationService(memory.NewInMemoryPublisherQueryService(store)),
+		app_publisher.NewUpdatePublisherApplicationService(publisherRepo, txManager),
+		app_publisher.NewDeletePublisherApplicationService(publisherRepo, txManager),
+	)
+
+	hierarchySvc := service.NewCategoryHierarchyDomainService(categoryRepo)
+	categoryHandler := handler.NewCategoryHandler(
+		category.NewRegisterCategoryApplicationService(categoryRepo, txManager, hierarchySvc),
+		category.NewGetCategoryApplicationService(categoryRepo),
+		category.NewListCategoriesApplicationService(memory.NewInMemoryCategoryQueryService(store)),
+		category.NewUpdateCategoryApplicationService(categoryRepo, txManager, hierarchySvc),
+		category.NewDeleteCategoryApplicationService(categoryRepo, txManager),
+		book.NewAssignCategoriesApplicationService(repo, txManager, refCheck, assembler, publisher),
+	)
+
+	mux := http.NewServeMux()
+	bookHandler.RegisterRoutes(mux)
+	catalogHandler.RegisterRoutes(mux)
+	authorHandler.RegisterRoutes(mux)
+	publisherHandler.RegisterRoutes(mux)
+	categoryHandler.RegisterRoutes(mux)
+
+	validator, err := middleware.NewOpenAPIValidator(api.OpenAPISpec, middleware.WithResponseValidation())
+	if err != nil {
+		t.Fatalf("OpenAPIバリデーターの生成に失敗しました: %v", err)
+	}
+
+	h := middleware.NewNegotiation(handler.RouteMediaTypes).Middleware(mux, validator.Middleware(mux))
+	srv := httptest.NewServer(middleware.Locale(h))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestBookHandler(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"書籍登録", http.MethodPost, "/books", `{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500}`, http.StatusCreated},
+		{"重複登録", http.MethodPost, "/books", `{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500}`, http.StatusConflict},
+		{"未知の項目を含む登録", http.MethodPost, "/books", `{"isbn":"978-4-00-222222-2","title":"Test Book","price_amount":1500}`, http.StatusBadRequest},
+		{"負の価格での登録", http.MethodPost, "/books", `{"isbn":"978-4-00-222222-2","title":"Test Book","price":-1}`, http.StatusBadRequest},
+		{"書籍取得", http.MethodGet, "/books/978-4-00-111111-1", "", http.StatusOK},
+		{"存在しない書籍の取得", http.MethodGet, "/books/978-4-00-999999-9", "", http.StatusNotFound},
+		{"書籍更新", http.MethodPut, "/books/978-4-00-111111-1", `{"title":"New Title","price":2000}`, http.StatusOK},
+		{"入荷", http.MethodPost, "/books/978-4-00-111111-1/stock/adjustments", `{"delta":3}`, http.StatusOK},
+		{"在庫不足の出荷", http.MethodPost, "/books/978-4-00-111111-1/stock/adjustments", `{"delta":-5}`, http.StatusConflict},
+		{"整数でない調整量", http.MethodPost, "/books/978-4-00-111111-1/stock/adjustments", `{"delta":1.5}`, http.StatusBadRequest},
+		{"書籍削除", http.MethodDelete, "/books/978-4-00-111111-1", "", http.StatusNoContent},
+		{"削除済み書籍の削除", http.MethodDelete, "/books/978-4-00-111111-1", "", http.StatusNotFound},
+		{"OpenAPIドキュメント", http.MethodGet, "/openapi.json", "", http.StatusOK},
+	}
+
+	// 各ケースは前のケースの結果に依存するため、順番に実行する
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatalf("%s: リクエストの生成に失敗しました: %v", tt.name, err)
+		}
+		if tt.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: リクエストに失敗しました: %v", tt.name, err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.wantStatus {
+			t.Errorf("%s: 期待するステータス: %d, 実際: %d", tt.name, tt.wantStatus, resp.StatusCode)
+		}
+	}
+}
+
+func TestCatalogHandler_ImportBooks(t *testing.T) {
+	srv := newTestServer(t)
+
+	csvBody := "isbn,title,price\n978-4-00-111111-1,Book A,1000\n978-4-00-222222-2,Book B,-1\n"
+	ndjsonBody := `{"isbn":"978-4-00-333333-3","title":"Book C","price":3000}` + "\n"
+
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		wantStatus  int
+		// wantBooks は取り込み後に GET /books/{isbn} で期待するステータスです。
+		wantBooks map[string]int
+	}{
+		// all-or-nothingでは不正な行があると正常な行も保存されない
+		{"不正な行を含むCSV(all-or-nothing)", "", "text/csv", csvBody, http.StatusUnprocessableEntity,
+			map[string]int{"978-4-00-111111-1": http.StatusNotFound}},
+		{"不正な行を含むCSV(best-effort)", "?mode=best-effort", "text/csv", csvBody, http.StatusOK,
+			map[string]int{"978-4-00-111111-1": http.StatusOK, "978-4-00-222222-2": http.StatusNotFound}},
+		{"NDJSONのドライラン", "?dry_run=true", "application/x-ndjson", ndjsonBody, http.StatusOK,
+			map[string]int{"978-4-00-333333-3": http.StatusNotFound}},
+		{"不正なモード", "?mode=partial", "text/csv", csvBody, http.StatusBadRequest, nil},
+		{"ヘッダーのないCSV", "", "text/csv", "978-4-00-444444-4,Book D,1000\n", http.StatusBadRequest, nil},
+		{"サポートされていないContent-Type", "", "application/json", `{}`, http.StatusUnsupportedMediaType, nil},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Post(srv.URL+"/books/import"+tt.query, tt.contentType, strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatalf("%s: リクエストに失敗しました: %v", tt.name, err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.wantStatus {
+			t.Errorf("%s: 期待するステータス: %d, 実際: %d", tt.name, tt.wantStatus, resp.StatusCode)
+		}
+		for isbn, want := range tt.wantBooks {
+			if got := doRequest(t, http.MethodGet, srv.URL+"/books/"+isbn, "", nil); got.status != want {
+				t.Errorf("%s: GET /books/%s の期待するステータス: %d, 実際: %d", tt.name, isbn, want, got.status)
+			}
+		}
+	}
+}
+
+func TestCatalogHandler_ExportBooks(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := http.Post(srv.URL+"/books", "application/json",
+		strings.NewReader(`{"isbn":"978-4-00-111111-1","title":"Test & Book","price":1500}`))
+	if err != nil {
+		t.Fatalf("リクエストに失敗しました: %v", err)
+	}
+	resp.Body.Close()
+
+	tests := []struct {
+		name            string
+		query           string
+		wantStatus      int
+		wantContentType string
+		wantBody        []string
+	}{
+		{"CSV", "", http.StatusOK, "text/csv; charset=utf-8", []string{
+			"isbn,title,price,currency,quantity_available,status\n",
+			"978-4-00-111111-1,Test & Book,1500,JPY,0,OUT_OF_STOCK\n",
+		}},
+		{"NDJSON", "?format=ndjson", http.StatusOK, "application/x-ndjson", []string{
+			`{"isbn":"978-4-00-111111-1","title":"Test \u0026 Book","price":1500,"currency":"JPY","quantity_available":0,"status":"OUT_OF_STOCK"}`,
+		}},
+		{"ONIX", "?format=onix", http.StatusOK, "application/xml", []string{
+			`<ONIXMessage xmlns="http://ns.editeur.org/onix/3.0/reference" release="3.0">`,
+			"<ProductIDType>15</ProductIDType>",
+			"<IDValue>9784001111111</IDValue>",
+			"<TitleText>Test &amp; Book</TitleText>",
+			"<ProductAvailability>31</ProductAvailability>",
+			"<PriceAmount>1500</PriceAmount>",
+			"<CurrencyCode>JPY</CurrencyCode>",
+			"</ONIXMessage>",
+		}},
+		{"不正な形式", "?format=xlsx", http.StatusBadRequest, "", nil},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Get(srv.URL + "/books/export" + tt.query)
+		if err != nil {
+			t.Fatalf("%s: リクエストに失敗しました: %v", tt.name, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.wantStatus {
+			t.Errorf("%s: 期待するステータス: %d, 実際: %d", tt.name, tt.wantStatus, resp.StatusCode)
+			continue
+		}
+		if tt.wantContentType != "" && resp.Header.Get("Content-Type") != tt.wantContentType {
+			t.Errorf("%s: 期待するContent-Type: %s, 実際: %s", tt.name, tt.wantContentType, resp.Header.Get("Content-Type"))
+		}
+		for _, want := range tt.wantBody {
+			if !strings.Contains(string(body), want) {
+				t.Errorf("%s: レスポンスに %q が含まれていません:\n%s", tt.name, want, body)
+			}
+		}
+	}
+}
+
+func TestBookHandler_Metadata(t *testing.T) {
+	srv := newTestServer(t)
+
+	authorA := createResource(t, srv.URL+"/authors", "著者A")
+	authorB := createResource(t, srv.URL+"/authors", "著者B")
+	pub := createResource(t, srv.URL+"/publishers", "出版社")
+
+	body := `{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500,` +
+		`"author_ids":["` + authorA + `","` + authorB + `"],"publisher_id":"` + pub + `","publication_date":"2024-04-01",` +
+		`"language":"jpn","page_count":320,"format":"PAPERBACK","edition":2}`
+	resp := doRequest(t, http.MethodPost, srv.URL+"/books", body, nil)
+	if resp.status != http.StatusCreated {
+		t.Fatalf("ステータスコードが不正です: %d %s", resp.status, resp.body)
+	}
+
+	resp = doRequest(t, http.MethodGet, srv.URL+"/books/978-4-00-111111-1", "", nil)
+	want := `"authors":[{"id":"` + authorA + `","name":"著者A"},{"id":"` + authorB + `","name":"著者B"}],` +
+		`"publisher":{"id":"` + pub + `","name":"出版社"},"publication_date":"2024-04-01",` +
+		`"language":"ja","page_count":320,"format":"PAPERBACK","edition":2`
+	if resp.status != http.StatusOK || !strings.Contains(resp.body, want) {
+		t.Errorf("書誌情報が返されていません: %d %s", resp.status, resp.body)
+	}
+
+	resp = doRequest(t, http.MethodGet, srv.URL+"/books/export?format=onix", "", nil)
+	for _, want := range []string{
+		"<ProductForm>BC</ProductForm>",
+		"<SequenceNumber>2</SequenceNumber>",
+		"<PersonName>著者B</PersonName>",
+		"<EditionNumber>2</EditionNumber>",
+		"<ExtentValue>320</ExtentValue>",
+		"<PublisherName>出版社</PublisherName>",
+		"<Date>20240401</Date>",
+	} {
+		if !strings.Contains(resp.body, want) {
+			t.Errorf("ONIXに %s が含まれていません:\n%s", want, resp.body)
+		}
+	}
+
+	resp = doRequest(t, http.MethodPost, srv.URL+"/books", `{"isbn":"978-4-00-222222-2","title":"Test Book","price":1500,"language":"xx"}`, nil)
+	if resp.status != http.StatusBadRequest || resp.errorBody(t).Reason != "language.invalid" {
+		t.Errorf("不正な言語のエラーが不正です: %d %s", resp.status, resp.body)
+	}
+}
+
+func TestBookHandler_UnknownReferences(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantReason string
+	}{
+		{"存在しない著者", `{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500,"author_ids":["0b5e2a4c-6f1d-4c3e-9a57-3f0a1b2c3d4e"]}`, "author.not_found"},
+		{"存在しない出版社", `{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500,"publisher_id":"0b5e2a4c-6f1d-4c3e-9a57-3f0a1b2c3d4e"}`, "publisher.not_found"},
+		{"不正な著者ID", `{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500,"author_ids":["著者A"]}`, "author_id.invalid"},
+	}
+
+	for _, tt := range tests {
+		resp := doRequest(t, http.MethodPost, srv.URL+"/books", tt.body, nil)
+		if resp.status != http.StatusBadRequest || resp.errorBody(t).Reason != tt.wantReason {
+			t.Errorf("%s: エラーが不正です: %d %s", tt.name, resp.status, resp.body)
+		}
+	}
+}
+
+func TestCatalogHandler_SearchBooks(t *testing.T) {
+	srv := newTestServer(t)
+
+	author := createResource(t, srv.URL+"/authors", "ドメイン太郎")
+	for _, body := range []string{
+		`{"isbn":"978-4-00-111111-1","title":"ドメイン駆動設計入門","price":3000}`,
+		`{"isbn":"978-4-00-222222-2","title":"Go言語の教科書","price":2500,"author_ids":["` + author + `"]}`,
+		`{"isbn":"978-4-00-333333-3","title":"料理の本","price":1500}`,
+	} {
+		if resp := doRequest(t, http.MethodPost, srv.URL+"/books", body, nil); resp.status != http.StatusCreated {
+			t.Fatalf("書籍の登録に失敗しました: %d %s", resp.status, resp.body)
+		}
+	}
+
+	// タイトルに一致した書籍が著者名に一致した書籍より先に並ぶ
+	resp := doRequest(t, http.MethodGet, srv.URL+"/books/search?q="+url.QueryEscape("どめいん"), "", nil)
+	var result struct {
+		Items []struct {
+			Book struct {
+				ISBN string `json:"isbn"`
+			} `json:"book"`
+			Highlights struct {
+				Title   string   `json:"title"`
+				Authors []string `json:"authors"`
+			} `json:"highlights"`
+		} `json:"items"`
+		Limit int `json:"limit"`
+	}
+	if resp.status != http.StatusOK {
+		t.Fatalf("ステータスコードが不正です: %d %s", resp.status, resp.body)
+	}
+	if err := json.Unmarshal([]byte(resp.body), &result); err != nil {
+		t.Fatalf("レスポンスの解析に失敗しました: %v", err)
+	}
+	if len(result.Items) != 2 || result.Items[0].Book.ISBN != "978-4-00-111111-1" || result.Items[1].Book.ISBN != "978-4-00-222222-2" {
+		t.Fatalf("検索結果が不正です: %s", resp.body)
+	}
+	if result.Items[0].Highlights.Title != "<mark>ドメイン</mark>駆動設計入門" ||
+		len(result.Items[1].Highlights.Authors) != 1 || result.Items[1].Highlights.Authors[0] != "<mark>ドメイン</mark>太郎" {
+		t.Errorf("強調表示が不正です: %s", resp.body)
+	}
+	if result.Limit != book.DefaultListLimit {
+		t.Errorf("期待する件数の上限: %d, 実際: %d", book.DefaultListLimit, result.Limit)
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantReason string
+	}{
+		// 長さと件数の範囲はAPI仕様で検証されるため、仕様に適合する値でアプリケーションサービスの検証を確認する
+		{"空白のみの検索語", "q=" + url.QueryEscape("  "), "search.query_required"},
+		{"多すぎる検索語", "q=" + url.QueryEscape("a b c d e f g h i j k"), "search.too_many_terms"},
+	}
+	for _, tt := range tests {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/books/search?"+tt.query, "", nil)
+		if resp.status != http.StatusBadRequest || resp.errorBody(t).Reason != tt.wantReason {
+			t.Errorf("%s: エラーが不正です: %d %s", tt.name, resp.status, resp.body)
+		}
+	}
+}
+
+func TestAuthorHandler(t *testing.T) {
+	srv := newTestServer(t)
+
+	id := createResource(t, srv.URL+"/authors", "夏目漱石")
+	createResource(t, srv.URL+"/authors", "芥川龍之介")
+
+	resp := doRequest(t, http.MethodGet, srv.URL+"/authors/"+id, "", nil)
+	if resp.status != http.StatusOK || !strings.Contains(resp.body, `"name":"夏目漱石"`) {
+		t.Errorf("著者が取得できません: %d %s", resp.status, resp.body)
+	}
+
+	resp = doRequest(t, http.MethodPut, srv.URL+"/authors/"+id, `{"name":"夏目金之助"}`, nil)
+	if resp.status != http.StatusOK || !strings.Contains(resp.body, `"name":"夏目金之助"`) {
+		t.Errorf("著者が更新できません: %d %s", resp.status, resp.body)
+	}
+
+	// 一覧は著者名の文字コード順（夏 U+590F、芥 U+82A5）
+	resp = doRequest(t, http.MethodGet, srv.URL+"/authors?limit=1&offset=1", "", nil)
+	if resp.status != http.StatusOK || !strings.Contains(resp.body, `"name":"芥川龍之介"`) || strings.Contains(resp.body, "夏目") {
+		t.Errorf("著者の一覧が名前順ではありません: %d %s", resp.status, resp.body)
+	}
+
+	// 書籍から参照されている著者は削除できない
+	resp = doRequest(t, http.MethodPost, srv.URL+"/books",
+		`{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500,"author_ids":["`+id+`"]}`, nil)
+	if resp.status != http.StatusCreated {
+		t.Fatalf("書籍が登録できません: %d %s", resp.status, resp.body)
+	}
+	resp = doRequest(t, http.MethodDelete, srv.URL+"/authors/"+id, "", nil)
+	if resp.status != http.StatusConflict || resp.errorBody(t).Reason != "author.in_use" {
+		t.Errorf("参照されている著者の削除のエラーが不正です: %d %s", resp.status, resp.body)
+	}
+
+	resp = doRequest(t, http.MethodDelete, srv.URL+"/books/978-4-00-111111-1", "", nil)
+	if resp.status != http.StatusNoContent {
+		t.Fatalf("書籍が削除できません: %d %s", resp.status, resp.body)
+	}
+	resp = doRequest(t, http.MethodDelete, srv.URL+"/authors/"+id, "", nil)
+	if resp.status != http.StatusNoContent {
+		t.Errorf("著者が削除できません: %d %s", resp.status, resp.body)
+	}
+	resp = doRequest(t, http.MethodGet, srv.URL+"/authors/"+id, "", nil)
+	if resp.status != http.StatusNotFound {
+		t.Errorf("削除した著者が取得できます: %d %s", resp.status, resp.body)
+	}
+
+	resp = doRequest(t, http.MethodPost, srv.URL+"/authors", `{"name":""}`, nil)
+	if resp.status != http.StatusBadRequest {
+		t.Errorf("空の名前の著者が登録できます: %d %s", resp.status, resp.body)
+	}
+}
+
+func TestCategoryHandler(t *testing.T) {
+	srv := newTestServer(t)
+
+	novel := createResource(t, srv.URL+"/categories", "小説")
+	tech := createResource(t, srv.URL+"/categories", "技術書")
+	resp := doRequest(t, http.MethodPost, srv.URL+"/categories", `{"name":"ミステリー","parent_id":"`+novel+`"}`, nil)
+	if resp.status != http.StatusCreated {
+		t.Fatalf("子カテゴリが登録できません: %d %s", resp.status, resp.body)
+	}
+	var mystery struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(resp.body), &mystery); err != nil {
+		t.Fatalf("レスポンスの解析に失敗しました: %v", err)
+	}
+
+	// 一覧は木構造で、各階層はカテゴリ名の文字コード順（小 U+5C0F、技 U+6280）
+	resp = doRequest(t, http.MethodGet, srv.URL+"/categories", "", nil)
+	var tree struct {
+		Items []struct {
+			ID       string `json:"id"`
+			Children []struct {
+				ID string `json:"id"`
+			} `json:"children"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(resp.body), &tree); err != nil {
+		t.Fatalf("レスポンスの解析に失敗しました: %v", err)
+	}
+	if len(tree.Items) != 2 || tree.Items[0].ID != novel || tree.Items[1].ID != tech ||
+		len(tree.Items[0].Children) != 1 || tree.Items[0].Children[0].ID != mystery.ID {
+		t.Errorf("カテゴリの木構造が不正です: %s", resp.body)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantReason string
+	}{
+		{"子孫を親とする変更", http.MethodPut, "/categories/" + novel, `{"name":"小説","parent_id":"` + mystery.ID + `"}`, http.StatusBadRequest, "category.cyclic_parent"},
+		{"不正な親IDでの登録", http.MethodPost, "/categories", `{"name":"SF","parent_id":"` + tech + `0"}`, http.StatusBadRequest, "category_id.invalid"},
+		{"未登録の親での登録", http.MethodPost, "/categories", `{"name":"SF","parent_id":"00000000-0000-0000-0000-000000000000"}`, http.StatusBadRequest, "category.parent_not_found"},
+		{"書籍登録", http.MethodPost, "/books", `{"isbn":"978-4-00-111111-1","title":"Mystery","price":1500}`, http.StatusCreated, ""},
+		{"書籍登録", http.MethodPost, "/books", `{"isbn":"978-4-00-222222-2","title":"Go","price":3000}`, http.StatusCreated, ""},
+		{"カテゴリの割り当て", http.MethodPut, "/books/978-4-00-111111-1/categories", `{"category_ids":["` + mystery.ID + `"]}`, http.StatusOK, ""},
+		{"カテゴリの割り当て", http.MethodPut, "/books/978-4-00-222222-2/categories", `{"category_ids":["` + tech + `"]}`, http.StatusOK, ""},
+		{"重複したカテゴリの割り当て", http.MethodPut, "/books/978-4-00-222222-2/categories", `{"category_ids":["` + tech + `","` + tech + `"]}`, http.StatusBadRequest, "categories.duplicate"},
+		{"未登録のカテゴリの割り当て", http.MethodPut, "/books/978-4-00-222222-2/categories", `{"category_ids":["00000000-0000-0000-0000-000000000000"]}`, http.StatusBadRequest, "category.not_found"},
+		{"存在しない書籍への割り当て", http.MethodPut, "/books/978-4-00-999999-9/categories", `{"category_ids":[]}`, http.StatusNotFound, "book.not_found"},
+		{"子カテゴリのあるカテゴリの削除", http.MethodDelete, "/categories/" + novel, "", http.StatusConflict, "category.has_children"},
+		{"割り当てられたカテゴリの削除", http.MethodDelete, "/categories/" + mystery.ID, "", http.StatusConflict, "category.in_use"},
+		{"不正なIDでの絞り込み", http.MethodGet, "/books?category_id=novel", "", http.StatusBadRequest, "category_id.invalid"},
+	}
+
+	// 各ケースは前のケースの結果に依存するため、順番に実行する
+	for _, tt := range tests {
+		resp := doRequest(t, tt.method, srv.URL+tt.path, tt.body, nil)
+		if resp.status != tt.wantStatus {
+			t.Fatalf("%s: ステータスコードが不正です: got %d, want %d: %s", tt.name, resp.status, tt.wantStatus, resp.body)
+		}
+		if tt.wantReason != "" && resp.errorBody(t).Reason != tt.wantReason {
+			t.Errorf("%s: エラーの理由が不正です: got %s, want %s", tt.name, resp.errorBody(t).Reason, tt.wantReason)
+		}
+	}
+
+	// 親カテゴリでの絞り込みには、子孫のカテゴリが割り当てられた書籍も含む
+	resp = doRequest(t, http.MethodGet, srv.URL+"/books?category_id="+novel, "", nil)
+	if resp.status != http.StatusOK || !strings.Contains(resp.body, `"name":"ミステリー"`) ||
+		!strings.Contains(resp.body, "978-4-00-111111-1") || strings.Contains(resp.body, "978-4-00-222222-2") {
+		t.Errorf("カテゴリによる絞り込みが不正です: %d %s", resp.status, resp.body)
+	}
+
+	// 割り当てを外したカテゴリは削除できる
+	resp = doRequest(t, http.MethodPut, srv.URL+"/books/978-4-00-111111-1/categories", `{"category_ids":[]}`, nil)
+	if resp.status != http.StatusOK || strings.Contains(resp.body, "categories") {
+		t.Errorf("カテゴリの割り当てが外せません: %d %s", resp.status, resp.body)
+	}
+	resp = doRequest(t, http.MethodDelete, srv.URL+"/categories/"+mystery.ID, "", nil)
+	if resp.status != http.StatusNoContent {
+		t.Errorf("カテゴリが削除できません: %d %s", resp.status, resp.body)
+	}
+	resp = doRequest(t, http.MethodGet, srv.URL+"/categories/"+mystery.ID, "", nil)
+	if resp.status != http.StatusNotFound {
+		t.Errorf("削除したカテゴリが取得できます: %d %s", resp.status, resp.body)
+	}
+}
+
+// createResource は著者・出版社・カテゴリをnameで登録し、IDを返します。
+func createResource(t *testing.T, url, name string) string {
+	t.Helper()
+
+	resp := doRequest(t, http.MethodPost, url, `{"name":"`+name+`"}`, nil)
+	if resp.status != http.StatusCreated {
+		t.Fatalf("%s の登録に失敗しました: %d %s", name, resp.status, resp.body)
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(resp.body), &created); err != nil {
+		t.Fatalf("レスポンスの解析に失敗しました: %v", err)
+	}
+	return created.ID
+}
@@ -0,0 +1,73 @@
+package presentation_test
+
+import (
+	"context"
+	"ddd-hands-on-go/api"
+	"ddd-hands-on-go/cmd/api/handler"
+	"ddd-hands-on-go/cmd/api/middleware"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubHealthCheck は結果を固定したテスト用のHealthCheckです。
+type stubHealthCheck struct {
+	name string
+	err  error
+}
+
+func (c *stubHealthCheck) Name() string                    { return c.name }
+func (c *stubHealthCheck) Check(ctx context.Context) error { return c.err }
+
+func TestHealthHandler(t *testing.T) {
+	migrations := &stubHealthCheck{name: "migrations"}
+	h := handler.NewHealthHandler(&stubHealthCheck{name: "database"}, migrations)
+
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	validator, err := middleware.NewOpenAPIValidator(api.OpenAPISpec, middleware.WithResponseValidation())
+	if err != nil {
+		t.Fatalf("OpenAPIバリデーターの生成に失敗しました: %v", err)
+	}
+	srv := httptest.NewServer(validator.Middleware(mux))
+	t.Cleanup(srv.Close)
+
+	get := func(path string) (int, map[string]interface{}) {
+		t.Helper()
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("リクエストに失敗しました: %v", err)
+		}
+		defer resp.Body.Close()
+		var body map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("レスポンスのデコードに失敗しました: %v", err)
+		}
+		return resp.StatusCode, body
+	}
+
+	if status, _ := get("/healthz"); status != http.StatusOK {
+		t.Errorf("/healthz: 期待するステータス: 200, 実際: %d", status)
+	}
+	if status, body := get("/readyz"); status != http.StatusOK || body["status"] != "ok" {
+		t.Errorf("/readyz: 期待するステータス: 200 (ok), 実際: %d (%v)", status, body)
+	}
+
+	// 未適用のマイグレーションがある場合はリクエストを受け付けられない
+	migrations.err = errors.New("未適用のマイグレーションがあります")
+	status, body := get("/readyz")
+	if status != http.StatusServiceUnavailable {
+		t.Errorf("/readyz: 期待するステータス: 503, 実際: %d", status)
+	}
+	checks, _ := body["checks"].(map[string]interface{})
+	if checks["database"] != "ok" || checks["migrations"] != migrations.err.Error() {
+		t.Errorf("/readyz: 確認項目ごとの結果が正しくありません: %v", body)
+	}
+
+	// ライブネスプローブは依存先の状態に影響されない
+	if status, _ := get("/healthz"); status != http.StatusOK {
+		t.Errorf("/healthz: 期待するステータス: 200, 実際: %d", status)
+	}
+}
@@ -0,0 +1,147 @@
+package presentation_test
+
+import (
+	"context"
+	"ddd-hands-on-go/cmd/api/handler"
+	"ddd-hands-on-go/cmd/api/middleware"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/application/idempotency"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/infrastructure/event"
+	"ddd-hands-on-go/internal/infrastructure/memory"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newIdempotencyTestServer(t *testing.T, ttl time.Duration) (*httptest.Server, *idempotency.Service, *int) {
+	t.Helper()
+	store := memory.NewStore()
+	repo := memory.NewInMemoryBookRepository(store)
+	txManager := memory.NewInMemoryTransactionManager(store)
+	refCheck, assembler := newBookReferences()
+	emitter := event.NewEventEmitter()
+	created := 0
+	emitter.Subscribe("BookCreated", func(shared.DomainEvent) { created++ })
+
+	bookHandler := handler.NewBookHandler(
+		book.NewRegisterBookApplicationService(repo, txManager, service.NewISBNDuplicationCheckDomainService(repo), refCheck, assembler, emitter),
+		book.NewGetBookApplicationService(repo, assembler),
+		book.NewUpdateBookApplicationService(repo, txManager, assembler, emitter),
+		book.NewDeleteBookApplicationService(repo, txManager, emitter),
+		book.NewAdjustStockApplicationService(repo, txManager, assembler, emitter),
+	)
+	mux := http.NewServeMux()
+	bookHandler.RegisterRoutes(mux)
+	svc := idempotency.NewService(memory.NewInMemoryIdempotencyStore(), txManager, ttl)
+	server := httptest.NewServer(middleware.NewIdempotency(svc, handler.IdempotentRoutes).Middleware(mux, mux))
+	t.Cleanup(server.Close)
+	return server, svc, &created
+}
+
+type idempotentResponse struct {
+	status   int
+	replayed bool
+	body     string
+}
+
+func postWithKey(t *testing.T, url, key, body string) idempotentResponse {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("リクエストに失敗しました: %v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return idempotentResponse{
+		status:   resp.StatusCode,
+		replayed: resp.Header.Get(middleware.IdempotentReplayedHeader) == "true",
+		body:     string(b),
+	}
+}
+
+func TestIdempotency(t *testing.T) {
+	const registerBody = `{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500}`
+	const adjustPath = "/books/978-4-00-111111-1/stock/adjustments"
+
+	t.Run("同じキーの再送には最初のレスポンスを返す", func(t *testing.T) {
+		server, _, created := newIdempotencyTestServer(t, time.Hour)
+
+		first := postWithKey(t, server.URL+"/books", "register-1", registerBody)
+		if first.status != http.StatusCreated || first.replayed {
+			t.Fatalf("1回目: %+v", first)
+		}
+		retry := postWithKey(t, server.URL+"/books", "register-1", registerBody)
+		if retry.status != http.StatusCreated || !retry.replayed || retry.body != first.body {
+			t.Errorf("再送: %+v", retry)
+		}
+		if *created != 1 {
+			t.Errorf("BookCreatedイベントの件数が不正です: %d", *created)
+		}
+
+		// 在庫の調整も1回だけ行われる
+		for i := 0; i < 2; i++ {
+			resp := postWithKey(t, server.URL+adjustPath, "adjust-1", `{"delta":5}`)
+			if resp.status != http.StatusOK || !strings.Contains(resp.body, `"quantity_available":5`) {
+				t.Errorf("%d回目: %+v", i+1, resp)
+			}
+		}
+	})
+
+	t.Run("キーがない場合は毎回実行する", func(t *testing.T) {
+		server, _, _ := newIdempotencyTestServer(t, time.Hour)
+
+		postWithKey(t, server.URL+"/books", "", registerBody)
+		if resp := postWithKey(t, server.URL+"/books", "", registerBody); resp.status != http.StatusConflict {
+			t.Errorf("重複登録のステータスコードが不正です: %+v", resp)
+		}
+	})
+
+	t.Run("異なる内容のリクエストでのキーの再利用は422", func(t *testing.T) {
+		server, _, _ := newIdempotencyTestServer(t, time.Hour)
+
+		postWithKey(t, server.URL+"/books", "register-1", registerBody)
+		other := `{"isbn":"978-4-00-222222-2","title":"Other Book","price":1500}`
+		if resp := postWithKey(t, server.URL+"/books", "register-1", other); resp.status != http.StatusUnprocessableEntity {
+			t.Errorf("ステータスコードが不正です: %+v", resp)
+		}
+	})
+
+	t.Run("失敗したリクエストのキーは保存しない", func(t *testing.T) {
+		server, _, _ := newIdempotencyTestServer(t, time.Hour)
+
+		// 書籍がないため404になり、キーの予約はロールバックされる
+		if resp := postWithKey(t, server.URL+adjustPath, "adjust-1", `{"delta":5}`); resp.status != http.StatusNotFound {
+			t.Fatalf("ステータスコードが不正です: %+v", resp)
+		}
+		postWithKey(t, server.URL+"/books", "register-1", registerBody)
+		if resp := postWithKey(t, server.URL+adjustPath, "adjust-1", `{"delta":5}`); resp.status != http.StatusOK || resp.replayed {
+			t.Errorf("同じキーでの再試行が実行されませんでした: %+v", resp)
+		}
+	})
+
+	t.Run("期限切れのキーは再利用できる", func(t *testing.T) {
+		server, svc, _ := newIdempotencyTestServer(t, time.Millisecond)
+
+		postWithKey(t, server.URL+"/books", "register-1", registerBody)
+		postWithKey(t, server.URL+adjustPath, "adjust-1", `{"delta":5}`)
+		time.Sleep(5 * time.Millisecond)
+
+		if n, err := svc.PurgeExpired(context.Background()); err != nil || n != 2 {
+			t.Errorf("期限切れのキーの削除件数が不正です: %d, %v", n, err)
+		}
+		resp := postWithKey(t, server.URL+adjustPath, "adjust-1", `{"delta":5}`)
+		if resp.replayed || !strings.Contains(resp.body, `"quantity_available":10`) {
+			t.Errorf("期限切れのキーのリクエストが実行されませんでした: %+v", resp)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package presentation_test
+
+import (
+	"context"
+	"ddd-hands-on-go/cmd/api/handler"
+	"ddd-hands-on-go/cmd/api/middleware"
+	"ddd-hands-on-go/internal/application/book"
+	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/infrastructure/event"
+	"ddd-hands-on-go/internal/metrics"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type stubBookStatusCounter map[string]int
+
+func (c stubBookStatusCounter) CountBooksByStatus(ctx context.Context) (map[string]int, error) {
+	return c, nil
+}
+
+func TestMetrics(t *testing.T) {
+	m := metrics.New()
+	m.RegisterBookStatusCounter(stubBookStatusCounter{"IN_STOCK": 0, "OUT_OF_STOCK": 2})
+
+	repo := &mockBookRepository{books: make(map[string]*domain_book.Book)}
+	txManager := &mockTransactionManager{}
+	refCheck, assembler := newBookReferences()
+	emitter := event.NewEventEmitter(event.WithMetrics(m))
+
+	bookHandler := handler.NewBookHandler(
+		book.NewRegisterBookApplicationService(repo, txManager, service.NewISBNDuplicationCheckDomainService(repo), refCheck, assembler, emitter),
+		book.NewGetBookApplicationService(repo, assembler),
+		book.NewUpdateBookApplicationService(repo, txManager, assembler, emitter),
+		book.NewDeleteBookApplicationService(repo, txManager, emitter),
+		book.NewAdjustStockApplicationService(repo, txManager, assembler, emitter),
+	)
+	mux := http.NewServeMux()
+	bookHandler.RegisterRoutes(mux)
+	mux.Handle("GET /metrics", m.Handler())
+	server := httptest.NewServer(middleware.NewHTTPMetrics(m).Middleware(mux, mux))
+	defer server.Close()
+
+	requests := []struct {
+		method, path, body string
+	}{
+		{http.MethodPost, "/books", `{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500}`},
+		{http.MethodGet, "/books/978-4-00-111111-1", ""},
+		{http.MethodGet, "/books/978-4-00-999999-9", ""},
+		{http.MethodGet, "/no-such-path/1", ""},
+	}
+	for _, r := range requests {
+		req, _ := http.NewRequest(r.method, server.URL+r.path, strings.NewReader(r.body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: リクエストに失敗しました: %v", r.method, r.path, err)
+		}
+		resp.Body.Close()
+	}
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("メトリクスの取得に失敗しました: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	exposition := string(body)
+
+	// ルートはパスではなくパターンで集計され、一致しないリクエストはまとめて集計される
+	want := []string{
+		`bookapi_http_requests_total{method="POST",route="POST /books",status="201"} 1`,
+		`bookapi_http_requests_total{method="GET",route="GET /books/{isbn}",status="200"} 1`,
+		`bookapi_http_requests_total{method="GET",route="GET /books/{isbn}",status="404"} 1`,
+		`bookapi_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`bookapi_http_request_duration_seconds_count{method="GET",route="GET /books/{isbn}"} 2`,
+		`bookapi_events_published_total{event="BookCreated"} 1`,
+		`bookapi_books{status="IN_STOCK"} 0`,
+		`bookapi_books{status="OUT_OF_STOCK"} 2`,
+	}
+	for _, w := range want {
+		if !strings.Contains(exposition, w) {
+			t.Errorf("メトリクスに %s が含まれていません:\n%s", w, exposition)
+		}
+	}
+	if strings.Contains(exposition, "978-4-00-") {
+		t.Errorf("メトリクスのラベルにISBNが含まれています:\n%s", exposition)
+	}
+}
@@ -0,0 +1,167 @@
+package presentation_test
+
+import (
+	"ddd-hands-on-go/cmd/api/response"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type negotiationResponse struct {
+	status int
+	header http.Header
+	body   string
+}
+
+func (r negotiationResponse) errorBody(t *testing.T) response.ErrorBody {
+	t.Helper()
+	var e response.ErrorResponse
+	if err := json.Unmarshal([]byte(r.body), &e); err != nil {
+		t.Fatalf("エラーレスポンスがJSONではありません: %v\n%s", err, r.body)
+	}
+	return e.Error
+}
+
+func doRequest(t *testing.T, method, url, body string, header map[string]string) negotiationResponse {
+	t.Helper()
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("リクエストに失敗しました: %v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return negotiationResponse{status: resp.StatusCode, header: resp.Header, body: string(b)}
+}
+
+func TestResponseRepresentation(t *testing.T) {
+	srv := newTestServer(t)
+	const registerBody = `{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500}`
+
+	t.Run("登録は書籍のJSONとLocationを返す", func(t *testing.T) {
+		resp := doRequest(t, http.MethodPost, srv.URL+"/books", registerBody, nil)
+		if resp.status != http.StatusCreated {
+			t.Fatalf("ステータスコードが不正です: %d %s", resp.status, resp.body)
+		}
+		if got := resp.header.Get("Location"); got != "/books/978-4-00-111111-1" {
+			t.Errorf("Locationが不正です: %q", got)
+		}
+		if !strings.Contains(resp.body, `"isbn":"978-4-00-111111-1"`) || !strings.Contains(resp.body, `"status":"OUT_OF_STOCK"`) {
+			t.Errorf("登録した書籍が返されていません: %s", resp.body)
+		}
+	})
+
+	t.Run("エラーはAccept-Languageの言語のJSONで返す", func(t *testing.T) {
+		tests := []struct {
+			acceptLanguage string
+			wantLanguage   string
+			wantMessage    string
+		}{
+			{"", "ja", "書籍の登録に失敗しました: このISBNの書籍は既に存在します: 978-4-00-111111-1"},
+			{"en-US,en;q=0.9,ja;q=0.8", "en", "Failed to register the book: A book with this ISBN already exists: 978-4-00-111111-1"},
+			{"fr, ja;q=0.5", "ja", "書籍の登録に失敗しました: このISBNの書籍は既に存在します: 978-4-00-111111-1"},
+		}
+		for _, tt := range tests {
+			resp := doRequest(t, http.MethodPost, srv.URL+"/books", registerBody, map[string]string{"Accept-Language": tt.acceptLanguage})
+			if resp.status != http.StatusConflict {
+				t.Fatalf("%q: ステータスコードが不正です: %d", tt.acceptLanguage, resp.status)
+			}
+			e := resp.errorBody(t)
+			if e.Code != response.CodeConflict || e.Reason != "book.already_exists" || e.Message != tt.wantMessage {
+				t.Errorf("%q: エラーが不正です: %+v", tt.acceptLanguage, e)
+			}
+			if got := resp.header.Get("Content-Language"); got != tt.wantLanguage {
+				t.Errorf("%q: Content-Languageが不正です: %q", tt.acceptLanguage, got)
+			}
+		}
+	})
+
+	t.Run("取り込みの行のエラーはコードとリクエストの言語のメッセージを返す", func(t *testing.T) {
+		body := "isbn,title,price\n978-4-00-222222-2,Book,abc\n978-4-00-111111-1,Book,100\n"
+		resp := doRequest(t, http.MethodPost, srv.URL+"/books/import?dry_run=true", body, map[string]string{
+			"Content-Type":    "text/csv",
+			"Accept-Language": "en",
+		})
+		for _, want := range []string{
+			`{"line":2,"isbn":"978-4-00-222222-2","code":"import.price_not_number","message":"Price is not a number: \"abc\""}`,
+			`{"line":3,"isbn":"978-4-00-111111-1","code":"book.already_exists","message":"A book with this ISBN already exists: 978-4-00-111111-1"}`,
+		} {
+			if !strings.Contains(resp.body, want) {
+				t.Errorf("レスポンスに %s が含まれていません:\n%s", want, resp.body)
+			}
+		}
+	})
+
+	t.Run("一覧はAcceptでJSONまたはCSVを返す", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/books?limit=10", "", nil)
+		if resp.status != http.StatusOK || !strings.Contains(resp.body, `"items":[{"isbn":"978-4-00-111111-1"`) || !strings.Contains(resp.body, `"limit":10`) {
+			t.Errorf("JSONの一覧が不正です: %d %s", resp.status, resp.body)
+		}
+
+		resp = doRequest(t, http.MethodGet, srv.URL+"/books", "", map[string]string{"Accept": "text/csv, application/json;q=0.5"})
+		if resp.status != http.StatusOK || !strings.HasPrefix(resp.header.Get("Content-Type"), "text/csv") ||
+			!strings.Contains(resp.body, "978-4-00-111111-1,Test Book,1500,JPY,0,OUT_OF_STOCK\n") {
+			t.Errorf("CSVの一覧が不正です: %d %s %s", resp.status, resp.header.Get("Content-Type"), resp.body)
+		}
+
+		resp = doRequest(t, http.MethodGet, srv.URL+"/books?limit=x", "", map[string]string{"Accept-Language": "en"})
+		if resp.status != http.StatusBadRequest {
+			t.Errorf("不正なlimitのステータスコードが不正です: %d", resp.status)
+		}
+	})
+
+	t.Run("受け付けられる形式がない場合は406", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/books/978-4-00-111111-1", "", map[string]string{"Accept": "application/xml"})
+		if resp.status != http.StatusNotAcceptable || resp.errorBody(t).Code != response.CodeNotAcceptable {
+			t.Errorf("レスポンスが不正です: %d %s", resp.status, resp.body)
+		}
+	})
+
+	t.Run("書き出しはAcceptで形式を選ぶ", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/books/export", "", map[string]string{"Accept": "application/x-ndjson"})
+		if resp.status != http.StatusOK || resp.header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("レスポンスが不正です: %d %s", resp.status, resp.header.Get("Content-Type"))
+		}
+	})
+
+	t.Run("存在しないパスとメソッドもJSONのエラーを返す", func(t *testing.T) {
+		resp := doRequest(t, http.MethodGet, srv.URL+"/magazines", "", nil)
+		if resp.status != http.StatusNotFound || resp.errorBody(t).Code != response.CodeNotFound {
+			t.Errorf("存在しないパスのレスポンスが不正です: %d %s", resp.status, resp.body)
+		}
+		resp = doRequest(t, http.MethodPatch, srv.URL+"/books/978-4-00-111111-1", "", nil)
+		if resp.status != http.StatusMethodNotAllowed || resp.errorBody(t).Code != response.CodeMethodNotAllowed || resp.header.Get("Allow") == "" {
+			t.Errorf("許可されていないメソッドのレスポンスが不正です: %d %v %s", resp.status, resp.header, resp.body)
+		}
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{response.MediaTypeJSON, response.MediaTypeCSV}
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", response.MediaTypeJSON},
+		{"*/*", response.MediaTypeJSON},
+		{"text/csv", response.MediaTypeCSV},
+		{"text/*, application/json;q=0.9", response.MediaTypeCSV},
+		{"application/json;q=0.1, text/csv;q=0.8", response.MediaTypeCSV},
+		{"*/*;q=0.1, text/csv;q=0", response.MediaTypeJSON},
+		{"application/xml", ""},
+		{"text/csv;q=0", ""},
+	}
+	for _, tt := range tests {
+		if got := response.Negotiate(tt.accept, offers); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, 期待: %q", tt.accept, got, tt.want)
+		}
+	}
+}
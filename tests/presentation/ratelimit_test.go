@@ -0,0 +1,199 @@
+package presentation_test
+
+import (
+	"ddd-hands-on-go/cmd/api/handler"
+	"ddd-hands-on-go/cmd/api/middleware"
+	"ddd-hands-on-go/internal/auth"
+	"ddd-hands-on-go/internal/ratelimit"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newLimitsTestServer(t *testing.T, cfg middleware.RateLimitConfig, maxBody int64) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	mux.HandleFunc("GET /books/{isbn}", ok)
+	mux.HandleFunc("POST /books", ok)
+	mux.HandleFunc("GET /healthz", ok)
+
+	h := middleware.NewRateLimiter(cfg).Middleware(mux, mux)
+	h = middleware.NewBodyLimit(maxBody, nil).Middleware(mux, h)
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func send(t *testing.T, method, url, body string) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("リクエストに失敗しました: %v", err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Run("上限を超えたリクエストは429とRetry-After", func(t *testing.T) {
+		server := newLimitsTestServer(t, middleware.RateLimitConfig{
+			Default: ratelimit.Rate{Requests: 2, Period: time.Minute},
+			Exempt:  handler.RateLimitExemptRoutes,
+		}, 1<<20)
+
+		for i := 0; i < 2; i++ {
+			if resp := send(t, http.MethodGet, server.URL+"/books/978-4-00-111111-1", ""); resp.StatusCode != http.StatusOK {
+				t.Fatalf("%d回目のステータスコードが不正です: %d", i+1, resp.StatusCode)
+			}
+		}
+		resp := send(t, http.MethodGet, server.URL+"/books/978-4-00-111111-1", "")
+		if resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("ステータスコードが不正です: %d", resp.StatusCode)
+		}
+		// 1分に2回のため、次のトークンは30秒後に補充される
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err != nil || seconds < 1 || seconds > 30 {
+			t.Errorf("Retry-Afterが不正です: %q", resp.Header.Get("Retry-After"))
+		}
+
+		// ヘルスチェックは制限しない
+		if resp := send(t, http.MethodGet, server.URL+"/healthz", ""); resp.StatusCode != http.StatusOK {
+			t.Errorf("ヘルスチェックのステータスコードが不正です: %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("ルートごとのレートは他のルートに影響しない", func(t *testing.T) {
+		server := newLimitsTestServer(t, middleware.RateLimitConfig{
+			Routes: map[string]ratelimit.Rate{"POST /books": {Requests: 1, Period: time.Minute}},
+		}, 1<<20)
+
+		if resp := send(t, http.MethodPost, server.URL+"/books", "{}"); resp.StatusCode != http.StatusOK {
+			t.Fatalf("ステータスコードが不正です: %d", resp.StatusCode)
+		}
+		if resp := send(t, http.MethodPost, server.URL+"/books", "{}"); resp.StatusCode != http.StatusTooManyRequests {
+			t.Errorf("ルートの上限を超えたステータスコードが不正です: %d", resp.StatusCode)
+		}
+		if resp := send(t, http.MethodGet, server.URL+"/books/978-4-00-111111-1", ""); resp.StatusCode != http.StatusOK {
+			t.Errorf("他のルートのステータスコードが不正です: %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("X-Forwarded-Forを信頼する場合はクライアントごとに区別する", func(t *testing.T) {
+		server := newLimitsTestServer(t, middleware.RateLimitConfig{
+			Default:           ratelimit.Rate{Requests: 1, Period: time.Minute},
+			TrustForwardedFor: true,
+		}, 1<<20)
+
+		for _, ip := range []string{"192.0.2.1", "192.0.2.2"} {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+"/books/978-4-00-111111-1", nil)
+			req.Header.Set("X-Forwarded-For", ip)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("リクエストに失敗しました: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("%s のステータスコードが不正です: %d", ip, resp.StatusCode)
+			}
+		}
+	})
+}
+
+func TestAuthFailureRateLimit(t *testing.T) {
+	apiKeys, err := auth.NewAPIKeyAuthenticator("reader:viewer:viewer-key")
+	if err != nil {
+		t.Fatalf("APIキーの設定に失敗しました: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /books/{isbn}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	// provideHTTPServerと同じく、アクターごとのレート制限は認証の後、認証の失敗のレート制限は認証の前に適用する
+	limiter := middleware.NewRateLimiter(middleware.RateLimitConfig{
+		Default:      ratelimit.Rate{Requests: 100, Period: time.Minute},
+		AuthFailures: ratelimit.Rate{Requests: 3, Period: time.Minute},
+	})
+	h := limiter.Middleware(mux, mux)
+	h = middleware.NewAuth([]auth.Authenticator{apiKeys}, handler.RouteRoles).Middleware(mux, h)
+	h = limiter.AuthFailureMiddleware(mux, h)
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+
+	get := func(apiKey string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/books/978-4-00-111111-1", nil)
+		if apiKey != "" {
+			req.Header.Set(middleware.APIKeyHeader, apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("リクエストに失敗しました: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	// 認証に成功したリクエストは認証の失敗の上限を消費しない
+	for i := 0; i < 5; i++ {
+		if resp := get("viewer-key"); resp.StatusCode != http.StatusOK {
+			t.Fatalf("%d回目の認証されたリクエストのステータスコードが不正です: %d", i+1, resp.StatusCode)
+		}
+	}
+
+	// 不正な認証情報と認証情報なしのリクエストは、上限までは401、上限を超えると429になる
+	for i, key := range []string{"wrong-key", "", "wrong-key"} {
+		if resp := get(key); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("%d回目の認証に失敗したリクエストのステータスコードが不正です: %d", i+1, resp.StatusCode)
+		}
+	}
+	resp := get("wrong-key")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("上限を超えたステータスコードが不正です: %d", resp.StatusCode)
+	}
+	// 1分に3回のため、次のトークンは20秒後に補充される
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err != nil || seconds < 1 || seconds > 20 {
+		t.Errorf("Retry-Afterが不正です: %q", resp.Header.Get("Retry-After"))
+	}
+
+	// 上限を超えたIPアドレスからは、トークンが補充されるまで認証を試せない
+	if resp := get("viewer-key"); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("上限を超えたIPアドレスからのリクエストのステータスコードが不正です: %d", resp.StatusCode)
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	server := newLimitsTestServer(t, middleware.RateLimitConfig{}, 16)
+
+	if resp := send(t, http.MethodPost, server.URL+"/books", `{"title":"ok"}`); resp.StatusCode != http.StatusOK {
+		t.Errorf("上限以内のステータスコードが不正です: %d", resp.StatusCode)
+	}
+	if resp := send(t, http.MethodPost, server.URL+"/books", `{"title":"too large body"}`); resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("上限を超えたステータスコードが不正です: %d", resp.StatusCode)
+	}
+}
+
+func TestParseRouteRates(t *testing.T) {
+	rates, err := ratelimit.ParseRouteRates("POST /books=60/1m, POST /books/import=5/1h")
+	if err != nil {
+		t.Fatalf("読み込みに失敗しました: %v", err)
+	}
+	if got := rates["POST /books/import"]; got != (ratelimit.Rate{Requests: 5, Period: time.Hour}) {
+		t.Errorf("レートが不正です: %v", got)
+	}
+	for _, spec := range []string{"POST /books", "POST /books=0/1m", "POST /books=10", "POST /books=1/1m,POST /books=2/1m"} {
+		if _, err := ratelimit.ParseRouteRates(spec); err == nil {
+			t.Errorf("%q はエラーになる必要があります", spec)
+		}
+	}
+}
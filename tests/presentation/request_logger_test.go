@@ -0,0 +1,103 @@
+package presentation_test
+
+import (
+	"bytes"
+	"ddd-hands-on-go/cmd/api/handler"
+	"ddd-hands-on-go/cmd/api/middleware"
+	"ddd-hands-on-go/internal/application/book"
+	domain_book "ddd-hands-on-go/internal/domain/model/book"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/infrastructure/event"
+	"ddd-hands-on-go/internal/infrastructure/subscriber"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// logLines はJSON形式のログを1行ずつ解析します。
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("ログの解析に失敗しました: %v: %s", err, line)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	repo := &mockBookRepository{books: make(map[string]*domain_book.Book)}
+	txManager := &mockTransactionManager{}
+	refCheck, assembler := newBookReferences()
+	emitter := event.NewEventEmitter()
+	emitter.Subscribe("BookCreated", subscriber.NewLogSubscriber(logger).Subscribe)
+
+	bookHandler := handler.NewBookHandler(
+		book.NewRegisterBookApplicationService(repo, txManager, service.NewISBNDuplicationCheckDomainService(repo), refCheck, assembler, emitter),
+		book.NewGetBookApplicationService(repo, assembler),
+		book.NewUpdateBookApplicationService(repo, txManager, assembler, emitter),
+		book.NewDeleteBookApplicationService(repo, txManager, emitter),
+		book.NewAdjustStockApplicationService(repo, txManager, assembler, emitter),
+	)
+	mux := http.NewServeMux()
+	bookHandler.RegisterRoutes(mux)
+	server := httptest.NewServer(middleware.NewRequestLogger(logger).Middleware(mux))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		isbn          string
+		requestID     string
+		wantRequestID func(string) bool
+	}{
+		{"クライアントのリクエストIDを引き継ぐ", "978-4-00-111111-1", "req-123", func(id string) bool { return id == "req-123" }},
+		{"不正なリクエストIDは生成し直す", "978-4-00-222222-2", `bad id "x"`, func(id string) bool { return len(id) == 32 }},
+		{"リクエストIDがなければ生成する", "978-4-00-333333-3", "", func(id string) bool { return len(id) == 32 }},
+	}
+
+	for _, tt := range tests {
+		buf.Reset()
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/books",
+			strings.NewReader(`{"isbn":"`+tt.isbn+`","title":"Test Book","price":1500}`))
+		req.Header.Set("Content-Type", "application/json")
+		if tt.requestID != "" {
+			req.Header.Set(middleware.RequestIDHeader, tt.requestID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: リクエストに失敗しました: %v", tt.name, err)
+		}
+		resp.Body.Close()
+
+		requestID := resp.Header.Get(middleware.RequestIDHeader)
+		if !tt.wantRequestID(requestID) {
+			t.Errorf("%s: レスポンスのリクエストIDが不正です: %q", tt.name, requestID)
+		}
+
+		// サブスクライバーのログの相関ID、アプリケーションサービスとアクセスログのリクエストIDが一致する
+		var correlated, serviceLog, accessLog bool
+		for _, line := range logLines(t, &buf) {
+			switch line["msg"] {
+			case "書籍が作成されました":
+				correlated = line["correlation_id"] == requestID
+			case "書籍を登録しました":
+				serviceLog = line["request_id"] == requestID
+			case "リクエストを処理しました":
+				accessLog = line["request_id"] == requestID && line["status"] == float64(http.StatusCreated)
+			}
+		}
+		if !correlated || !serviceLog || !accessLog {
+			t.Errorf("%s: ログをリクエストIDで結び付けられません (イベント=%t, サービス=%t, アクセスログ=%t):\n%s",
+				tt.name, correlated, serviceLog, accessLog, buf.String())
+		}
+	}
+}
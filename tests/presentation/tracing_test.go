@@ -0,0 +1,99 @@
+package presentation_test
+
+import (
+	"ddd-hands-on-go/cmd/api/handler"
+	"ddd-hands-on-go/cmd/api/middleware"
+	"ddd-hands-on-go/internal/application/book"
+	"ddd-hands-on-go/internal/domain/service"
+	"ddd-hands-on-go/internal/domain/shared"
+	"ddd-hands-on-go/internal/infrastructure/event"
+	"ddd-hands-on-go/internal/infrastructure/memory"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(prev)
+
+	store := memory.NewStore()
+	repo := memory.NewInMemoryBookRepository(store)
+	txManager := memory.NewInMemoryTransactionManager(store)
+	refCheck, assembler := newBookReferences()
+	emitter := event.NewEventEmitter()
+	emitter.Subscribe("BookCreated", func(shared.DomainEvent) {})
+
+	bookHandler := handler.NewBookHandler(
+		book.NewRegisterBookApplicationService(repo, txManager, service.NewISBNDuplicationCheckDomainService(repo), refCheck, assembler, emitter),
+		book.NewGetBookApplicationService(repo, assembler),
+		book.NewUpdateBookApplicationService(repo, txManager, assembler, emitter),
+		book.NewDeleteBookApplicationService(repo, txManager, emitter),
+		book.NewAdjustStockApplicationService(repo, txManager, assembler, emitter),
+	)
+	mux := http.NewServeMux()
+	bookHandler.RegisterRoutes(mux)
+	server := httptest.NewServer(middleware.Tracing(mux, mux))
+	defer server.Close()
+
+	// クライアントから受け取ったトレースを引き継ぐ
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/books",
+		strings.NewReader(`{"isbn":"978-4-00-111111-1","title":"Test Book","price":1500}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("リクエストに失敗しました: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("ステータスコードが不正です: %d", resp.StatusCode)
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		spans[s.Name()] = s
+	}
+
+	// 子のスパン名 -> 親のスパン名
+	parents := map[string]string{
+		"POST /books":                            "",
+		"BookHandler.RegisterBook":               "POST /books",
+		"RegisterBookApplicationService.Execute": "BookHandler.RegisterBook",
+		"InMemoryTransactionManager.Begin":       "RegisterBookApplicationService.Execute",
+		"BookCreated process":                    "InMemoryTransactionManager.Begin",
+	}
+	for name, parent := range parents {
+		s, ok := spans[name]
+		if !ok {
+			t.Errorf("スパン %s が記録されていません: %v", name, spanNames(recorder.Ended()))
+			continue
+		}
+		if got := s.SpanContext().TraceID().String(); got != traceID {
+			t.Errorf("%s: トレースIDが引き継がれていません: %s", name, got)
+		}
+		if parent == "" {
+			continue
+		}
+		if p, ok := spans[parent]; ok && s.Parent().SpanID() != p.SpanContext().SpanID() {
+			t.Errorf("%s の親が %s ではありません", name, parent)
+		}
+	}
+}
+
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for _, s := range spans {
+		names = append(names, s.Name())
+	}
+	return names
+}